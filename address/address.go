@@ -0,0 +1,128 @@
+// Package address encodes and validates base58check blockchain addresses. An address
+// is a version byte identifying the network and address kind, the 20-byte
+// RIPEMD-160(SHA-256) hash of a public key (or of a multisig key set) and a 4-byte
+// double SHA-256 checksum.
+package address
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+)
+
+const HASH_LEN = 20
+
+var (
+	ErrFormat   = errors.New("address: malformed base58check encoding")
+	ErrChecksum = errors.New("address: checksum mismatch")
+	ErrLength   = errors.New("address: invalid length")
+	ErrVersion  = errors.New("address: unknown version byte")
+	ErrNetwork  = errors.New("address: belongs to a different network")
+)
+
+// Network holds the version bytes of a network: Version prefixes single-key addresses,
+// MultisigVersion multisig addresses and KeyVersion base58check encoded private keys.
+type Network struct {
+	Name            string
+	Version         byte
+	MultisigVersion byte
+	KeyVersion      byte
+}
+
+var (
+	Mainnet = Network{Name: "mainnet", Version: 0x00, MultisigVersion: 0x05, KeyVersion: 0x80} // addresses start with 1, multisig with 3
+	Testnet = Network{Name: "testnet", Version: 0x6f, MultisigVersion: 0xc4, KeyVersion: 0xef} // addresses start with m or n, multisig with 2
+	Regtest = Network{Name: "regtest", Version: 0x3c, MultisigVersion: 0x7a, KeyVersion: 0xbc} // addresses start with R, multisig with r
+
+	networks = []Network{Mainnet, Testnet, Regtest}
+
+	// active is the network this node runs on; it is set once at startup.
+	active = Mainnet
+)
+
+// NetworkByKeyVersion finds the network of a base58check private key version byte.
+func NetworkByKeyVersion(version byte) (Network, error) {
+	for _, n := range networks {
+		if n.KeyVersion == version {
+			return n, nil
+		}
+	}
+	return Network{}, ErrVersion
+}
+
+func NetworkByName(name string) (Network, error) {
+	for _, n := range networks {
+		if n.Name == name {
+			return n, nil
+		}
+	}
+	return Network{}, fmt.Errorf("address: unknown network %q", name)
+}
+
+// SetNetwork selects the network addresses are created and validated for. It must be
+// called before any wallet is created.
+func SetNetwork(n Network) {
+	active = n
+}
+
+func ActiveNetwork() Network {
+	return active
+}
+
+type Address struct {
+	Network  Network
+	Multisig bool // Hash is of a multisig key set, see FromMultisig
+	Hash     [HASH_LEN]byte
+}
+
+// FromHash builds an address of the active network from a public key hash.
+func FromHash(hash []byte) (Address, error) {
+	if len(hash) != HASH_LEN {
+		return Address{}, ErrLength
+	}
+	a := Address{Network: active}
+	copy(a.Hash[:], hash)
+	return a, nil
+}
+
+func (a Address) String() string {
+	if a.Multisig {
+		return base58.CheckEncode(a.Hash[:], a.Network.MultisigVersion)
+	}
+	return base58.CheckEncode(a.Hash[:], a.Network.Version)
+}
+
+// Parse decodes a single-key or multisig address of any known network.
+func Parse(s string) (Address, error) {
+	hash, version, err := base58.CheckDecode(s)
+	switch {
+	case errors.Is(err, base58.ErrChecksum):
+		return Address{}, ErrChecksum
+	case err != nil:
+		return Address{}, ErrFormat
+	case len(hash) != HASH_LEN:
+		return Address{}, ErrLength
+	}
+
+	for _, n := range networks {
+		if n.Version == version || n.MultisigVersion == version {
+			a := Address{Network: n, Multisig: n.MultisigVersion == version}
+			copy(a.Hash[:], hash)
+			return a, nil
+		}
+	}
+	return Address{}, ErrVersion
+}
+
+// Validate checks that s is a well-formed address of the active network.
+func Validate(s string) error {
+	a, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	if a.Network != active {
+		return ErrNetwork
+	}
+	return nil
+}
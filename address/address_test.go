@@ -0,0 +1,110 @@
+package address
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		a    Address
+		want string
+	}{
+		{Address{Network: Mainnet}, "1111111111111111111114oLvT2"},
+		{Address{Network: Testnet}, "mfWxJ45yp2SFn7UciZyNpvDKrzbhyfKrY8"},
+		{Address{Network: Mainnet, Multisig: true}, "31h1vYVSYuKP6AhS86fbRdMw9XHieotbST"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.String(); got != tt.want {
+			t.Errorf("%s address = %s, want %s", tt.a.Network.Name, got, tt.want)
+		}
+		a, err := Parse(tt.want)
+		if err != nil || a != tt.a {
+			t.Errorf("Parse(%s) = %+v, %v", tt.want, a, err)
+		}
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	hash := make([]byte, HASH_LEN)
+	for i := range hash {
+		hash[i] = byte(i * 13)
+	}
+	tests := []struct {
+		n                Network
+		single, multisig string
+	}{
+		{Mainnet, "1", "3"},
+		{Testnet, "mn", "2"},
+		{Regtest, "R", "r"},
+	}
+	for _, tt := range tests {
+		a := Address{Network: tt.n}
+		copy(a.Hash[:], hash)
+		if s := a.String(); !strings.ContainsAny(s[:1], tt.single) {
+			t.Errorf("%s address %s doesn't start with one of %q", tt.n.Name, s, tt.single)
+		}
+		a.Multisig = true
+		if s := a.String(); !strings.HasPrefix(s, tt.multisig) {
+			t.Errorf("%s multisig address %s doesn't start with %q", tt.n.Name, s, tt.multisig)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := Address{Network: Mainnet, Hash: [HASH_LEN]byte{1, 2, 3}}.String()
+	corrupt := []byte(valid)
+	if corrupt[len(corrupt)-1] == '2' { // still base58, checksum no longer matches
+		corrupt[len(corrupt)-1] = '3'
+	} else {
+		corrupt[len(corrupt)-1] = '2'
+	}
+
+	tests := []struct {
+		s    string
+		want error
+	}{
+		{"", ErrFormat},
+		{"0OIl", ErrFormat},
+		{string(corrupt), ErrChecksum},
+		{"1111111111111111111114oLvT2"[:20], ErrChecksum},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	defer SetNetwork(ActiveNetwork())
+	a := Address{Network: Testnet, Hash: [HASH_LEN]byte{9}}.String()
+
+	SetNetwork(Mainnet)
+	if err := Validate(a); !errors.Is(err, ErrNetwork) {
+		t.Fatalf("Validate of a testnet address on mainnet = %v, want %v", err, ErrNetwork)
+	}
+	SetNetwork(Testnet)
+	if err := Validate(a); err != nil {
+		t.Fatalf("Validate on testnet = %v", err)
+	}
+	if b, err := FromHash(make([]byte, HASH_LEN)); err != nil || b.Network != Testnet {
+		t.Fatalf("FromHash on testnet = %+v, %v", b, err)
+	}
+	if _, err := FromHash(make([]byte, 19)); !errors.Is(err, ErrLength) {
+		t.Fatalf("FromHash of 19 bytes = %v, want %v", err, ErrLength)
+	}
+}
+
+func TestNetworkLookups(t *testing.T) {
+	if n, err := NetworkByName("regtest"); err != nil || n != Regtest {
+		t.Fatalf("NetworkByName(regtest) = %+v, %v", n, err)
+	}
+	if _, err := NetworkByName("simnet"); err == nil {
+		t.Fatal("found an unknown network")
+	}
+	if n, err := NetworkByKeyVersion(0xef); err != nil || n != Testnet {
+		t.Fatalf("NetworkByKeyVersion(0xef) = %+v, %v", n, err)
+	}
+}
@@ -0,0 +1,52 @@
+package address
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"sort"
+)
+
+// MAX_MULTISIG_KEYS bounds N in an M-of-N multisig address.
+const MAX_MULTISIG_KEYS = 15
+
+var (
+	ErrThreshold    = errors.New("address: multisig needs 1 <= required <= keys <= 15")
+	ErrDuplicateKey = errors.New("address: multisig keys must be distinct")
+)
+
+// FromMultisig derives the M-of-N multisig address of publicKeys on the active network
+// from the hash of M, N and the keys, each as its 64-byte X||Y encoding, sorted. The
+// order the keys are given in therefore does not matter.
+func FromMultisig(required int, publicKeys []*ecdsa.PublicKey) (Address, error) {
+	if required < 1 || required > len(publicKeys) || len(publicKeys) > MAX_MULTISIG_KEYS {
+		return Address{}, ErrThreshold
+	}
+
+	keys := make([][]byte, len(publicKeys))
+	for i, pub := range publicKeys {
+		keys[i] = encodePublicKey(pub)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	b := []byte{byte(required), byte(len(keys))}
+	for i, k := range keys {
+		if i > 0 && bytes.Equal(k, keys[i-1]) {
+			return Address{}, ErrDuplicateKey
+		}
+		b = append(b, k...)
+	}
+
+	a := Address{Network: active, Multisig: true}
+	copy(a.Hash[:], hash160(b))
+	return a, nil
+}
+
+// SortPublicKeys orders publicKeys the way FromMultisig hashes them.
+func SortPublicKeys(publicKeys []*ecdsa.PublicKey) {
+	sort.Slice(publicKeys, func(i, j int) bool {
+		return bytes.Compare(encodePublicKey(publicKeys[i]), encodePublicKey(publicKeys[j])) < 0
+	})
+}
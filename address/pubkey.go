@@ -0,0 +1,55 @@
+package address
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+// FromPublicKey derives the address of a public key on the active network from the
+// RIPEMD-160(SHA-256) hash of its X and Y coordinates, each as 32 big-endian bytes
+// (the SEC1 uncompressed encoding without its 0x04 prefix).
+//
+// Test vectors (mainnet):
+//   - private key 1, the base point: 1H9ysxkbjve5xCgsooBQLxWbPjD77AHuCC under both
+//     FromPublicKey and LegacyFromPublicKey
+//   - private key 43, whose Y coordinate starts with a zero byte:
+//     1Aa8RWWNFVCB9S8Tzkqj574bEhqVD34uuy, legacy 14fQ8vY828W9jfipYKm3s4WU8hU2Jmp1z3
+func FromPublicKey(publicKey *ecdsa.PublicKey) Address {
+	a := Address{Network: active}
+	copy(a.Hash[:], hash160(encodePublicKey(publicKey)))
+	return a
+}
+
+// LegacyFromPublicKey derives addresses the way wallets did before FromPublicKey,
+// hashing the minimal big-endian bytes of X and Y. Those drop leading zero bytes, so
+// for about 1 in 128 keys the result differs from FromPublicKey. It is kept only so
+// funds held by such addresses can still be spent.
+//
+// Deprecated: use FromPublicKey.
+func LegacyFromPublicKey(publicKey *ecdsa.PublicKey) Address {
+	a := Address{Network: active}
+	copy(a.Hash[:], hash160(append(publicKey.X.Bytes(), publicKey.Y.Bytes()...)))
+	return a
+}
+
+// BelongsTo reports whether s is the address of publicKey under either derivation.
+func BelongsTo(s string, publicKey *ecdsa.PublicKey) bool {
+	return s == FromPublicKey(publicKey).String() || s == LegacyFromPublicKey(publicKey).String()
+}
+
+// encodePublicKey returns X and Y of publicKey, each as 32 big-endian bytes.
+func encodePublicKey(publicKey *ecdsa.PublicKey) []byte {
+	b := make([]byte, 64)
+	publicKey.X.FillBytes(b[:32])
+	publicKey.Y.FillBytes(b[32:])
+	return b
+}
+
+func hash160(b []byte) []byte {
+	digest := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(digest[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,43 @@
+package address
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+)
+
+// publicKey returns the P-256 public key of the private key d.
+func publicKey(d int64) *ecdsa.PublicKey {
+	c := elliptic.P256()
+	x, y := c.ScalarBaseMult(big.NewInt(d).FillBytes(make([]byte, 32)))
+	return &ecdsa.PublicKey{Curve: c, X: x, Y: y}
+}
+
+func TestFromPublicKey(t *testing.T) {
+	defer SetNetwork(ActiveNetwork())
+	SetNetwork(Mainnet)
+
+	tests := []struct {
+		d               int64
+		address, legacy string
+	}{
+		{1, "1H9ysxkbjve5xCgsooBQLxWbPjD77AHuCC", "1H9ysxkbjve5xCgsooBQLxWbPjD77AHuCC"},
+		{43, "1Aa8RWWNFVCB9S8Tzkqj574bEhqVD34uuy", "14fQ8vY828W9jfipYKm3s4WU8hU2Jmp1z3"},
+	}
+	for _, tt := range tests {
+		pub := publicKey(tt.d)
+		if got := FromPublicKey(pub).String(); got != tt.address {
+			t.Errorf("address of private key %d = %s, want %s", tt.d, got, tt.address)
+		}
+		if got := LegacyFromPublicKey(pub).String(); got != tt.legacy {
+			t.Errorf("legacy address of private key %d = %s, want %s", tt.d, got, tt.legacy)
+		}
+		if !BelongsTo(tt.address, pub) || !BelongsTo(tt.legacy, pub) {
+			t.Errorf("addresses of private key %d don't belong to its public key", tt.d)
+		}
+	}
+	if BelongsTo(FromPublicKey(publicKey(2)).String(), publicKey(1)) {
+		t.Error("the address of private key 2 belongs to private key 1")
+	}
+}
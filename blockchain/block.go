@@ -4,7 +4,6 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"log"
-	"time"
 
 	"github.com/zde37/Zero-Chain/transaction"
 )
@@ -13,24 +12,32 @@ type Block struct {
 	Hash         [32]byte                    
 	Nonce        int                         
 	Index        int                         
-	TimeStamp    string                      
+	TimeStamp    int64 // unix seconds, covered by the proof-of-work
 	PreviousHash [32]byte                    
 	Transactions []*transaction.Transaction  
 }
 
-func NewBlock(nonce, previousIndex int, previousHash [32]byte, transactions []*transaction.Transaction) *Block {
+func NewBlock(nonce, previousIndex int, previousHash [32]byte, timestamp int64, transactions []*transaction.Transaction) *Block {
 	b := new(Block)
 	b.Nonce = nonce
 	b.Index = previousIndex + 1
 	b.PreviousHash = previousHash
 	b.Transactions = transactions
-	b.TimeStamp = time.Now().String()
+	b.TimeStamp = timestamp
 
 	b.Hash = b.GenerateHash()
 
 	return b
 }
 
+// ValidHash reports whether b.Hash is the hash of the block's contents, as NewBlock
+// computes it.
+func (b *Block) ValidHash() bool {
+	c := *b
+	c.Hash = [32]byte{}
+	return c.GenerateHash() == b.Hash
+}
+
 func (b *Block) GenerateHash() [32]byte {
 	m, err := json.Marshal(b)
 	if err != nil {
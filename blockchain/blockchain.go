@@ -7,11 +7,13 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/chainparams"
 	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/miner"
 	"github.com/zde37/Zero-Chain/protobuf/protogen"
 	"github.com/zde37/Zero-Chain/transaction"
 	"google.golang.org/grpc"
@@ -19,15 +21,12 @@ import (
 )
 
 const (
-	MINING_DIFFICULTY = 4
-	MINING_SENDER     = "Zero-Chain"
-	MINING_REWARD     = 2.0
-	MINING_TIMER_SEC  = 200
-
-	BLOCKCHAIN_PORT_RANGE_START       = 7000
-	BLOCKCHAIN_PORT_RANGE_END         = 7003
-	NEIGHBOR_IP_RANGE_START           = 0
-	NEIGHBOR_IP_RANGE_END             = 1
+	MINING_SENDER    = "Zero-Chain"
+	MINING_TIMER_SEC = 200 // interval mode only
+
+	MINING_MODE_CONTINUOUS = "continuous" // start on the next block the network's MinBlockInterval after the tip
+	MINING_MODE_INTERVAL   = "interval"   // start mining every MINING_TIMER_SEC
+
 	BLOCKCHAIN_NEIGHBOR_SYNC_TIME_SEC = 10
 )
 
@@ -36,24 +35,59 @@ type BlockChain struct {
 	MemPool           []*transaction.Transaction
 	BlockChainAddress string
 	Port              uint16
-	mut               sync.Mutex
+	MiningMode        string
+	Params            chainparams.Params
+	mut               sync.RWMutex // guards the chain, its indexes and the memory pool
 	wgConsensus       *sync.WaitGroup
 	wgMining          *sync.WaitGroup
 	transactionChan   chan bool
-	wgBlock           *sync.WaitGroup
 
 	neighbors    []string
 	mutNeighbors sync.Mutex
+
+	txIndex      map[[32]byte]txEntry
+	orphaned     map[[32]byte]txEntry
+	cleared      map[[32]byte]*transaction.Transaction // cleared from the memory pool, not yet seen in a block
+	addressIndex map[string][]txEntry
+	blockIndex   map[[32]byte]*Block
+
+	events   eventBus
+	timeData timeData
+
+	checkpoints map[int][32]byte
+	assumeValid [32]byte
+	syncStats   SyncStats
+	syncMut     sync.Mutex
+
+	miner          *miner.Miner
+	miningMut      sync.Mutex
+	miningTimer    time.Duration      // interval mode only, MINING_TIMER_SEC outside of tests
+	stopMining     context.CancelFunc // nil while mining is stopped
+	cancelSearch   context.CancelFunc // nil between nonce searches
+	templateHeight int
+	blocksFound    int
 }
 
-func New(blockchainAddress string, port uint16) *BlockChain {
+func New(blockchainAddress string, port uint16, miningMode string, params chainparams.Params) *BlockChain {
 	bc := new(BlockChain)
 	bc.BlockChainAddress = blockchainAddress
 	bc.Port = port
+	bc.MiningMode = miningMode
+	bc.Params = params
 	bc.wgConsensus = new(sync.WaitGroup)
-	bc.wgBlock = new(sync.WaitGroup)
 	bc.transactionChan = make(chan bool)
 	bc.wgMining = new(sync.WaitGroup)
+	bc.txIndex = make(map[[32]byte]txEntry)
+	bc.orphaned = make(map[[32]byte]txEntry)
+	bc.cleared = make(map[[32]byte]*transaction.Transaction)
+	bc.addressIndex = make(map[string][]txEntry)
+	bc.blockIndex = make(map[[32]byte]*Block)
+	bc.events.subscribers = make(map[chan Event]struct{})
+	bc.events.queues = make(map[*eventQueue]struct{})
+	bc.timeData.offsets = make(map[string]time.Duration)
+	bc.miner = miner.New(0)
+	bc.miningTimer = MINING_TIMER_SEC * time.Second
+	bc.loadCheckpoints()
 	bc.genesisBlock() 
 	return bc
 }
@@ -61,24 +95,64 @@ func New(blockchainAddress string, port uint16) *BlockChain {
 func (bc *BlockChain) Run() {
 	bc.StartSyncNeighbors()
 	bc.ResolveConflicts()
-	bc.StartMining()
+	if !bc.Params.MineOnDemand {
+		bc.StartMining()
+	}
 }
 
 func(bc *BlockChain) genesisBlock()  {
-	block := new(Block) 
-	block.Hash = block.GenerateHash()
-	block.Index = 0
-	block.PreviousHash = [32]byte{}
-	block.TimeStamp = time.Now().String() 
+	block := GenesisBlock(bc.Params.Genesis)
+	if bc.Params.GenesisHash != "" && fmt.Sprintf("%x", block.Hash) != bc.Params.GenesisHash {
+		log.Fatalf("blockchain: %s genesis block %x does not match %s", bc.Params.Name, block.Hash, bc.Params.GenesisHash)
+	}
+	log.Printf("%s genesis block %x", bc.Params.Name, block.Hash)
 	bc.Chain = append(bc.Chain, block)
+	bc.indexBlock(block)
 }
 
-func (bc *BlockChain) CreateBlock(nonce, previousIndex int, previousHash [32]byte) {
-	block := NewBlock(nonce, previousIndex, previousHash, bc.MemPool)
+// GenesisBlock builds the first block of a network from its description. Every node of
+// the network builds the same block, so chains of the same network share block 0.
+func GenesisBlock(genesis chainparams.Genesis) *Block {
+	transactions := make([]*transaction.Transaction, 0, len(genesis.Allocations))
+	for _, a := range genesis.Allocations {
+		t := transaction.New(MINING_SENDER, a.Address, a.Value, 0, 0)
+		t.TimeStamp = time.Unix(genesis.Timestamp, 0).UTC().String() // not part of the hash
+		transactions = append(transactions, t)
+	}
+	return NewBlock(0, -1, [32]byte{}, genesis.Timestamp, transactions)
+}
+
+func (bc *BlockChain) CreateBlock(nonce, previousIndex int, previousHash [32]byte, timestamp int64) {
+	bc.connectBlock(NewBlock(nonce, previousIndex, previousHash, timestamp, bc.MemPool))
+}
+
+// connectBlock appends block to the chain and removes its transactions from the memory
+// pool here and on the other nodes. The caller must hold bc.mut.
+func (bc *BlockChain) connectBlock(block *Block) {
 	bc.Chain = append(bc.Chain, block)
-	bc.MemPool = []*transaction.Transaction{} // clear memory pool on current blockchain node
+	bc.indexBlock(block)
+
+	confirmed := make(map[[32]byte]bool, len(block.Transactions))
+	for _, t := range block.Transactions {
+		confirmed[t.Hash] = true
+	}
+	memPool := []*transaction.Transaction{}
+	for _, t := range bc.MemPool {
+		if !confirmed[t.Hash] {
+			memPool = append(memPool, t)
+		}
+	}
+	bc.MemPool = memPool
+	bc.publish(Event{Type: EVENT_BLOCK_CONNECTED, Block: block})
+
+	// the neighbors clear their memory pools under their own chain locks, so they are
+	// not waited for while this one is held
+	bc.clearNeighborMemPools()
+}
 
-	bc.wgBlock.Add(len(bc.neighbors))
+// clearNeighborMemPools asks every neighbor to clear its memory pool once a block has
+// been connected here, without waiting for the answers.
+func (bc *BlockChain) clearNeighborMemPools() {
 	ctx := context.Background()
 	for _, n := range bc.neighbors { // clear memory pool on other blockchain nodes
 		go func() {
@@ -98,17 +172,15 @@ func (bc *BlockChain) CreateBlock(nonce, previousIndex int, previousHash [32]byt
 				log.Printf("create-block: failed to clear mempool on %s node: %v", n, err)
 				return
 			}
-			bc.wgBlock.Done()
 			log.Printf("create-block: %s", resp.GetStatus())
 		}()
 	}
-	bc.wgBlock.Wait()
 }
 
 func (bc *BlockChain) SetNeighbors() {
 	bc.neighbors = helpers.FindNeighbors(
-		"127.0.0.1", bc.Port, NEIGHBOR_IP_RANGE_START, NEIGHBOR_IP_RANGE_END,
-		BLOCKCHAIN_PORT_RANGE_START, BLOCKCHAIN_PORT_RANGE_END)
+		"127.0.0.1", bc.Port, bc.Params.NeighborIPRangeStart, bc.Params.NeighborIPRangeEnd,
+		bc.Params.PortRangeStart, bc.Params.PortRangeEnd)
 
 	log.Printf("neighbors: %v", bc.neighbors)
 }
@@ -117,6 +189,7 @@ func (bc *BlockChain) SyncNeighbors() {
 	bc.mutNeighbors.Lock()
 	defer bc.mutNeighbors.Unlock()
 	bc.SetNeighbors()
+	go bc.sampleClocks(bc.neighbors)
 }
 
 func (bc *BlockChain) StartSyncNeighbors() {
@@ -130,103 +203,262 @@ func (bc *BlockChain) CopyMemPool() []*transaction.Transaction {
 	return transactions
 }
 
+// ClearMemPool empties the memory pool once a neighbor has connected a block. Most of
+// the cleared transactions are in that block, so they are only reported as evicted if
+// they are still missing from the chain after the next consensus run.
 func (bc *BlockChain) ClearMemPool() {
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+	for _, t := range bc.MemPool {
+		bc.cleared[t.Hash] = t
+	}
 	bc.MemPool = bc.MemPool[:0]
 }
 
+// evictCleared publishes an eviction for every cleared transaction that didn't make it
+// into the chain. The caller must hold bc.mut.
+func (bc *BlockChain) evictCleared() {
+	for hash, t := range bc.cleared {
+		if _, ok := bc.txIndex[hash]; !ok {
+			bc.publish(Event{Type: EVENT_TX_EVICTED, Transaction: t})
+		}
+		delete(bc.cleared, hash)
+	}
+}
+
 func (bc *BlockChain) ValidProof(nonce int,
-	previousHash [32]byte, transactions []*transaction.Transaction) bool {
-	zeros := strings.Repeat("0", MINING_DIFFICULTY)
+	previousHash [32]byte, timestamp int64, transactions []*transaction.Transaction) bool {
+	return miner.Meets(proofHash(nonce, previousHash, timestamp, transactions), bc.Params.Difficulty)
+}
+
+// proofHash is the hash proof-of-work is done on: the block without its index and hash,
+// so the nonce can be searched before the block exists.
+func proofHash(nonce int, previousHash [32]byte, timestamp int64, transactions []*transaction.Transaction) [32]byte {
 	tryBlock := Block{
 		Nonce:        nonce,
 		PreviousHash: previousHash,
-		TimeStamp:    "",
+		TimeStamp:    timestamp,
 		Transactions: transactions,
 	}
-	tryHashStr := fmt.Sprintf("%x", tryBlock.GenerateHash())
-	return tryHashStr[:MINING_DIFFICULTY] == zeros
-}
-
-func (bc *BlockChain) ProofOfWork() int {
-	transactions := bc.CopyMemPool()
-	previousHash := bc.LastBlock().Hash
-	nonce := 0
-
-	for !bc.ValidProof(nonce, previousHash, transactions) {
-		nonce++
-	}
-	return nonce
+	return tryBlock.GenerateHash()
 }
 
 func (bc *BlockChain) LastBlock() *Block {
 	return bc.Chain[len(bc.Chain)-1]
 }
 
-func (bc *BlockChain) CreateTransaction(ctx context.Context, sender, recipient string, value float32, senderPublicKey *ecdsa.PublicKey, s *helpers.Signature) bool {
-	isTransacted := bc.AddTransaction(sender, recipient, value, senderPublicKey, s) 
+func (bc *BlockChain) CreateTransaction(ctx context.Context, sender, recipient string, value, fee float32, nonce uint64,
+	senderPublicKey *ecdsa.PublicKey, s *helpers.Signature) bool {
+	isTransacted := bc.AddTransaction(sender, recipient, value, fee, nonce, senderPublicKey, s)
 	if isTransacted {
-		publicKeyStr := fmt.Sprintf("%064x%064x", senderPublicKey.X.Bytes(), senderPublicKey.Y.Bytes())
-		signatureStr := s.String()
- 
-		for _, n := range bc.neighbors {
-			go func(ch chan<- bool) {
-				conn, err := grpc.NewClient(
-					n,
-					grpc.WithTransportCredentials(insecure.NewCredentials()),
-				) 
-				if err != nil {
-					log.Printf("create-transaction: failed to create grpc client on %s node: %v", n, err)
-					ch <- false
-					return
-				}
-				defer conn.Close()
- 
-				client := protogen.NewBlockChainServiceClient(conn)
-				resp, err := client.UpdateTransaction(ctx, &protogen.TransactionRequest{
-					SenderBlockchainAddress:    sender,
-					RecipientBlockchainAddress: recipient,
-					SenderPublicKey:            publicKeyStr,
-					Signature:                  signatureStr,
-					Value:                      value,
-				}) 
-				if err != nil {
-					log.Printf("create-transaction: failed to update transaction on %s node: %v", n, err)
-					ch <- false
-					return
-				}
- 
-				ch <- true
-				log.Printf("create-transaction: %s", resp.GetStatus())
-			}(bc.transactionChan)
-		}
+		isTransacted = bc.broadcastTransaction(ctx, &protogen.TransactionRequest{
+			SenderBlockchainAddress:    sender,
+			RecipientBlockchainAddress: recipient,
+			SenderPublicKey:            fmt.Sprintf("%064x%064x", senderPublicKey.X.Bytes(), senderPublicKey.Y.Bytes()),
+			Signature:                  s.String(),
+			Value:                      value,
+			Fee:                        fee,
+			Nonce:                      nonce,
+		})
+	}
+
+	return isTransacted
+}
 
-		isTransacted = <-bc.transactionChan 
+// CreateMultisigTransaction is CreateTransaction for a transaction sent from a multisig
+// address, authorized by ms.
+func (bc *BlockChain) CreateMultisigTransaction(ctx context.Context, sender, recipient string, value, fee float32, nonce uint64,
+	ms *transaction.Multisig) bool {
+	isTransacted := bc.AddMultisigTransaction(sender, recipient, value, fee, nonce, ms)
+	if isTransacted {
+		publicKeys, signatures := ms.Encode()
+		isTransacted = bc.broadcastTransaction(ctx, &protogen.TransactionRequest{
+			SenderBlockchainAddress:    sender,
+			RecipientBlockchainAddress: recipient,
+			Value:                      value,
+			Fee:                        fee,
+			Nonce:                      nonce,
+			RequiredSignatures:         uint32(ms.RequiredSignatures),
+			PublicKeys:                 publicKeys,
+			Signatures:                 signatures,
+		})
 	}
 
 	return isTransacted
 }
 
-func (bc *BlockChain) AddTransaction(senderBlockChainAddress, recipientBlockChainAddress string, value float32,
-	senderPublicKey *ecdsa.PublicKey, s *helpers.Signature) bool {
-	t := transaction.New(senderBlockChainAddress, recipientBlockChainAddress, value)
-	if senderBlockChainAddress == MINING_SENDER { // miner
-		bc.MemPool = append(bc.MemPool, t)
+// broadcastTransaction relays a transaction accepted into the memory pool to the neighbors.
+func (bc *BlockChain) broadcastTransaction(ctx context.Context, tr *protogen.TransactionRequest) bool {
+	if len(bc.neighbors) == 0 { // nobody to tell, and nobody would answer on transactionChan
 		return true
 	}
-	 
-	if bc.VerifyTransactionSignature(senderPublicKey, s, t) { 
-		if senderBlockChainAddress == recipientBlockChainAddress { // this should be checked on the wallet server and frontend and returned to the user
-			log.Println("blockchain: you can't send money to yourself")
-			return false
-		} 
-		if bc.CalculateWalletBalance(senderBlockChainAddress) < value { // this should be checked on the wallet server and frontend and returned to the user
-			log.Println("blockchain: Insufficient funds")
-			return false
-		} 
-		bc.MemPool = append(bc.MemPool, t)
+	for _, n := range bc.neighbors {
+		go func(ch chan<- bool) {
+			conn, err := grpc.NewClient(
+				n,
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			if err != nil {
+				log.Printf("create-transaction: failed to create grpc client on %s node: %v", n, err)
+				ch <- false
+				return
+			}
+			defer conn.Close()
+
+			client := protogen.NewBlockChainServiceClient(conn)
+			resp, err := client.UpdateTransaction(ctx, tr)
+			if err != nil {
+				log.Printf("create-transaction: failed to update transaction on %s node: %v", n, err)
+				ch <- false
+				return
+			}
+
+			ch <- true
+			log.Printf("create-transaction: %s", resp.GetStatus())
+		}(bc.transactionChan)
+	}
+
+	return <-bc.transactionChan
+}
+
+func (bc *BlockChain) AddTransaction(senderBlockChainAddress, recipientBlockChainAddress string, value, fee float32, nonce uint64,
+	senderPublicKey *ecdsa.PublicKey, s *helpers.Signature) bool {
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+	return bc.addTransaction(senderBlockChainAddress, recipientBlockChainAddress, value, fee, nonce, senderPublicKey, s)
+}
+
+// addTransaction is AddTransaction for callers holding bc.mut.
+func (bc *BlockChain) addTransaction(senderBlockChainAddress, recipientBlockChainAddress string, value, fee float32, nonce uint64,
+	senderPublicKey *ecdsa.PublicKey, s *helpers.Signature) bool {
+	t := transaction.New(senderBlockChainAddress, recipientBlockChainAddress, value, fee, nonce)
+	if !validAddresses(senderBlockChainAddress, recipientBlockChainAddress) {
+		return false
+	}
+	t.SenderPublicKey, t.Signature = senderPublicKey, s
+	if !bc.verifyAuthorization(t) {
+		return false
+	}
+
+	return bc.admitTransaction(t)
+}
+
+// AddMultisigTransaction is AddTransaction for a transaction sent from a multisig
+// address: ms must carry the address's keys and threshold, and at least that many of
+// the keys must have signed.
+func (bc *BlockChain) AddMultisigTransaction(senderBlockChainAddress, recipientBlockChainAddress string, value, fee float32, nonce uint64,
+	ms *transaction.Multisig) bool {
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+	t := transaction.New(senderBlockChainAddress, recipientBlockChainAddress, value, fee, nonce)
+	if !validAddresses(senderBlockChainAddress, recipientBlockChainAddress) {
+		return false
+	}
+	t.Multisig = ms
+	if !bc.verifyAuthorization(t) {
+		return false
+	}
+
+	return bc.admitTransaction(t)
+}
+
+func validAddresses(senderBlockChainAddress, recipientBlockChainAddress string) bool {
+	if err := address.Validate(recipientBlockChainAddress); err != nil {
+		log.Printf("blockchain: invalid recipient %s: %v", recipientBlockChainAddress, err)
+		return false
+	}
+	if err := address.Validate(senderBlockChainAddress); err != nil {
+		log.Printf("blockchain: invalid sender %s: %v", senderBlockChainAddress, err)
+		return false
+	}
+	return true
+}
+
+// admitTransaction applies the nonce, replace-by-fee and balance rules to an authorized
+// transaction and adds it to the memory pool.
+func (bc *BlockChain) admitTransaction(t *transaction.Transaction) bool {
+	sender := t.SenderBlockChainAddress
+
+	// a pending transaction with the same sender and nonce is replaced if the new one pays a higher fee
+	pending := bc.pendingTransactionIndex(sender, t.Nonce)
+	if sender == t.RecipientBlockChainAddress && (pending < 0 || t.Value != 0) { // only a zero-value cancellation may be sent to yourself
+		log.Println("blockchain: you can't send money to yourself")
+		return false
+	}
+	if pending < 0 && t.Nonce != bc.NextNonce(sender) {
+		log.Printf("blockchain: invalid nonce %d for %s", t.Nonce, sender)
+		return false
+	}
+	if pending >= 0 && t.Fee <= bc.MemPool[pending].Fee {
+		log.Printf("blockchain: replacement fee %v must be higher than %v", t.Fee, bc.MemPool[pending].Fee)
+		return false
+	}
+	if bc.SpendableBalance(sender)-bc.pendingCost(sender, pending) < t.Cost() { // this should be checked on the wallet server and frontend and returned to the user
+		log.Println("blockchain: Insufficient funds")
+		return false
+	}
+
+	if pending >= 0 {
+		log.Printf("blockchain: transaction %x replaced by %x", bc.MemPool[pending].Hash, t.Hash)
+		bc.publish(Event{Type: EVENT_TX_EVICTED, Transaction: bc.MemPool[pending]})
+		bc.MemPool[pending] = t
+		bc.publish(Event{Type: EVENT_TX_ACCEPTED, Transaction: t})
 		return true
-	} 
-	return false
+	}
+	bc.MemPool = append(bc.MemPool, t)
+	bc.publish(Event{Type: EVENT_TX_ACCEPTED, Transaction: t})
+	return true
+}
+
+// NextNonce returns the nonce the next transaction sent from blockchainAddress must carry:
+// the number of its confirmed transactions plus the number still waiting in the memory pool.
+func (bc *BlockChain) NextNonce(blockchainAddress string) uint64 {
+	var nonce uint64
+	for _, b := range bc.Chain {
+		for _, t := range b.Transactions {
+			if t.SenderBlockChainAddress == blockchainAddress {
+				nonce++
+			}
+		}
+	}
+	for _, t := range bc.MemPool {
+		if t.SenderBlockChainAddress == blockchainAddress {
+			nonce++
+		}
+	}
+	return nonce
+}
+
+// pendingTransactionIndex returns the memory pool position of the transaction sent
+// from blockchainAddress with the given nonce, or -1 if there is none.
+func (bc *BlockChain) pendingTransactionIndex(blockchainAddress string, nonce uint64) int {
+	for i, t := range bc.MemPool {
+		if t.SenderBlockChainAddress == blockchainAddress && t.Nonce == nonce {
+			return i
+		}
+	}
+	return -1
+}
+
+// pendingCost sums what blockchainAddress has committed to spend in the memory pool,
+// ignoring the transaction at position skip.
+func (bc *BlockChain) pendingCost(blockchainAddress string, skip int) float32 {
+	var cost float32
+	for i, t := range bc.MemPool {
+		if i != skip && t.SenderBlockChainAddress == blockchainAddress {
+			cost += t.Cost()
+		}
+	}
+	return cost
+}
+
+// MemPoolFees sums the fees of every transaction waiting in the memory pool.
+func (bc *BlockChain) MemPoolFees() float32 {
+	var fees float32
+	for _, t := range bc.MemPool {
+		fees += t.Fee
+	}
+	return fees
 }
 
 func (bc *BlockChain) VerifyTransactionSignature(senderPublicKey *ecdsa.PublicKey, s *helpers.Signature, t *transaction.Transaction) bool {
@@ -239,74 +471,125 @@ func (bc *BlockChain) VerifyTransactionSignature(senderPublicKey *ecdsa.PublicKe
 	return ecdsa.Verify(senderPublicKey, hash[:], s.R, s.S)
 }
 
-func (bc *BlockChain) StartMining() {
-	t := time.NewTicker(MINING_TIMER_SEC * time.Second)
-	go func(ticker *time.Ticker) {
-		for range ticker.C {
-			bc.Mining()
-		}
-	}(t) // add ticker.Stop() during graceful shutdown 
+// verifyAuthorization checks that the sender of t signed it, with its own key or with
+// enough of its multisig keys. Block rewards carry no signature; the ledger checks
+// their amounts instead.
+func (bc *BlockChain) verifyAuthorization(t *transaction.Transaction) bool {
+	switch {
+	case t.SenderBlockChainAddress == MINING_SENDER:
+		return true
+	case t.Multisig != nil:
+		return bc.VerifyMultisig(t.Multisig, t)
+	case t.SenderPublicKey == nil || t.Signature == nil:
+		log.Printf("blockchain: transaction %x from %s is not signed", t.Hash, t.SenderBlockChainAddress)
+		return false
+	case !address.BelongsTo(t.SenderBlockChainAddress, t.SenderPublicKey):
+		log.Printf("blockchain: public key does not match sender %s", t.SenderBlockChainAddress)
+		return false
+	}
+	return bc.VerifyTransactionSignature(t.SenderPublicKey, t.Signature, t)
 }
 
-func (bc *BlockChain) Mining() {
-	bc.mut.Lock()
-	defer bc.mut.Unlock()
+// validTransactionHashes checks that every transaction in b carries the hash of its
+// contents. The hash isn't covered by the block hash, but it keys the transaction
+// index, the ledger and webhook deliveries.
+func validTransactionHashes(b *Block) bool {
+	for _, t := range b.Transactions {
+		if t.Hash != t.TxHash() {
+			log.Printf("invalid block %d: transaction hash %x does not match its contents", b.Index, t.Hash)
+			return false
+		}
+	}
+	return true
+}
 
-	bc.AddTransaction(MINING_SENDER, bc.BlockChainAddress, MINING_REWARD, nil, nil)
-	nonce := bc.ProofOfWork()
-	previousHash := bc.LastBlock().Hash
-	previousIndex := bc.LastBlock().Index
-	bc.CreateBlock(nonce, previousIndex, previousHash)
+// validSignatures checks the authorization of every transaction in b.
+func (bc *BlockChain) validSignatures(b *Block) bool {
+	for _, t := range b.Transactions {
+		if !bc.verifyAuthorization(t) {
+			log.Printf("invalid block %d: transaction %x is not authorized by its sender", b.Index, t.Hash)
+			return false
+		}
+	}
+	return true
+}
 
-	bc.wgMining.Add(len(bc.neighbors))
-	ctx := context.Background()
-	for _, n := range bc.neighbors {
-		go func() {
-			conn, err := grpc.NewClient(
-				n,
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
-			)
-			if err != nil {
-				log.Printf("mining: failed to create grpc client on %s node: %v", n, err)
-				return
-			}
-			defer conn.Close()
+// VerifyMultisig checks that ms belongs to the multisig address sending t and that at
+// least its threshold of keys signed t.
+func (bc *BlockChain) VerifyMultisig(ms *transaction.Multisig, t *transaction.Transaction) bool {
+	a, err := ms.Address()
+	if err != nil {
+		log.Printf("blockchain: invalid multisig: %v", err)
+		return false
+	}
+	if a.String() != t.SenderBlockChainAddress {
+		log.Printf("blockchain: multisig keys do not match sender %s", t.SenderBlockChainAddress)
+		return false
+	}
 
-			client := protogen.NewBlockChainServiceClient(conn)
-			resp, err := client.Consensus(ctx, &protogen.Empty{})
-			if err != nil {
-				log.Printf("mining: consensus failed on %s node: %v", n, err)
-				return
-			}
-			bc.wgMining.Done()
-			log.Printf("mining: consensus %s", resp.GetStatus())
-		}()
+	m, err := t.MarshalJSON()
+	if err != nil {
+		log.Printf("blockchain: failed to marshal transaction: %v", err)
+		return false
 	}
-	bc.wgMining.Wait()
+	if valid := ms.ValidSignatures(m); valid < ms.RequiredSignatures {
+		log.Printf("blockchain: %d of %d required multisig signatures for %s", valid, ms.RequiredSignatures, t.SenderBlockChainAddress)
+		return false
+	}
+	return true
 }
 
 func (bc *BlockChain) CalculateWalletBalance(blockchainAddress string) float32 {
 	var totalAmount float32
 	for _, b := range bc.Chain {
 		for _, t := range b.Transactions {
-			value := t.Value
 			if t.RecipientBlockChainAddress == blockchainAddress {
-				totalAmount += value
+				totalAmount += t.Value
 			}
 
 			if t.SenderBlockChainAddress == blockchainAddress {
-				totalAmount -= value
+				totalAmount -= t.Cost()
 			}
 		}
 	}
 	return totalAmount
 }
 
+// SpendableBalance is the balance of blockchainAddress without the block rewards that
+// are not yet mature enough to be spent in the next block.
+func (bc *BlockChain) SpendableBalance(blockchainAddress string) float32 {
+	balance := bc.CalculateWalletBalance(blockchainAddress)
+	next := bc.LastBlock().Index + 1
+	for i := len(bc.Chain) - 1; i > 0 && !bc.Params.Emission.Mature(bc.Chain[i].Index, next); i-- {
+		for _, t := range bc.Chain[i].Transactions {
+			if t.SenderBlockChainAddress == MINING_SENDER && t.RecipientBlockChainAddress == blockchainAddress {
+				balance -= t.Value
+			}
+		}
+	}
+	return balance
+}
+
+// ValidChain checks a chain received from a neighbor block by block. The proof-of-work
+// and transaction signatures of the blocks up to the assume-valid block are not
+// re-checked.
 func (bc *BlockChain) ValidChain(chain []*Block) bool {
+	start := time.Now()
+	assumed := bc.assumedValid(chain)
+	valid := bc.validChain(chain, assumed)
+	bc.recordSync(len(chain)-1, max(assumed, 0), time.Since(start), valid)
+	return valid
+}
+
+func (bc *BlockChain) validChain(chain []*Block, assumed int) bool {
 	preBlock := chain[0]
 	currentIndex := 1
+	if preBlock.Hash != bc.Chain[0].Hash {
+		log.Printf("invalid genesis block: %x, expected %x", preBlock.Hash, bc.Chain[0].Hash)
+		return false
+	}
+	ledger := newLedger(bc.Params.Emission, preBlock)
 
-	// genesis block will always be valid
 	for currentIndex < len(chain) {
 		b := chain[currentIndex]
 
@@ -320,7 +603,26 @@ func (bc *BlockChain) ValidChain(chain []*Block) bool {
 			return false
 		}
 
-		if !bc.ValidProof(b.Nonce, b.PreviousHash, b.Transactions) {
+		if !b.ValidHash() {
+			log.Printf("invalid block %d: hash %x does not match its contents", b.Index, b.Hash)
+			return false
+		}
+		if !bc.checkpointsMatch(b) {
+			return false
+		}
+		if !validTransactionHashes(b) {
+			return false
+		}
+		if currentIndex > assumed && !bc.ValidProof(b.Nonce, b.PreviousHash, b.TimeStamp, b.Transactions) {
+			return false
+		}
+		if currentIndex > assumed && !bc.validSignatures(b) {
+			return false
+		}
+		if !bc.validTimestamp(b, chain[:currentIndex]) {
+			return false
+		}
+		if !ledger.connect(b) {
 			return false
 		}
 		preBlock = b
@@ -331,12 +633,16 @@ func (bc *BlockChain) ValidChain(chain []*Block) bool {
 
 func (bc *BlockChain) ResolveConflicts() bool {
 	var longestChain []*Block = nil
+	var longestMut sync.Mutex
+	bc.mut.RLock()
 	maxLength := len(bc.Chain)
+	bc.mut.RUnlock()
 	bc.wgConsensus.Add(len(bc.neighbors))
 
 	ctx := context.Background()
 	for _, n := range bc.neighbors {
 		go func() {
+			defer bc.wgConsensus.Done()
 			conn, err := grpc.NewClient(
 				n,
 				grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -358,20 +664,32 @@ func (bc *BlockChain) ResolveConflicts() bool {
 				log.Printf("resolve-conflicts: %v", err)
 				return
 			} 
-			if len(chain) > maxLength && bc.ValidChain(chain) {
+			if !bc.ValidChain(chain) {
+				return
+			}
+			longestMut.Lock()
+			if len(chain) > maxLength {
 				maxLength = len(chain)
-				longestChain = chain 
-			} 
-			bc.wgConsensus.Done()
+				longestChain = chain
+			}
+			longestMut.Unlock()
 		}()
 	}
 	bc.wgConsensus.Wait()
-	if longestChain == nil {
+
+	// the chain may have grown while the neighbors' chains were fetched and checked
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+	defer bc.evictCleared()
+	if longestChain == nil || len(longestChain) <= len(bc.Chain) {
 		log.Println("resolve conflicts failed")
 		return false
 	}
 
+	oldChain := bc.Chain
 	bc.Chain = longestChain
+	bc.reindex(oldChain)
+	bc.publishReorg(oldChain)
 	log.Println("resolve conflicts success")
 	return true
 }
@@ -415,13 +733,34 @@ func (bc *BlockChain) convertProtoTransactions(tx []*protogen.Transaction) ([]*t
 			return transactions, fmt.Errorf("blockchain: failed to convert transaction hash: %v", err)
 		}
 
-		transactions = append(transactions, &transaction.Transaction{
+		r := transaction.Request{
+			SenderPublicKey:    t.GetSenderPublicKey(),
+			Signature:          t.GetSignature(),
+			RequiredSignatures: t.GetRequiredSignatures(),
+			PublicKeys:         t.GetPublicKeys(),
+			Signatures:         t.GetSignatures(),
+		}
+		publicKey, signature, ms, err := r.Authorization()
+		if err != nil {
+			return transactions, fmt.Errorf("blockchain: failed to convert transaction %x: %v", hash, err)
+		}
+
+		converted := &transaction.Transaction{
 			SenderBlockChainAddress:    t.GetSenderBlockchainAddress(),
 			RecipientBlockChainAddress: t.GetRecipientBlockchainAddress(),
 			Value:                      t.GetValue(),
+			Fee:                        t.GetFee(),
+			Nonce:                      t.GetNonce(),
 			TimeStamp:                  t.GetTimestamp(),
 			Hash:                       hash,
-		})
+			SenderPublicKey:            publicKey,
+			Signature:                  signature,
+			Multisig:                   ms,
+		}
+		if converted.TxHash() != hash {
+			return transactions, fmt.Errorf("blockchain: transaction hash %x does not match its contents", hash)
+		}
+		transactions = append(transactions, converted)
 	}
 	return transactions, nil
 }
@@ -0,0 +1,571 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// testParams are the mainnet parameters with the lowest difficulty, blocks mined on
+// demand and the given allocations in the genesis block.
+func testParams(allocations ...chainparams.Allocation) chainparams.Params {
+	p := chainparams.Mainnet
+	p.Name = "test"
+	p.GenesisHash = ""
+	p.Checkpoints = nil
+	p.Difficulty = 1
+	p.MineOnDemand = true
+	p.Genesis.Allocations = allocations
+	for _, a := range allocations {
+		p.Emission.GenesisAllocation += float64(a.Value)
+	}
+	return p
+}
+
+// newTestChain returns a chain without neighbors whose genesis block gives each wallet
+// 100 coins.
+func newTestChain(t *testing.T, funded ...*wallet.Wallet) *BlockChain {
+	t.Helper()
+	allocations := make([]chainparams.Allocation, 0, len(funded))
+	for _, w := range funded {
+		allocations = append(allocations, chainparams.Allocation{Address: w.BlockchainAddress, Value: 100})
+	}
+	return New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, testParams(allocations...))
+}
+
+// send signs a transfer from w and adds it to the memory pool.
+func send(bc *BlockChain, w *wallet.Wallet, recipient string, value, fee float32, nonce uint64) bool {
+	md := transaction.NewMetaData(w.PrivateKey, w.PublicKey, w.BlockchainAddress, recipient, value, fee, nonce)
+	return bc.AddTransaction(w.BlockchainAddress, recipient, value, fee, nonce, w.PublicKey, md.GenerateSignature())
+}
+
+// mine connects n blocks paying their rewards to the chain's own address.
+func mine(t *testing.T, bc *BlockChain, n int) []*Block {
+	t.Helper()
+	blocks, ok := bc.GenerateBlocks(context.Background(), n, bc.BlockChainAddress)
+	if !ok {
+		t.Fatalf("generated %d of %d blocks", len(blocks), n)
+	}
+	return blocks
+}
+
+func TestNonces(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	if send(bc, alice, bob.BlockchainAddress, 1, 0.1, 1) {
+		t.Fatal("accepted nonce 1 before nonce 0")
+	}
+	if !send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("rejected nonce 0")
+	}
+	if got := bc.NextNonce(alice.BlockchainAddress); got != 1 {
+		t.Fatalf("next nonce with one pending transaction = %d, want 1", got)
+	}
+	mine(t, bc, 1)
+	if got := bc.NextNonce(alice.BlockchainAddress); got != 1 {
+		t.Fatalf("next nonce with one confirmed transaction = %d, want 1", got)
+	}
+	if send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("accepted a confirmed nonce again")
+	}
+	if got, want := bc.CalculateWalletBalance(alice.BlockchainAddress), float32(100-1.1); got != want {
+		t.Fatalf("balance = %v, want %v", got, want)
+	}
+}
+
+func TestReplaceByFee(t *testing.T) {
+	alice, bob, carol := wallet.New(), wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	if !send(bc, alice, bob.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("rejected the original transaction")
+	}
+	if send(bc, alice, carol.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("accepted a replacement that doesn't pay a higher fee")
+	}
+	if !send(bc, alice, carol.BlockchainAddress, 10, 1, 0) {
+		t.Fatal("rejected a replacement paying a higher fee")
+	}
+	if len(bc.MemPool) != 1 || bc.MemPool[0].RecipientBlockChainAddress != carol.BlockchainAddress {
+		t.Fatalf("memory pool holds %d transactions, want only the replacement", len(bc.MemPool))
+	}
+	if send(bc, alice, bob.BlockchainAddress, 99.5, 0.5, 1) {
+		t.Fatal("accepted a transaction spending more than the pending one left")
+	}
+}
+
+func TestCancelTransaction(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	if send(bc, alice, alice.BlockchainAddress, 0, 1, 0) {
+		t.Fatal("accepted a transfer to the sender with nothing to cancel")
+	}
+	if !send(bc, alice, bob.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("rejected the original transaction")
+	}
+	if send(bc, alice, alice.BlockchainAddress, 5, 1, 0) {
+		t.Fatal("accepted a cancellation carrying a value")
+	}
+	if !send(bc, alice, alice.BlockchainAddress, 0, 1, 0) {
+		t.Fatal("rejected the cancellation")
+	}
+	mine(t, bc, 1)
+	if got := bc.CalculateWalletBalance(bob.BlockchainAddress); got != 0 {
+		t.Fatalf("recipient of the cancelled transaction holds %v", got)
+	}
+	if got, want := bc.CalculateWalletBalance(alice.BlockchainAddress), float32(99); got != want {
+		t.Fatalf("balance after cancellation = %v, want %v", got, want)
+	}
+}
+
+// withTransaction returns a copy of chain whose block i has its first non-reward
+// transaction replaced by the result of edit.
+func withTransaction(chain []*Block, i int, edit func(t transaction.Transaction) *transaction.Transaction) []*Block {
+	copied := append([]*Block(nil), chain...)
+	b := *chain[i]
+	b.Transactions = append([]*transaction.Transaction(nil), b.Transactions...)
+	for j, t := range b.Transactions {
+		if t.SenderBlockChainAddress != MINING_SENDER {
+			b.Transactions[j] = edit(*t)
+			break
+		}
+	}
+	copied[i] = &b
+	return copied
+}
+
+func TestValidChainSignatures(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	if !send(bc, alice, bob.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("rejected a signed transaction")
+	}
+	mine(t, bc, 1)
+	if !bc.ValidChain(bc.Chain) {
+		t.Fatal("rejected a chain of signed transactions")
+	}
+
+	unsigned := withTransaction(bc.Chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.SenderPublicKey, tx.Signature = nil, nil
+		return &tx
+	})
+	if bc.ValidChain(unsigned) {
+		t.Fatal("accepted a block with an unsigned transaction")
+	}
+
+	forged := withTransaction(bc.Chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		md := transaction.NewMetaData(bob.PrivateKey, bob.PublicKey, tx.SenderBlockChainAddress, tx.RecipientBlockChainAddress, tx.Value, tx.Fee, tx.Nonce)
+		tx.SenderPublicKey, tx.Signature = bob.PublicKey, md.GenerateSignature()
+		return &tx
+	})
+	if bc.ValidChain(forged) {
+		t.Fatal("accepted a block with a transaction signed by a key other than the sender's")
+	}
+
+	tampered := withTransaction(bc.Chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.Value = 90
+		return &tx
+	})
+	if bc.ValidChain(tampered) {
+		t.Fatal("accepted a block with a transaction changed after it was signed")
+	}
+}
+
+func TestValidChainTransactionHashes(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	if !send(bc, alice, bob.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("rejected a signed transaction")
+	}
+	mine(t, bc, 1)
+
+	// A different hash would let a neighbor's transaction collide with, or dodge, the
+	// ledger's replay check and the transaction index.
+	tampered := withTransaction(bc.Chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.Hash = sha256.Sum256([]byte("another transaction"))
+		return &tx
+	})
+	if bc.ValidChain(tampered) {
+		t.Fatal("accepted a block with a transaction whose hash does not match its contents")
+	}
+
+	var tx *transaction.Transaction
+	for _, t := range bc.Chain[1].Transactions {
+		if t.SenderBlockChainAddress == alice.BlockchainAddress {
+			tx = t
+		}
+	}
+	r := tx.Request()
+	pb := &protogen.Transaction{
+		SenderBlockchainAddress:    r.SenderBlockchainAddress,
+		RecipientBlockchainAddress: r.RecipientBlockchainAddress,
+		Value:                      r.Value,
+		Fee:                        r.Fee,
+		Nonce:                      r.Nonce,
+		Hash:                       fmt.Sprintf("%x", tx.Hash),
+		Timestamp:                  tx.TimeStamp,
+		SenderPublicKey:            r.SenderPublicKey,
+		Signature:                  r.Signature,
+	}
+	if _, err := bc.convertProtoTransactions([]*protogen.Transaction{pb}); err != nil {
+		t.Fatal(err)
+	}
+	pb.Hash = fmt.Sprintf("%x", sha256.Sum256([]byte("another transaction")))
+	if _, err := bc.convertProtoTransactions([]*protogen.Transaction{pb}); err == nil {
+		t.Fatal("converted a neighbor's transaction whose hash does not match its contents")
+	}
+}
+
+func TestValidChainMultisig(t *testing.T) {
+	keys := []*wallet.Wallet{wallet.New(), wallet.New(), wallet.New()}
+	publicKeys := []*ecdsa.PublicKey{keys[0].PublicKey, keys[1].PublicKey, keys[2].PublicKey}
+	a, err := address.FromMultisig(2, publicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, recipient := a.String(), wallet.New().BlockchainAddress
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS,
+		testParams(chainparams.Allocation{Address: sender, Value: 100}))
+
+	sign := func(signers ...int) *transaction.Multisig {
+		ms := &transaction.Multisig{RequiredSignatures: 2, PublicKeys: publicKeys, Signatures: make([]*helpers.Signature, len(keys))}
+		for _, i := range signers {
+			md := transaction.NewMetaData(keys[i].PrivateKey, keys[i].PublicKey, sender, recipient, 10, 0.5, 0)
+			ms.Signatures[i] = md.GenerateSignature()
+		}
+		return ms
+	}
+	if bc.AddMultisigTransaction(sender, recipient, 10, 0.5, 0, sign(1)) {
+		t.Fatal("accepted a transaction signed by one of two required keys")
+	}
+	if !bc.AddMultisigTransaction(sender, recipient, 10, 0.5, 0, sign(0, 2)) {
+		t.Fatal("rejected a transaction signed by two of three keys")
+	}
+	mine(t, bc, 1)
+
+	// Send the chain's transactions through their protobuf form, as neighbors do.
+	chain := append([]*Block(nil), bc.Chain...)
+	for i, b := range chain {
+		pb := make([]*protogen.Transaction, len(b.Transactions))
+		for j, tx := range b.Transactions {
+			r := tx.Request()
+			pb[j] = &protogen.Transaction{
+				SenderBlockchainAddress:    r.SenderBlockchainAddress,
+				RecipientBlockchainAddress: r.RecipientBlockchainAddress,
+				Value:                      r.Value,
+				Fee:                        r.Fee,
+				Nonce:                      r.Nonce,
+				Hash:                       fmt.Sprintf("%x", tx.Hash),
+				Timestamp:                  tx.TimeStamp,
+				SenderPublicKey:            r.SenderPublicKey,
+				Signature:                  r.Signature,
+				RequiredSignatures:         r.RequiredSignatures,
+				PublicKeys:                 r.PublicKeys,
+				Signatures:                 r.Signatures,
+			}
+		}
+		transactions, err := bc.convertProtoTransactions(pb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		converted := *b
+		converted.Transactions = transactions
+		chain[i] = &converted
+	}
+	if !bc.ValidChain(chain) {
+		t.Fatal("rejected a multisig transaction received from a neighbor")
+	}
+
+	underSigned := withTransaction(chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.Multisig = sign(1)
+		return &tx
+	})
+	if bc.ValidChain(underSigned) {
+		t.Fatal("accepted a block with a multisig transaction below its threshold")
+	}
+}
+
+// TestValidChainReplay replays a confirmed transaction, and sends new ones out of nonce
+// order, from a single-key and a multisig sender.
+func TestValidChainReplay(t *testing.T) {
+	keys := []*wallet.Wallet{wallet.New(), wallet.New(), wallet.New()}
+	publicKeys := []*ecdsa.PublicKey{keys[0].PublicKey, keys[1].PublicKey, keys[2].PublicKey}
+	a, err := address.FromMultisig(2, publicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multisig, alice, recipient := a.String(), wallet.New(), wallet.New().BlockchainAddress
+
+	// each sender signs transfers to recipient with a 0.5 fee
+	senders := []struct {
+		name    string
+		address string
+		sign    func(tx *transaction.Transaction)
+	}{
+		{"single key", alice.BlockchainAddress, func(tx *transaction.Transaction) {
+			md := transaction.NewMetaData(alice.PrivateKey, alice.PublicKey, tx.SenderBlockChainAddress, tx.RecipientBlockChainAddress, tx.Value, tx.Fee, tx.Nonce)
+			tx.SenderPublicKey, tx.Signature = alice.PublicKey, md.GenerateSignature()
+		}},
+		{"multisig", multisig, func(tx *transaction.Transaction) {
+			tx.Multisig = &transaction.Multisig{RequiredSignatures: 2, PublicKeys: publicKeys, Signatures: make([]*helpers.Signature, len(keys))}
+			for _, i := range []int{0, 2} {
+				md := transaction.NewMetaData(keys[i].PrivateKey, keys[i].PublicKey, tx.SenderBlockChainAddress, tx.RecipientBlockChainAddress, tx.Value, tx.Fee, tx.Nonce)
+				tx.Multisig.Signatures[i] = md.GenerateSignature()
+			}
+		}},
+	}
+	for _, sender := range senders {
+		signed := func(value float32, nonce uint64) *transaction.Transaction {
+			tx := transaction.New(sender.address, recipient, value, 0.5, nonce)
+			sender.sign(tx)
+			return tx
+		}
+
+		bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS,
+			testParams(chainparams.Allocation{Address: sender.address, Value: 100}))
+		tx := signed(10, 0)
+		if tx.Multisig != nil {
+			if !bc.AddMultisigTransaction(tx.SenderBlockChainAddress, recipient, tx.Value, tx.Fee, tx.Nonce, tx.Multisig) {
+				t.Fatalf("%s: rejected the transaction", sender.name)
+			}
+		} else if !bc.AddTransaction(tx.SenderBlockChainAddress, recipient, tx.Value, tx.Fee, tx.Nonce, tx.SenderPublicKey, tx.Signature) {
+			t.Fatalf("%s: rejected the transaction", sender.name)
+		}
+		mine(t, bc, 1)
+		confirmed := bc.Chain[1].Transactions[0]
+
+		chain := append([]*Block(nil), bc.Chain...)
+		if next := append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{signed(5, 1)})); !bc.ValidChain(next) {
+			t.Fatalf("%s: rejected the sender's next nonce", sender.name)
+		}
+		for name, invalid := range map[string][]*Block{
+			"replayed in a later block": append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{confirmed})),
+			"included twice in a block": append(chain[:1:1], solveBlock(bc, chain[0], []*transaction.Transaction{confirmed, confirmed})),
+			"reused nonce":              append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{signed(5, 0)})),
+			"skipped nonce":             append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{signed(5, 2)})),
+		} {
+			if bc.ValidChain(invalid) {
+				t.Errorf("%s: accepted a transaction %s", sender.name, name)
+			}
+		}
+	}
+}
+
+func TestSearchStopsWhenMemPoolChanges(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	bc.mut.Lock()
+	template, _, _ := bc.blockTemplate(bc.BlockChainAddress)
+	bc.mut.Unlock()
+	template.Difficulty = 64 // never met
+	done := make(chan error, 1)
+	go func() {
+		_, err := bc.searchNonce(context.Background(), template)
+		done <- err
+	}()
+
+	// wait for the search to subscribe before changing the memory pool
+	subscribed := func() bool {
+		bc.events.mut.Lock()
+		defer bc.events.mut.Unlock()
+		return len(bc.events.subscribers) > 0
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !subscribed() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("rejected the transaction")
+	}
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("search found a nonce for an unreachable difficulty")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("search kept running on a stale template")
+	}
+}
+
+func TestContinuousMiningInterval(t *testing.T) {
+	bc := newTestChain(t)
+	if wait := bc.untilNextBlock(); wait > 0 {
+		t.Fatalf("waiting %v to mine on an old genesis block", wait)
+	}
+	if !bc.StartMining() {
+		t.Fatal("mining did not start")
+	}
+	defer bc.StopMining()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for bc.MiningStatus().BlocksFound == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if wait := bc.untilNextBlock(); wait <= bc.Params.MinBlockInterval-5*time.Second {
+		t.Fatalf("waiting %v after a fresh block, want about %v", wait, bc.Params.MinBlockInterval)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := bc.MiningStatus().BlocksFound; got != 1 {
+		t.Fatalf("found %d blocks right after one another, want 1", got)
+	}
+}
+
+// waitBlocksFound waits up to timeout for the miner to have found n blocks and returns
+// how many it found.
+func waitBlocksFound(bc *BlockChain, n int, timeout time.Duration) int {
+	deadline := time.Now().Add(timeout)
+	for bc.MiningStatus().BlocksFound < n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return bc.MiningStatus().BlocksFound
+}
+
+// TestContinuousMiningMinimumInterval mines two blocks in a row with a short minimum
+// interval: the second is only searched for once the interval has passed.
+func TestContinuousMiningMinimumInterval(t *testing.T) {
+	p := testParams()
+	p.MinBlockInterval = 2 * time.Second
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, p)
+	if !bc.StartMining() {
+		t.Fatal("mining did not start")
+	}
+	defer bc.StopMining()
+
+	if got := waitBlocksFound(bc, 2, 10*time.Second); got < 2 {
+		t.Fatalf("found %d blocks, want 2", got)
+	}
+	first, second := bc.BlockByHeight(1), bc.BlockByHeight(2)
+	if gap := time.Duration(second.TimeStamp-first.TimeStamp) * time.Second; gap < p.MinBlockInterval {
+		t.Fatalf("blocks %v apart, want at least %v", gap, p.MinBlockInterval)
+	}
+}
+
+// TestIntervalMining starts a search on every tick of the mining timer, without
+// waiting for the minimum block interval.
+func TestIntervalMining(t *testing.T) {
+	bc := newTestChain(t)
+	bc.MiningMode = MINING_MODE_INTERVAL
+	bc.miningTimer = 300 * time.Millisecond
+	if !bc.StartMining() {
+		t.Fatal("mining did not start")
+	}
+	defer bc.StopMining()
+
+	time.Sleep(100 * time.Millisecond)
+	if s := bc.MiningStatus(); s.Mode != MINING_MODE_INTERVAL || s.BlocksFound != 0 {
+		t.Fatalf("status before the first tick = %+v", s)
+	}
+	// well within the minimum block interval of continuous mining
+	if got := waitBlocksFound(bc, 2, 5*time.Second); got < 2 {
+		t.Fatalf("found %d blocks on a %v timer, want 2", got, bc.miningTimer)
+	}
+}
+
+// TestSwitchMiningMode stops mining, changes the mode and starts again, in both
+// directions.
+func TestSwitchMiningMode(t *testing.T) {
+	bc := newTestChain(t)
+	bc.miningTimer = 100 * time.Millisecond
+
+	if !bc.StartMining() {
+		t.Fatal("continuous mining did not start")
+	}
+	if got := waitBlocksFound(bc, 1, 5*time.Second); got != 1 {
+		t.Fatalf("continuous mining found %d blocks, want 1", got)
+	}
+	bc.StopMining()
+
+	// the tip is fresh, so only interval mining finds the next block right away
+	bc.MiningMode = MINING_MODE_INTERVAL
+	if !bc.StartMining() {
+		t.Fatal("interval mining did not start")
+	}
+	if got := waitBlocksFound(bc, 2, 5*time.Second); got < 2 {
+		t.Fatalf("interval mining found %d blocks in all, want 2", got)
+	}
+	bc.StopMining()
+
+	bc.MiningMode = MINING_MODE_CONTINUOUS
+	found := bc.MiningStatus().BlocksFound
+	if !bc.StartMining() {
+		t.Fatal("continuous mining did not restart")
+	}
+	defer bc.StopMining()
+	time.Sleep(500 * time.Millisecond)
+	if s := bc.MiningStatus(); !s.Running || s.Mode != MINING_MODE_CONTINUOUS || s.BlocksFound != found {
+		t.Fatalf("status = %+v, want continuous mining waiting for the minimum interval", s)
+	}
+}
+
+func TestMiningControl(t *testing.T) {
+	bc := newTestChain(t)
+	if bc.StopMining() {
+		t.Fatal("stopped mining that was not running")
+	}
+	if bc.SetCoinbaseAddress("not an address") {
+		t.Fatal("accepted an invalid coinbase address")
+	}
+	coinbase := wallet.New().BlockchainAddress
+	if !bc.SetCoinbaseAddress(coinbase) {
+		t.Fatal("rejected a valid coinbase address")
+	}
+	bc.SetMiningWorkers(2)
+
+	if !bc.StartMining() {
+		t.Fatal("mining did not start")
+	}
+	if bc.StartMining() {
+		t.Fatal("started mining twice")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for bc.MiningStatus().BlocksFound == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !bc.StopMining() {
+		t.Fatal("mining did not stop")
+	}
+
+	s := bc.MiningStatus()
+	if s.Running || s.Workers != 2 || s.CoinbaseAddress != coinbase || s.BlocksFound != 1 {
+		t.Fatalf("status = %+v", s)
+	}
+	if got := bc.CalculateWalletBalance(coinbase); got <= 0 {
+		t.Fatalf("coinbase address holds %v after a mined block", got)
+	}
+}
+
+func TestGenesisBlock(t *testing.T) {
+	for _, p := range []chainparams.Params{chainparams.Mainnet, chainparams.Testnet, chainparams.Regtest} {
+		if got := fmt.Sprintf("%x", GenesisBlock(p.Genesis).Hash); got != p.GenesisHash {
+			t.Errorf("%s genesis block %s, want %s", p.Name, got, p.GenesisHash)
+		}
+	}
+
+	// a private network's genesis block holds its allocations and is the same everywhere
+	alice := wallet.New()
+	genesis := chainparams.Genesis{Timestamp: 1718000000, Allocations: []chainparams.Allocation{{Address: alice.BlockchainAddress, Value: 100}}}
+	a, b := GenesisBlock(genesis), GenesisBlock(genesis)
+	if a.Hash != b.Hash {
+		t.Fatal("genesis blocks of the same network differ")
+	}
+	if len(a.Transactions) != 1 || a.Transactions[0].Value != 100 || a.TimeStamp != genesis.Timestamp {
+		t.Fatalf("genesis block = %+v", a)
+	}
+	bc := newTestChain(t, alice)
+	if got := bc.SpendableBalance(alice.BlockchainAddress); got != 100 {
+		t.Fatalf("allocation spendable balance = %v, want 100", got)
+	}
+}
@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"log"
+	"time"
+)
+
+// loadCheckpoints decodes the network's checkpoints and its assume-valid block hash,
+// which stays zero when there is none. The params have been validated, so the hashes
+// decode.
+func (bc *BlockChain) loadCheckpoints() {
+	bc.checkpoints = make(map[int][32]byte, len(bc.Params.Checkpoints))
+	for _, c := range bc.Params.Checkpoints {
+		var h [32]byte
+		hex.Decode(h[:], []byte(c.Hash))
+		bc.checkpoints[c.Height] = h
+	}
+	if bc.Params.AssumeValid != "" {
+		hex.Decode(bc.assumeValid[:], []byte(bc.Params.AssumeValid))
+	}
+}
+
+// checkpointsMatch reports whether b is the block pinned at its height, if any.
+func (bc *BlockChain) checkpointsMatch(b *Block) bool {
+	if want, ok := bc.checkpoints[b.Index]; ok && b.Hash != want {
+		log.Printf("invalid block %d: %x does not match checkpoint %x", b.Index, b.Hash, want)
+		return false
+	}
+	return true
+}
+
+// assumedValid returns the index in chain of the assume-valid block, or -1 when chain
+// doesn't hold it. The proof-of-work and signatures of the blocks up to it aren't
+// re-checked: their hashes are, and the assume-valid block's hash commits to all of them.
+func (bc *BlockChain) assumedValid(chain []*Block) int {
+	if bc.assumeValid == [32]byte{} {
+		return -1
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Hash == bc.assumeValid {
+			return i
+		}
+	}
+	return -1
+}
+
+// SyncStats measures how fast chains received from neighbors are validated.
+type SyncStats struct {
+	ChainsValidated int // received from neighbors and checked, rejected ones included
+	ChainsRejected  int
+	BlocksValidated int           // in valid chains
+	ProofsSkipped   int           // blocks up to the assume-valid block, proof-of-work and signatures not re-checked
+	Duration        time.Duration // spent validating valid chains
+	LastBlocks      int           // in the last valid chain
+	LastDuration    time.Duration
+}
+
+func (s SyncStats) BlocksPerSecond() float64 {
+	return blocksPerSecond(s.BlocksValidated, s.Duration)
+}
+
+func (s SyncStats) LastBlocksPerSecond() float64 {
+	return blocksPerSecond(s.LastBlocks, s.LastDuration)
+}
+
+func blocksPerSecond(blocks int, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(blocks) / d.Seconds()
+}
+
+// recordSync adds the validation of a chain to the sync statistics. Rejected chains are
+// only counted, as validation stops at their first invalid block.
+func (bc *BlockChain) recordSync(blocks, proofsSkipped int, d time.Duration, valid bool) {
+	bc.syncMut.Lock()
+	defer bc.syncMut.Unlock()
+	bc.syncStats.ChainsValidated++
+	if !valid {
+		bc.syncStats.ChainsRejected++
+		return
+	}
+	bc.syncStats.BlocksValidated += blocks
+	bc.syncStats.ProofsSkipped += proofsSkipped
+	bc.syncStats.Duration += d
+	bc.syncStats.LastBlocks = blocks
+	bc.syncStats.LastDuration = d
+	log.Printf("sync: validated %d blocks in %v (%.0f blocks/s, %d assumed valid)", blocks, d, blocksPerSecond(blocks, d), proofsSkipped)
+}
+
+// SyncInfo describes the node's checkpoints, its assume-valid block and how fast it
+// has been validating neighbor chains.
+type SyncInfo struct {
+	Height               int
+	LastCheckpointHeight int    // -1 when the network has none
+	AssumeValid          string // hex; empty when every block's proof-of-work and signatures are checked
+	AssumeValidHeight    int    // -1 when the assume-valid block isn't in the chain
+	Stats                SyncStats
+}
+
+func (bc *BlockChain) SyncInfo() SyncInfo {
+	bc.mut.RLock()
+	info := SyncInfo{
+		Height:               bc.LastBlock().Index,
+		LastCheckpointHeight: -1,
+		AssumeValid:          bc.Params.AssumeValid,
+		AssumeValidHeight:    bc.assumedValid(bc.Chain),
+	}
+	bc.mut.RUnlock()
+	for height := range bc.checkpoints {
+		info.LastCheckpointHeight = max(info.LastCheckpointHeight, height)
+	}
+
+	bc.syncMut.Lock()
+	info.Stats = bc.syncStats
+	bc.syncMut.Unlock()
+	return info
+}
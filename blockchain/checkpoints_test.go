@@ -0,0 +1,81 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// syncedChain returns a chain whose first block holds a signed transfer from alice,
+// with two more blocks on top, and the parameters it was built with.
+func syncedChain(t *testing.T) ([]*Block, chainparams.Params) {
+	t.Helper()
+	alice, bob := wallet.New(), wallet.New()
+	params := testParams(chainparams.Allocation{Address: alice.BlockchainAddress, Value: 100})
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params)
+	if !send(bc, alice, bob.BlockchainAddress, 10, 0.5, 0) {
+		t.Fatal("rejected the transaction")
+	}
+	mine(t, bc, 3)
+	return bc.Chain, params
+}
+
+func TestCheckpoints(t *testing.T) {
+	chain, params := syncedChain(t)
+
+	pinned := params
+	pinned.Checkpoints = []chainparams.Checkpoint{{Height: 2, Hash: fmt.Sprintf("%x", chain[2].Hash)}}
+	if !New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, pinned).ValidChain(chain) {
+		t.Fatal("rejected a chain matching the checkpoint")
+	}
+
+	fork, _ := syncedChain(t) // another chain on another genesis block
+	pinned.Checkpoints = []chainparams.Checkpoint{{Height: 2, Hash: fmt.Sprintf("%x", fork[2].Hash)}}
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, pinned)
+	if bc.ValidChain(chain) {
+		t.Fatal("accepted a chain with another block at a checkpoint")
+	}
+	if info := bc.SyncInfo(); info.LastCheckpointHeight != 2 || info.Stats.ChainsRejected != 1 {
+		t.Fatalf("sync info = %+v", info)
+	}
+}
+
+func TestAssumeValid(t *testing.T) {
+	chain, params := syncedChain(t)
+	// signatures aren't part of the block hash, so stripping one leaves the chain linked
+	unsigned := withTransaction(chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.SenderPublicKey, tx.Signature = nil, nil
+		return &tx
+	})
+
+	if New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params).ValidChain(unsigned) {
+		t.Fatal("accepted an unsigned transaction without an assume-valid block")
+	}
+
+	params.AssumeValid = fmt.Sprintf("%x", chain[2].Hash)
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params)
+	if !bc.ValidChain(unsigned) {
+		t.Fatal("re-checked the signatures below the assume-valid block")
+	}
+	stats := bc.SyncInfo().Stats
+	if stats.ChainsValidated != 1 || stats.BlocksValidated != 3 || stats.ProofsSkipped != 2 || stats.LastBlocks != 3 {
+		t.Fatalf("sync stats = %+v", stats)
+	}
+
+	// blocks above the assume-valid block are checked in full
+	params.AssumeValid = fmt.Sprintf("%x", chain[0].Hash)
+	if New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params).ValidChain(unsigned) {
+		t.Fatal("skipped the signatures above the assume-valid block")
+	}
+	tampered := withTransaction(chain, 1, func(tx transaction.Transaction) *transaction.Transaction {
+		tx.Value = 1000 // more than alice holds
+		return &tx
+	})
+	params.AssumeValid = fmt.Sprintf("%x", chain[2].Hash)
+	if New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params).ValidChain(tampered) {
+		t.Fatal("accepted a block whose contents don't match its hash below the assume-valid block")
+	}
+}
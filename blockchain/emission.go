@@ -0,0 +1,114 @@
+package blockchain
+
+import (
+	"log"
+
+	"github.com/zde37/Zero-Chain/chainparams"
+)
+
+// AMOUNT_TOLERANCE absorbs float32 rounding when amounts summed in different orders are
+// compared
+const AMOUNT_TOLERANCE = 1e-4
+
+type coinbaseOutput struct {
+	height    int
+	recipient string
+	value     float32
+}
+
+// ledger replays a chain block by block, checking each block's reward against the
+// emission schedule, that nobody spends more than they hold, immature block rewards
+// excluded, and that no transaction is included twice or out of its sender's nonce
+// order.
+type ledger struct {
+	emission  chainparams.Emission
+	spendable map[string]float32
+	immature  []coinbaseOutput  // oldest first
+	nonces    map[string]uint64 // next nonce expected from each sender
+	seen      map[[32]byte]struct{}
+}
+
+// newLedger returns a ledger holding the allocations of genesis, which are spendable
+// right away.
+func newLedger(e chainparams.Emission, genesis *Block) *ledger {
+	l := &ledger{
+		emission:  e,
+		spendable: make(map[string]float32),
+		nonces:    make(map[string]uint64),
+		seen:      make(map[[32]byte]struct{}),
+	}
+	for _, t := range genesis.Transactions {
+		l.spendable[t.RecipientBlockChainAddress] += t.Value
+		l.seen[t.Hash] = struct{}{}
+	}
+	return l
+}
+
+// connect checks b and applies it to the ledger.
+func (l *ledger) connect(b *Block) bool {
+	for len(l.immature) > 0 && l.emission.Mature(l.immature[0].height, b.Index) {
+		l.spendable[l.immature[0].recipient] += l.immature[0].value
+		l.immature = l.immature[1:]
+	}
+
+	var fees float32
+	for i, t := range b.Transactions {
+		if _, ok := l.seen[t.Hash]; ok {
+			log.Printf("invalid block %d: transaction %x is included twice", b.Index, t.Hash)
+			return false
+		}
+		l.seen[t.Hash] = struct{}{}
+
+		if t.SenderBlockChainAddress == MINING_SENDER {
+			if i != len(b.Transactions)-1 {
+				log.Printf("invalid block %d: the coinbase must be the last transaction", b.Index)
+				return false
+			}
+			if subsidy := l.emission.Subsidy(b.Index); t.Value > subsidy+fees+AMOUNT_TOLERANCE {
+				log.Printf("invalid block %d: reward %v exceeds subsidy %v plus fees %v", b.Index, t.Value, subsidy, fees)
+				return false
+			}
+			l.immature = append(l.immature, coinbaseOutput{b.Index, t.RecipientBlockChainAddress, t.Value})
+			continue
+		}
+
+		if expected := l.nonces[t.SenderBlockChainAddress]; t.Nonce != expected {
+			log.Printf("invalid block %d: %s sent nonce %d, expected %d", b.Index, t.SenderBlockChainAddress, t.Nonce, expected)
+			return false
+		}
+		l.nonces[t.SenderBlockChainAddress]++
+		if l.spendable[t.SenderBlockChainAddress]+AMOUNT_TOLERANCE < t.Cost() {
+			log.Printf("invalid block %d: %s spends %v of %v spendable", b.Index, t.SenderBlockChainAddress, t.Cost(), l.spendable[t.SenderBlockChainAddress])
+			return false
+		}
+		l.spendable[t.SenderBlockChainAddress] -= t.Cost()
+		l.spendable[t.RecipientBlockChainAddress] += t.Value
+		fees += t.Fee
+	}
+	return true
+}
+
+// SupplyInfo describes the coins issued so far against the emission schedule.
+type SupplyInfo struct {
+	Height            int
+	CirculatingSupply float64 // created by the blocks up to Height, genesis allocations included
+	MaxSupply         float64
+	BlockSubsidy      float32 // created by the next block
+	HalvingInterval   int
+	NextHalvingHeight int
+	CoinbaseMaturity  int
+}
+
+func (bc *BlockChain) SupplyInfo() SupplyInfo {
+	height := bc.Tip().Index
+	e := bc.Params.Emission
+	return SupplyInfo{
+		Height:            height,
+		CirculatingSupply: e.Issued(height),
+		MaxSupply:         e.MaxSupply,
+		BlockSubsidy:      e.Subsidy(height + 1),
+		HalvingInterval:   e.HalvingInterval,
+		NextHalvingHeight: e.NextHalving(height),
+		CoinbaseMaturity:  e.CoinbaseMaturity,
+	}
+}
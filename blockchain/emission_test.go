@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestCoinbaseMaturity(t *testing.T) {
+	miner, bob := wallet.New(), wallet.New()
+	params := testParams()
+	params.Emission.CoinbaseMaturity = 3
+	bc := New(wallet.New().BlockchainAddress, 0, MINING_MODE_CONTINUOUS, params)
+
+	if _, ok := bc.GenerateBlocks(context.Background(), 1, miner.BlockchainAddress); !ok {
+		t.Fatal("failed to generate a block")
+	}
+	mine(t, bc, 1)
+	reward := bc.CalculateWalletBalance(miner.BlockchainAddress)
+	if reward != params.Emission.Subsidy(1) {
+		t.Fatalf("reward = %v, want %v", reward, params.Emission.Subsidy(1))
+	}
+	if got := bc.SpendableBalance(miner.BlockchainAddress); got != 0 {
+		t.Fatalf("spendable balance of an immature reward = %v", got)
+	}
+	if send(bc, miner, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("accepted a transaction spending an immature reward")
+	}
+
+	mine(t, bc, 1) // the next block is the reward's third confirmation
+	if got := bc.SpendableBalance(miner.BlockchainAddress); got != reward {
+		t.Fatalf("spendable balance of a mature reward = %v, want %v", got, reward)
+	}
+	if !send(bc, miner, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("rejected a transaction spending a mature reward")
+	}
+	mine(t, bc, 1)
+	if !bc.ValidChain(bc.Chain) {
+		t.Fatal("rejected a chain spending a mature reward")
+	}
+
+	// the same spend one block earlier is invalid in a chain received from a neighbor
+	early := append([]*Block(nil), bc.Chain[:3]...)
+	spend := bc.Chain[4].Transactions[0]
+	early = append(early, solveBlock(bc, early[2], []*transaction.Transaction{
+		spend, bc.coinbaseTransaction(bc.BlockChainAddress, 3, []*transaction.Transaction{spend}),
+	}))
+	if bc.ValidChain(early) {
+		t.Fatal("accepted a chain spending an immature reward")
+	}
+}
+
+func TestBlockReward(t *testing.T) {
+	bc := newTestChain(t)
+	mine(t, bc, 1)
+	subsidy := bc.Params.Emission.Subsidy(2)
+
+	chain := append([]*Block(nil), bc.Chain...)
+	fair := transaction.New(MINING_SENDER, bc.BlockChainAddress, subsidy, 0, 2)
+	if !bc.ValidChain(append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{fair}))) {
+		t.Fatal("rejected a block paying the subsidy")
+	}
+	inflated := transaction.New(MINING_SENDER, bc.BlockChainAddress, subsidy+1, 0, 2)
+	if bc.ValidChain(append(chain, solveBlock(bc, chain[1], []*transaction.Transaction{inflated}))) {
+		t.Fatal("accepted a block paying more than the subsidy")
+	}
+
+	if info := bc.SupplyInfo(); info.Height != 1 || info.CirculatingSupply != bc.Params.Emission.Issued(1) || info.BlockSubsidy != subsidy {
+		t.Fatalf("supply info = %+v", info)
+	}
+}
+
+// solveBlock returns a block holding transactions on top of previous with a valid
+// proof-of-work.
+func solveBlock(bc *BlockChain, previous *Block, transactions []*transaction.Transaction) *Block {
+	timestamp := previous.TimeStamp + 1
+	nonce := 0
+	for !bc.ValidProof(nonce, previous.Hash, timestamp, transactions) {
+		nonce++
+	}
+	return NewBlock(nonce, previous.Index, previous.Hash, timestamp, transactions)
+}
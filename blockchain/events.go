@@ -0,0 +1,166 @@
+package blockchain
+
+import (
+	"log"
+	"sync"
+
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+const (
+	EVENT_BLOCK_CONNECTED    = "block_connected"
+	EVENT_BLOCK_DISCONNECTED = "block_disconnected"
+	EVENT_TX_ACCEPTED        = "tx_accepted"
+	EVENT_TX_EVICTED         = "tx_evicted"
+
+	EVENT_BUFFER_SIZE = 64
+)
+
+// Event is published whenever a block is connected to or disconnected from the chain
+// or a transaction enters or leaves the memory pool. Block is set for block events,
+// Transaction for transaction events.
+type Event struct {
+	Type        string
+	Block       *Block
+	Transaction *transaction.Transaction
+}
+
+// Involves reports whether the event touches blockchainAddress, either through its
+// transaction or through any transaction of its block.
+func (e Event) Involves(blockchainAddress string) bool {
+	involves := func(t *transaction.Transaction) bool {
+		return t.SenderBlockChainAddress == blockchainAddress || t.RecipientBlockChainAddress == blockchainAddress
+	}
+	if e.Transaction != nil {
+		return involves(e.Transaction)
+	}
+	if e.Block != nil {
+		for _, t := range e.Block.Transactions {
+			if involves(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type eventBus struct {
+	mut         sync.Mutex
+	subscribers map[chan Event]struct{}
+	queues      map[*eventQueue]struct{}
+}
+
+// eventQueue holds the events a lossless subscriber hasn't received yet.
+type eventQueue struct {
+	mut    sync.Mutex
+	events []Event
+	ready  chan struct{} // signalled when events are queued
+	done   chan struct{} // closed when the subscriber unsubscribes
+}
+
+// Subscribe registers a new listener on the chain's event bus. Events are dropped for
+// subscribers that fall more than EVENT_BUFFER_SIZE events behind; the returned
+// function unsubscribes and closes the channel.
+func (bc *BlockChain) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, EVENT_BUFFER_SIZE)
+	bc.events.mut.Lock()
+	bc.events.subscribers[ch] = struct{}{}
+	bc.events.mut.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			bc.events.mut.Lock()
+			delete(bc.events.subscribers, ch)
+			bc.events.mut.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// SubscribeLossless registers a listener that receives every event in order: events it
+// hasn't taken yet are queued without bound instead of dropped, so it suits consumers
+// that must not miss any, like webhook deliveries. The returned function unsubscribes
+// and closes the channel.
+func (bc *BlockChain) SubscribeLossless() (<-chan Event, func()) {
+	ch := make(chan Event)
+	q := &eventQueue{ready: make(chan struct{}, 1), done: make(chan struct{})}
+	bc.events.mut.Lock()
+	bc.events.queues[q] = struct{}{}
+	bc.events.mut.Unlock()
+	go q.forward(ch)
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			bc.events.mut.Lock()
+			delete(bc.events.queues, q)
+			bc.events.mut.Unlock()
+			close(q.done)
+		})
+	}
+}
+
+func (q *eventQueue) push(e Event) {
+	q.mut.Lock()
+	q.events = append(q.events, e)
+	q.mut.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default: // already signalled
+	}
+}
+
+// forward hands the queued events to ch until the subscriber unsubscribes, then closes ch.
+func (q *eventQueue) forward(ch chan<- Event) {
+	defer close(ch)
+	for {
+		q.mut.Lock()
+		events := q.events
+		q.events = nil
+		q.mut.Unlock()
+		for _, e := range events {
+			select {
+			case ch <- e:
+			case <-q.done:
+				return
+			}
+		}
+
+		select {
+		case <-q.ready:
+		case <-q.done:
+			return
+		}
+	}
+}
+
+func (bc *BlockChain) publish(e Event) {
+	bc.events.mut.Lock()
+	defer bc.events.mut.Unlock()
+	for q := range bc.events.queues {
+		q.push(e)
+	}
+	for ch := range bc.events.subscribers {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("events: subscriber is too slow, dropping %s event", e.Type)
+		}
+	}
+}
+
+// publishReorg announces the blocks of oldChain that were replaced and the blocks of
+// the current chain that replaced them.
+func (bc *BlockChain) publishReorg(oldChain []*Block) {
+	fork := 0
+	for fork < len(oldChain) && fork < len(bc.Chain) && oldChain[fork].Hash == bc.Chain[fork].Hash {
+		fork++
+	}
+	for i := len(oldChain) - 1; i >= fork; i-- {
+		bc.publish(Event{Type: EVENT_BLOCK_DISCONNECTED, Block: oldChain[i]})
+	}
+	for _, b := range bc.Chain[fork:] {
+		bc.publish(Event{Type: EVENT_BLOCK_CONNECTED, Block: b})
+	}
+}
@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// drain returns the events already waiting on ch.
+func drain(ch <-chan Event) []Event {
+	var events []Event
+	for {
+		select {
+		case e := <-ch:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestMiningEvents(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	events, unsubscribe := bc.Subscribe()
+	defer unsubscribe()
+
+	send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0)
+	send(bc, alice, bob.BlockchainAddress, 1, 0.2, 0) // replaces the first
+	mine(t, bc, 1)
+
+	var types []string
+	for _, e := range drain(events) {
+		types = append(types, e.Type)
+	}
+	want := []string{EVENT_TX_ACCEPTED, EVENT_TX_EVICTED, EVENT_TX_ACCEPTED, EVENT_BLOCK_CONNECTED}
+	if !slices.Equal(types, want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+}
+
+// TestMinedBlockEvents mines a block with the node's own miner: its reward is part of
+// the connected block but is never announced as an accepted transaction.
+func TestMinedBlockEvents(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0)
+	events, unsubscribe := bc.Subscribe()
+	defer unsubscribe()
+
+	if !bc.StartMining() {
+		t.Fatal("mining did not start")
+	}
+	found := waitBlocksFound(bc, 1, 5*time.Second)
+	bc.StopMining()
+	if found != 1 {
+		t.Fatalf("found %d blocks, want 1", found)
+	}
+
+	var types []string
+	for _, e := range drain(events) {
+		types = append(types, e.Type)
+	}
+	if want := []string{EVENT_BLOCK_CONNECTED}; !slices.Equal(types, want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	block := bc.BlockByHeight(1)
+	if n := len(block.Transactions); n != 2 {
+		t.Fatalf("block holds %d transactions, want the payment and the reward", n)
+	}
+	if reward := block.Transactions[1]; reward.SenderBlockChainAddress != MINING_SENDER || reward.RecipientBlockChainAddress != bc.BlockChainAddress {
+		t.Fatalf("last transaction = %+v, want the reward to %s", reward, bc.BlockChainAddress)
+	}
+	if len(bc.PendingTransactions()) != 0 {
+		t.Fatal("memory pool not empty after the block")
+	}
+}
+
+// TestClearMemPoolEvictions clears the memory pool as a neighbor's block would, then
+// connects a block holding only one of the cleared transactions: only the other one is
+// evicted, and only once consensus has run.
+func TestClearMemPoolEvictions(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0)
+	send(bc, alice, bob.BlockchainAddress, 1, 0.1, 1)
+	mined, dropped := bc.MemPool[0], bc.MemPool[1]
+
+	events, unsubscribe := bc.Subscribe()
+	defer unsubscribe()
+	bc.ClearMemPool()
+	if e := drain(events); len(e) != 0 {
+		t.Fatalf("clearing the memory pool published %d events", len(e))
+	}
+
+	bc.mut.Lock()
+	last := bc.LastBlock()
+	bc.connectBlock(NewBlock(0, last.Index, last.Hash, time.Now().UnixNano(), []*transaction.Transaction{mined}))
+	bc.mut.Unlock()
+	bc.ResolveConflicts()
+
+	var evicted []*transaction.Transaction
+	for _, e := range drain(events) {
+		if e.Type == EVENT_TX_EVICTED {
+			evicted = append(evicted, e.Transaction)
+		}
+	}
+	if len(evicted) != 1 || evicted[0] != dropped {
+		t.Fatalf("evicted %d transactions, want only the one missing from the chain", len(evicted))
+	}
+}
+
+// TestSubscribeLossless publishes more events than a subscriber's buffer holds while
+// nobody reads them: the lossless subscriber still receives all of them, in order.
+func TestSubscribeLossless(t *testing.T) {
+	bc := newTestChain(t)
+	lossy, unsubscribeLossy := bc.Subscribe()
+	defer unsubscribeLossy()
+	lossless, unsubscribe := bc.SubscribeLossless()
+
+	blocks := mine(t, bc, EVENT_BUFFER_SIZE+10)
+	if n := len(drain(lossy)); n != EVENT_BUFFER_SIZE {
+		t.Fatalf("buffered subscriber received %d events, want %d", n, EVENT_BUFFER_SIZE)
+	}
+	for _, b := range blocks {
+		select {
+		case e := <-lossless:
+			if e.Type != EVENT_BLOCK_CONNECTED || e.Block != b {
+				t.Fatalf("received %s for block %d, want block %d connected", e.Type, e.Block.Index, b.Index)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("lossless subscriber is missing block %d", b.Index)
+		}
+	}
+
+	unsubscribe()
+	if _, ok := <-lossless; ok {
+		t.Fatal("channel still open after unsubscribing")
+	}
+}
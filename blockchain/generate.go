@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/miner"
+)
+
+const MAX_GENERATE_BLOCKS = 1000 // per GenerateBlocks call
+
+// GenerateBlocks mines n blocks on top of the tip right away, each holding the memory
+// pool and paying its reward to coinbase, and announces them to the neighbors once all
+// are connected. It is only available on networks that mine on demand, whose difficulty
+// makes a search take a handful of hashes, so the chain lock is held throughout.
+func (bc *BlockChain) GenerateBlocks(ctx context.Context, n int, coinbase string) ([]*Block, bool) {
+	if !bc.Params.MineOnDemand {
+		log.Printf("generate-blocks: blocks are not generated on demand on %s", bc.Params.Name)
+		return nil, false
+	}
+	if n < 1 || n > MAX_GENERATE_BLOCKS {
+		log.Printf("generate-blocks: can generate 1 to %d blocks, not %d", MAX_GENERATE_BLOCKS, n)
+		return nil, false
+	}
+	if err := address.Validate(coinbase); err != nil {
+		log.Printf("generate-blocks: invalid coinbase address %s: %v", coinbase, err)
+		return nil, false
+	}
+
+	m := miner.New(1)
+	blocks := make([]*Block, 0, n)
+	bc.mut.Lock()
+	// at this difficulty a nonce turns up before the miner checks ctx, so check it here
+	for len(blocks) < n && ctx.Err() == nil {
+		template, transactions, timestamp := bc.blockTemplate(coinbase)
+		if time.Unix(timestamp, 0).After(bc.AdjustedTime().Add(bc.Params.MaxFutureBlockTime)) {
+			log.Printf("generate-blocks: block %d would be too far in the future, generate fewer blocks at a time", template.Height)
+			break
+		}
+		nonce, err := m.Search(ctx, template)
+		if err != nil {
+			break
+		}
+		tip := bc.LastBlock()
+		block := NewBlock(nonce, tip.Index, tip.Hash, timestamp, transactions)
+		bc.connectBlock(block)
+		blocks = append(blocks, block)
+	}
+	bc.mut.Unlock()
+
+	if len(blocks) > 0 {
+		log.Printf("generate-blocks: connected blocks %d to %d", blocks[0].Index, blocks[len(blocks)-1].Index)
+		bc.announceBlock()
+	}
+	return blocks, len(blocks) == n
+}
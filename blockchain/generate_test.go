@@ -0,0 +1,63 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestGenerateBlocks(t *testing.T) {
+	alice, bob, coinbase := wallet.New(), wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	if !send(bc, alice, bob.BlockchainAddress, 1, 0.5, 0) {
+		t.Fatal("rejected the transaction")
+	}
+
+	blocks, ok := bc.GenerateBlocks(context.Background(), 3, coinbase.BlockchainAddress)
+	if !ok || len(blocks) != 3 {
+		t.Fatalf("generated %d of 3 blocks", len(blocks))
+	}
+	if bc.LastBlock() != blocks[2] || blocks[0].Index != 1 {
+		t.Fatalf("generated blocks %d to %d, tip %d", blocks[0].Index, blocks[2].Index, bc.LastBlock().Index)
+	}
+	if len(blocks[0].Transactions) != 2 || len(blocks[1].Transactions) != 1 || len(bc.MemPool) != 0 {
+		t.Fatal("the memory pool was not confirmed in the first block")
+	}
+	want := bc.Params.Emission.Subsidy(1)*3 + 0.5
+	if got := bc.CalculateWalletBalance(coinbase.BlockchainAddress); got != want {
+		t.Fatalf("coinbase balance = %v, want %v", got, want)
+	}
+	if !bc.ValidChain(bc.Chain) {
+		t.Fatal("rejected the generated chain")
+	}
+}
+
+func TestGenerateBlocksErrors(t *testing.T) {
+	coinbase := wallet.New().BlockchainAddress
+	bc := newTestChain(t)
+	for _, n := range []int{0, MAX_GENERATE_BLOCKS + 1} {
+		if _, ok := bc.GenerateBlocks(context.Background(), n, coinbase); ok {
+			t.Errorf("generated %d blocks", n)
+		}
+	}
+	if _, ok := bc.GenerateBlocks(context.Background(), 1, "not an address"); ok {
+		t.Error("generated a block paying an invalid address")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if blocks, ok := bc.GenerateBlocks(ctx, 1, coinbase); ok || len(blocks) != 0 {
+		t.Errorf("generated %d blocks after cancellation", len(blocks))
+	}
+
+	params := testParams()
+	params.MineOnDemand = false
+	mainnet := New(coinbase, 0, MINING_MODE_CONTINUOUS, params)
+	if _, ok := mainnet.GenerateBlocks(context.Background(), 1, coinbase); ok {
+		t.Error("generated a block on a network that doesn't mine on demand")
+	}
+	if len(bc.Chain) != 1 || len(mainnet.Chain) != 1 {
+		t.Fatal("failed calls connected blocks")
+	}
+}
@@ -0,0 +1,231 @@
+package blockchain
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+const (
+	TX_STATUS_UNKNOWN   = "unknown"
+	TX_STATUS_PENDING   = "pending"
+	TX_STATUS_CONFIRMED = "confirmed"
+	TX_STATUS_ORPHANED  = "orphaned"
+)
+
+// TxRecord describes where a transaction currently stands. Block is the block the
+// transaction was mined in; for orphaned transactions it is the block that was
+// dropped from the chain.
+type TxRecord struct {
+	Transaction   *transaction.Transaction
+	Status        string
+	Block         *Block
+	Confirmations int
+}
+
+// AddressTx is one entry of an address' history. Amount is the signed change the
+// transaction made to the address' balance and Balance the running balance after it;
+// Block is nil while the transaction is still pending.
+type AddressTx struct {
+	Transaction *transaction.Transaction
+	Block       *Block
+	Incoming    bool
+	Amount      float32
+	Balance     float32
+}
+
+type txEntry struct {
+	transaction *transaction.Transaction
+	block       *Block
+}
+
+// indexBlock adds a newly connected block and its transactions to the block-hash,
+// tx-hash and address indexes.
+func (bc *BlockChain) indexBlock(b *Block) {
+	bc.blockIndex[b.Hash] = b
+	for _, t := range b.Transactions {
+		e := txEntry{transaction: t, block: b}
+		bc.txIndex[t.Hash] = e
+		delete(bc.orphaned, t.Hash)
+		delete(bc.cleared, t.Hash)
+
+		if t.SenderBlockChainAddress != MINING_SENDER {
+			bc.addressIndex[t.SenderBlockChainAddress] = append(bc.addressIndex[t.SenderBlockChainAddress], e)
+		}
+		if t.RecipientBlockChainAddress != t.SenderBlockChainAddress {
+			bc.addressIndex[t.RecipientBlockChainAddress] = append(bc.addressIndex[t.RecipientBlockChainAddress], e)
+		}
+	}
+}
+
+// reindex rebuilds the tx-hash index after the chain has been replaced and records
+// every transaction of the old chain that did not make it into the new one as orphaned.
+func (bc *BlockChain) reindex(oldChain []*Block) {
+	bc.txIndex = make(map[[32]byte]txEntry)
+	bc.addressIndex = make(map[string][]txEntry)
+	bc.blockIndex = make(map[[32]byte]*Block)
+	for _, b := range bc.Chain {
+		bc.indexBlock(b)
+	}
+
+	for _, b := range oldChain {
+		for _, t := range b.Transactions {
+			if _, ok := bc.txIndex[t.Hash]; !ok {
+				bc.orphaned[t.Hash] = txEntry{transaction: t, block: b}
+			}
+		}
+	}
+}
+
+// GetTransaction looks a transaction up by hash in the memory pool, the chain and
+// the set of transactions orphaned by chain reorganisations, in that order.
+func (bc *BlockChain) GetTransaction(hash [32]byte) TxRecord {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	for _, t := range bc.MemPool {
+		if t.Hash == hash {
+			return TxRecord{Transaction: t, Status: TX_STATUS_PENDING}
+		}
+	}
+
+	if e, ok := bc.txIndex[hash]; ok {
+		return TxRecord{
+			Transaction:   e.transaction,
+			Status:        TX_STATUS_CONFIRMED,
+			Block:         e.block,
+			Confirmations: bc.LastBlock().Index - e.block.Index + 1,
+		}
+	}
+
+	if e, ok := bc.orphaned[hash]; ok {
+		return TxRecord{Transaction: e.transaction, Status: TX_STATUS_ORPHANED, Block: e.block}
+	}
+	return TxRecord{Status: TX_STATUS_UNKNOWN}
+}
+
+// HistoryPosition places an entry in an address' history, which is ordered by block
+// height, pending transactions last, and by transaction hash within a block. Every
+// position, including that of a transaction since dropped, falls between the same
+// entries, so it can mark where a page of history ends.
+type HistoryPosition struct {
+	Height int // PENDING_HEIGHT while pending
+	Hash   [32]byte
+}
+
+const PENDING_HEIGHT = math.MaxInt
+
+func (p HistoryPosition) Compare(q HistoryPosition) int {
+	if c := cmp.Compare(p.Height, q.Height); c != 0 {
+		return c
+	}
+	return bytes.Compare(p.Hash[:], q.Hash[:])
+}
+
+func (e *AddressTx) Position() HistoryPosition {
+	if e.Block == nil {
+		return HistoryPosition{Height: PENDING_HEIGHT, Hash: e.Transaction.Hash}
+	}
+	return HistoryPosition{Height: e.Block.Index, Hash: e.Transaction.Hash}
+}
+
+// AddressHistory returns every transaction sent to or from blockchainAddress in history
+// order, see HistoryPosition.
+func (bc *BlockChain) AddressHistory(blockchainAddress string) []*AddressTx {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+
+	history := make([]*AddressTx, 0)
+	for _, e := range bc.addressIndex[blockchainAddress] {
+		history = append(history, &AddressTx{Transaction: e.transaction, Block: e.block})
+	}
+	for _, t := range bc.MemPool {
+		if t.SenderBlockChainAddress == blockchainAddress || t.RecipientBlockChainAddress == blockchainAddress {
+			history = append(history, &AddressTx{Transaction: t})
+		}
+	}
+	slices.SortFunc(history, func(a, b *AddressTx) int {
+		return a.Position().Compare(b.Position())
+	})
+
+	var balance float32
+	for _, entry := range history {
+		t := entry.Transaction
+		if t.SenderBlockChainAddress == blockchainAddress {
+			entry.Amount -= t.Cost()
+		}
+		if t.RecipientBlockChainAddress == blockchainAddress {
+			entry.Amount += t.Value
+			entry.Incoming = t.SenderBlockChainAddress != blockchainAddress
+		}
+		balance += entry.Amount
+		entry.Balance = balance
+	}
+	return history
+}
+
+// BlockByHeight returns the block at the given height, or nil if the chain is shorter.
+func (bc *BlockChain) BlockByHeight(height int) *Block {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	if height < 0 || height >= len(bc.Chain) {
+		return nil
+	}
+	return bc.Chain[height]
+}
+
+// BlockByHash returns the block with the given hash, or nil if it is not on the chain.
+func (bc *BlockChain) BlockByHash(hash [32]byte) *Block {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return bc.blockIndex[hash]
+}
+
+// Blocks returns up to limit consecutive blocks starting at height from and whether
+// more blocks follow them.
+func (bc *BlockChain) Blocks(from, limit int) ([]*Block, bool) {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	if from < 0 || from >= len(bc.Chain) {
+		return []*Block{}, false
+	}
+	to := min(from+limit, len(bc.Chain))
+	return slices.Clone(bc.Chain[from:to]), to < len(bc.Chain)
+}
+
+// Tip returns the last block of the chain.
+func (bc *BlockChain) Tip() *Block {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return bc.LastBlock()
+}
+
+// ChainCopy returns a copy of the chain's block list.
+func (bc *BlockChain) ChainCopy() []*Block {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return slices.Clone(bc.Chain)
+}
+
+// PendingTransactions returns a copy of the memory pool.
+func (bc *BlockChain) PendingTransactions() []*transaction.Transaction {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return bc.CopyMemPool()
+}
+
+// WalletBalance is CalculateWalletBalance for callers that don't hold the chain's lock.
+func (bc *BlockChain) WalletBalance(blockchainAddress string) float32 {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return bc.CalculateWalletBalance(blockchainAddress)
+}
+
+// AccountNonce is NextNonce for callers that don't hold the chain's lock.
+func (bc *BlockChain) AccountNonce(blockchainAddress string) uint64 {
+	bc.mut.RLock()
+	defer bc.mut.RUnlock()
+	return bc.NextNonce(blockchainAddress)
+}
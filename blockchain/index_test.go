@@ -0,0 +1,131 @@
+package blockchain
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestGetTransaction(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	if !send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0) {
+		t.Fatal("rejected the transaction")
+	}
+	hash := bc.MemPool[0].Hash
+	if r := bc.GetTransaction(hash); r.Status != TX_STATUS_PENDING || r.Block != nil {
+		t.Fatalf("status in the memory pool = %s, want %s", r.Status, TX_STATUS_PENDING)
+	}
+
+	block := mine(t, bc, 1)[0]
+	r := bc.GetTransaction(hash)
+	if r.Status != TX_STATUS_CONFIRMED || r.Block != block || r.Confirmations != 1 {
+		t.Fatalf("after mining: status %s in block %v with %d confirmations, want %s in block %d with 1",
+			r.Status, r.Block, r.Confirmations, TX_STATUS_CONFIRMED, block.Index)
+	}
+	mine(t, bc, 2)
+	if r := bc.GetTransaction(hash); r.Confirmations != 3 {
+		t.Fatalf("confirmations two blocks later = %d, want 3", r.Confirmations)
+	}
+
+	if r := bc.GetTransaction([32]byte{1}); r.Status != TX_STATUS_UNKNOWN || r.Transaction != nil {
+		t.Fatalf("status of an unknown hash = %s, want %s", r.Status, TX_STATUS_UNKNOWN)
+	}
+}
+
+func TestGetTransactionOrphaned(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	send(bc, alice, bob.BlockchainAddress, 1, 0.1, 0)
+	hash := bc.MemPool[0].Hash
+	mine(t, bc, 1)
+
+	// a longer chain without the transaction replaces the one holding it
+	oldChain := bc.Chain
+	bc.Chain = append([]*Block{}, bc.Chain[0])
+	mine(t, bc, 2)
+	bc.reindex(oldChain)
+
+	r := bc.GetTransaction(hash)
+	if r.Status != TX_STATUS_ORPHANED || r.Block != oldChain[1] {
+		t.Fatalf("status after the reorg = %s, want %s in the dropped block", r.Status, TX_STATUS_ORPHANED)
+	}
+}
+
+// TestIndexConcurrentReads reads the indexes while blocks are connected; run with -race.
+func TestIndexConcurrentReads(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			if _, ok := bc.GenerateBlocks(context.Background(), 1, bc.BlockChainAddress); !ok {
+				t.Error("failed to generate a block")
+			}
+		}
+	}()
+	for i := range 20 {
+		send(bc, alice, bob.BlockchainAddress, 1, 0.1, uint64(i))
+		bc.GetTransaction([32]byte{})
+		bc.AddressHistory(alice.BlockchainAddress)
+		bc.ChainCopy()
+		bc.PendingTransactions()
+		bc.WalletBalance(alice.BlockchainAddress)
+		bc.AccountNonce(alice.BlockchainAddress)
+	}
+	wg.Wait()
+}
+
+func TestBlockQueries(t *testing.T) {
+	bc := newTestChain(t)
+	blocks := mine(t, bc, 5)
+
+	for _, b := range blocks {
+		if got := bc.BlockByHeight(b.Index); got != b {
+			t.Fatalf("block at height %d = %v, want %x", b.Index, got, b.Hash)
+		}
+		if got := bc.BlockByHash(b.Hash); got != b {
+			t.Fatalf("block with hash %x = %v", b.Hash, got)
+		}
+	}
+	if bc.BlockByHeight(6) != nil || bc.BlockByHeight(-1) != nil || bc.BlockByHash([32]byte{1}) != nil {
+		t.Fatal("found a block that doesn't exist")
+	}
+	if tip := bc.Tip(); tip != blocks[4] {
+		t.Fatalf("tip at height %d, want 5", tip.Index)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	bc := newTestChain(t)
+	mine(t, bc, 4) // heights 0 to 4
+
+	tests := []struct {
+		from, limit int
+		want        []int
+		more        bool
+	}{
+		{0, 2, []int{0, 1}, true},
+		{3, 2, []int{3, 4}, false},
+		{4, 10, []int{4}, false},
+		{5, 10, nil, false},
+	}
+	for _, tt := range tests {
+		blocks, more := bc.Blocks(tt.from, tt.limit)
+		var heights []int
+		for _, b := range blocks {
+			heights = append(heights, b.Index)
+		}
+		if !slices.Equal(heights, tt.want) || more != tt.more {
+			t.Errorf("Blocks(%d, %d) = %v, more %v; want %v, more %v", tt.from, tt.limit, heights, more, tt.want, tt.more)
+		}
+	}
+}
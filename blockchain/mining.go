@@ -0,0 +1,297 @@
+package blockchain
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// MiningStatus describes the node's miner. TemplateHeight is the height of the block
+// being searched for, or 0 between searches.
+type MiningStatus struct {
+	Running         bool
+	Mode            string
+	Workers         int
+	HashRate        float64
+	CoinbaseAddress string
+	TemplateHeight  int
+	BlocksFound     int
+}
+
+func ValidMiningMode(mode string) bool {
+	return mode == MINING_MODE_CONTINUOUS || mode == MINING_MODE_INTERVAL
+}
+
+// StartMining starts mining in bc.MiningMode; it reports false if mining is already
+// running.
+func (bc *BlockChain) StartMining() bool {
+	bc.miningMut.Lock()
+	defer bc.miningMut.Unlock()
+	if bc.stopMining != nil {
+		return false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bc.stopMining = cancel
+
+	if bc.MiningMode == MINING_MODE_INTERVAL {
+		go func() {
+			ticker := time.NewTicker(bc.miningTimer)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					bc.Mining(ctx)
+				}
+			}
+		}()
+		return true
+	}
+
+	// Mining returns once a block is connected and its search already restarts when a
+	// peer's block arrives, so looping is all continuous mining takes besides keeping
+	// blocks the network's MinBlockInterval apart
+	go func() {
+		for ctx.Err() == nil {
+			if wait := bc.untilNextBlock(); wait > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(wait):
+				}
+				continue // the tip may have moved while waiting
+			}
+			bc.Mining(ctx)
+		}
+	}()
+	return true
+}
+
+// untilNextBlock returns how long continuous mining waits before searching for the
+// block on top of the current tip.
+func (bc *BlockChain) untilNextBlock() time.Duration {
+	bc.mut.RLock()
+	tip := bc.LastBlock().TimeStamp
+	bc.mut.RUnlock()
+	return time.Unix(tip, 0).Add(bc.Params.MinBlockInterval).Sub(bc.AdjustedTime())
+}
+
+// StopMining abandons the running search and stops mining; it reports false if mining
+// was not running.
+func (bc *BlockChain) StopMining() bool {
+	bc.miningMut.Lock()
+	defer bc.miningMut.Unlock()
+	if bc.stopMining == nil {
+		return false
+	}
+	bc.stopMining()
+	bc.stopMining = nil
+	return true
+}
+
+// SetMiningWorkers changes the number of proof-of-work goroutines, one per CPU when
+// workers is not positive. The running search restarts with the new count.
+func (bc *BlockChain) SetMiningWorkers(workers int) {
+	bc.miner.SetWorkers(workers)
+	bc.restartSearch()
+}
+
+// SetCoinbaseAddress sends the rewards of blocks mined from now on to blockchainAddress.
+// The running search restarts on a template paying the new address.
+func (bc *BlockChain) SetCoinbaseAddress(blockchainAddress string) bool {
+	if err := address.Validate(blockchainAddress); err != nil {
+		log.Printf("mining: invalid coinbase address %s: %v", blockchainAddress, err)
+		return false
+	}
+	bc.mut.Lock()
+	bc.BlockChainAddress = blockchainAddress
+	bc.mut.Unlock()
+	bc.restartSearch()
+	return true
+}
+
+func (bc *BlockChain) MiningStatus() MiningStatus {
+	bc.mut.Lock()
+	coinbase := bc.BlockChainAddress
+	bc.mut.Unlock()
+
+	bc.miningMut.Lock()
+	defer bc.miningMut.Unlock()
+	return MiningStatus{
+		Running:         bc.stopMining != nil,
+		Mode:            bc.MiningMode,
+		Workers:         bc.miner.Workers(),
+		HashRate:        bc.miner.HashRate(),
+		CoinbaseAddress: coinbase,
+		TemplateHeight:  bc.templateHeight,
+		BlocksFound:     bc.blocksFound,
+	}
+}
+
+// HashRate returns the miner's current hashes per second.
+func (bc *BlockChain) HashRate() float64 {
+	return bc.miner.HashRate()
+}
+
+// Mining finds and connects the next block, giving up when ctx is done. The chain lock
+// is only held while the template is built and while the block is connected; the nonce
+// search runs without it and starts over on a fresh template whenever a block is
+// connected or disconnected, the memory pool changes or the mining settings change.
+func (bc *BlockChain) Mining(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		bc.mut.Lock()
+		template, transactions, timestamp := bc.blockTemplate(bc.BlockChainAddress)
+		previousHash := bc.LastBlock().Hash
+		previousIndex := bc.LastBlock().Index
+		bc.mut.Unlock()
+
+		// blocks found faster than the median-time-past advances push timestamps ahead of
+		// the clock; wait for it to catch up rather than mine a block nobody accepts
+		if ahead := time.Unix(timestamp, 0).Sub(bc.AdjustedTime().Add(bc.Params.MaxFutureBlockTime)); ahead > 0 {
+			log.Printf("mining: block %d would be too far in the future, waiting %v", template.Height, ahead)
+			select {
+			case <-ctx.Done():
+			case <-time.After(ahead):
+			}
+			continue
+		}
+
+		nonce, err := bc.searchNonce(ctx, template)
+		if ctx.Err() != nil {
+			return // mining stopped, the template is not stale
+		}
+		if err != nil {
+			log.Printf("mining: template for height %d is stale, restarting", template.Height)
+			continue
+		}
+
+		bc.mut.Lock()
+		if !bc.templateCurrent(previousHash, transactions) {
+			bc.mut.Unlock()
+			log.Printf("mining: template for height %d is stale, restarting", template.Height)
+			continue
+		}
+		// the template's transactions end with the reward, which never enters the memory pool
+		bc.connectBlock(NewBlock(nonce, previousIndex, previousHash, timestamp, transactions))
+		bc.mut.Unlock()
+
+		bc.miningMut.Lock()
+		bc.blocksFound++
+		bc.miningMut.Unlock()
+		log.Printf("mining: found block %d at %.0f hashes/s", template.Height, bc.HashRate())
+		break
+	}
+	bc.announceBlock()
+}
+
+// announceBlock asks every neighbor to run consensus so they adopt a block found here.
+func (bc *BlockChain) announceBlock() {
+	bc.wgMining.Add(len(bc.neighbors))
+	for _, n := range bc.neighbors {
+		go func() {
+			defer bc.wgMining.Done()
+			conn, err := grpc.NewClient(
+				n,
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			if err != nil {
+				log.Printf("announce-block: failed to create grpc client on %s node: %v", n, err)
+				return
+			}
+			defer conn.Close()
+
+			client := protogen.NewBlockChainServiceClient(conn)
+			resp, err := client.Consensus(context.Background(), &protogen.Empty{})
+			if err != nil {
+				log.Printf("announce-block: consensus failed on %s node: %v", n, err)
+				return
+			}
+			log.Printf("announce-block: consensus %s", resp.GetStatus())
+		}()
+	}
+	bc.wgMining.Wait()
+}
+
+// blockTemplate returns the work for the next block: the memory pool plus the mining
+// reward to coinbase on top of the current tip, and the block's timestamp. The caller
+// must hold bc.mut.
+func (bc *BlockChain) blockTemplate(coinbase string) (miner.Template, []*transaction.Transaction, int64) {
+	previousHash := bc.LastBlock().Hash
+	height := bc.LastBlock().Index + 1
+	timestamp := bc.nextTimestamp()
+	transactions := append(bc.CopyMemPool(), bc.coinbaseTransaction(coinbase, height, bc.MemPool))
+
+	return miner.Template{
+		Height:     height,
+		Difficulty: bc.Params.Difficulty,
+		Hash: func(nonce int) [32]byte {
+			return proofHash(nonce, previousHash, timestamp, transactions)
+		},
+	}, transactions, timestamp
+}
+
+// templateCurrent reports whether a template built from transactions on top of
+// previousHash is still the work for the next block. The caller must hold bc.mut.
+func (bc *BlockChain) templateCurrent(previousHash [32]byte, transactions []*transaction.Transaction) bool {
+	reward := transactions[len(transactions)-1]
+	if bc.LastBlock().Hash != previousHash ||
+		reward.RecipientBlockChainAddress != bc.BlockChainAddress ||
+		len(bc.MemPool) != len(transactions)-1 {
+		return false
+	}
+	for i, t := range bc.MemPool {
+		if t.Hash != transactions[i].Hash {
+			return false
+		}
+	}
+	return true
+}
+
+// searchNonce runs the miner on template until it finds a nonce, ctx is done, the chain
+// or memory pool changes or restartSearch is called.
+func (bc *BlockChain) searchNonce(ctx context.Context, template miner.Template) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	bc.miningMut.Lock()
+	bc.cancelSearch = cancel
+	bc.templateHeight = template.Height
+	bc.miningMut.Unlock()
+	defer func() {
+		bc.miningMut.Lock()
+		bc.cancelSearch = nil
+		bc.templateHeight = 0
+		bc.miningMut.Unlock()
+	}()
+
+	events, unsubscribe := bc.Subscribe()
+	defer unsubscribe()
+	go func() {
+		if _, ok := <-events; ok { // every event type changes the template
+			cancel()
+		}
+	}()
+
+	return bc.miner.Search(ctx, template)
+}
+
+// restartSearch abandons the running nonce search, if any, so mining continues on a
+// fresh template.
+func (bc *BlockChain) restartSearch() {
+	bc.miningMut.Lock()
+	defer bc.miningMut.Unlock()
+	if bc.cancelSearch != nil {
+		bc.cancelSearch()
+	}
+}
@@ -0,0 +1,146 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+// BlockTemplate is the work for the next block handed to a miner outside the node. The
+// proof-of-work hash is sha256(HeaderPrefix || nonce in decimal || HeaderSuffix) and has
+// to start with Difficulty zero hex digits.
+type BlockTemplate struct {
+	Height       int
+	PreviousHash [32]byte
+	Timestamp    int64 // unix seconds, to be submitted with the block
+	Difficulty   int
+	Transactions []*transaction.Transaction // the memory pool in block order, without the coinbase
+	Coinbase     *transaction.Transaction
+	HeaderPrefix []byte
+	HeaderSuffix []byte
+}
+
+// coinbaseTransaction returns the mining reward for the block at height holding
+// transactions: the height's subsidy plus their fees.
+func (bc *BlockChain) coinbaseTransaction(coinbase string, height int, transactions []*transaction.Transaction) *transaction.Transaction {
+	var fees float32
+	for _, t := range transactions {
+		fees += t.Fee
+	}
+	// the reward carries the block's height as its nonce so every reward has a unique hash
+	return transaction.New(MINING_SENDER, coinbase, bc.Params.Emission.Subsidy(height)+fees, 0, uint64(height))
+}
+
+// BlockTemplate returns the work for the next block paying its reward to coinbase, or
+// to the node's own address when coinbase is empty.
+func (bc *BlockChain) BlockTemplate(coinbase string) (*BlockTemplate, bool) {
+	if coinbase != "" {
+		if err := address.Validate(coinbase); err != nil {
+			log.Printf("block-template: invalid coinbase address %s: %v", coinbase, err)
+			return nil, false
+		}
+	}
+
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+	if coinbase == "" {
+		coinbase = bc.BlockChainAddress
+	}
+	template, transactions, timestamp := bc.blockTemplate(coinbase)
+	previousHash := bc.LastBlock().Hash
+
+	prefix, suffix, ok := proofPreimage(previousHash, timestamp, transactions)
+	if !ok {
+		return nil, false
+	}
+	return &BlockTemplate{
+		Height:       template.Height,
+		PreviousHash: previousHash,
+		Timestamp:    timestamp,
+		Difficulty:   template.Difficulty,
+		Transactions: transactions[:len(transactions)-1],
+		Coinbase:     transactions[len(transactions)-1],
+		HeaderPrefix: prefix,
+		HeaderSuffix: suffix,
+	}, true
+}
+
+// SubmitBlock connects the block a miner outside the node found for a template: the
+// memory pool transactions with the given hashes, in that order, and the reward to
+// coinbase on top of previousHash at timestamp. It fails if the tip has moved or one of
+// the transactions has left the memory pool since the template was handed out.
+func (bc *BlockChain) SubmitBlock(previousHash [32]byte, timestamp int64, coinbase string, hashes [][32]byte, nonce int) (*Block, bool) {
+	if err := address.Validate(coinbase); err != nil {
+		log.Printf("submit-block: invalid coinbase address %s: %v", coinbase, err)
+		return nil, false
+	}
+
+	bc.mut.Lock()
+	tip := bc.LastBlock()
+	if tip.Hash != previousHash {
+		bc.mut.Unlock()
+		log.Printf("submit-block: stale block on top of %x, the tip is %x", previousHash, tip.Hash)
+		return nil, false
+	}
+
+	memPool := make(map[[32]byte]*transaction.Transaction, len(bc.MemPool))
+	for _, t := range bc.MemPool {
+		memPool[t.Hash] = t
+	}
+	transactions := make([]*transaction.Transaction, 0, len(hashes)+1)
+	for _, h := range hashes {
+		t, ok := memPool[h]
+		if !ok {
+			bc.mut.Unlock()
+			log.Printf("submit-block: transaction %x is not in the memory pool or listed twice", h)
+			return nil, false
+		}
+		delete(memPool, h) // a transaction listed twice would be spent and its fee paid twice
+		transactions = append(transactions, t)
+	}
+	transactions = append(transactions, bc.coinbaseTransaction(coinbase, tip.Index+1, transactions))
+
+	if !bc.ValidProof(nonce, previousHash, timestamp, transactions) {
+		bc.mut.Unlock()
+		log.Printf("submit-block: nonce %d does not meet the difficulty", nonce)
+		return nil, false
+	}
+	block := NewBlock(nonce, tip.Index, previousHash, timestamp, transactions)
+	if !bc.validTimestamp(block, bc.Chain) {
+		bc.mut.Unlock()
+		return nil, false
+	}
+	bc.connectBlock(block)
+	bc.mut.Unlock()
+
+	log.Printf("submit-block: connected block %d", block.Index)
+	bc.announceBlock()
+	return block, true
+}
+
+// proofPreimage splits the bytes proofHash hashes around the nonce, so a miner can hash
+// prefix || nonce || suffix without knowing how blocks are serialized.
+func proofPreimage(previousHash [32]byte, timestamp int64, transactions []*transaction.Transaction) ([]byte, []byte, bool) {
+	m, err := json.Marshal(Block{
+		TimeStamp:    timestamp,
+		PreviousHash: previousHash,
+		Transactions: transactions,
+	})
+	if err != nil {
+		log.Printf("block-template: failed to serialize block: %v", err)
+		return nil, nil, false
+	}
+	// the zero hash comes first and the transactions use lowercase keys, so the first
+	// match is the block's nonce
+	field := []byte(`"Nonce":`)
+	i := bytes.Index(m, append(field, '0'))
+	if i < 0 {
+		log.Printf("block-template: nonce not found in serialized block")
+		return nil, nil, false
+	}
+	i += len(field)
+	return m[:i:i], m[i+1:], true
+}
@@ -0,0 +1,88 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// solve searches a nonce for template the way an external miner does, from its header
+// bytes alone.
+func solve(t *testing.T, template *BlockTemplate) int {
+	t.Helper()
+	nonce, err := miner.New(1).Search(context.Background(), miner.Template{
+		Height:     template.Height,
+		Difficulty: template.Difficulty,
+		Hash:       miner.HeaderHash(template.HeaderPrefix, template.HeaderSuffix),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nonce
+}
+
+func hashes(transactions []*transaction.Transaction) [][32]byte {
+	h := make([][32]byte, len(transactions))
+	for i, t := range transactions {
+		h[i] = t.Hash
+	}
+	return h
+}
+
+func TestSubmitBlock(t *testing.T) {
+	alice, bob, coinbase := wallet.New(), wallet.New(), wallet.New()
+	bc := newTestChain(t, alice)
+	if !send(bc, alice, bob.BlockchainAddress, 1, 0.5, 0) {
+		t.Fatal("rejected the transaction")
+	}
+
+	template, ok := bc.BlockTemplate(coinbase.BlockchainAddress)
+	if !ok {
+		t.Fatal("no block template")
+	}
+	if len(template.Transactions) != 1 || template.Coinbase.RecipientBlockChainAddress != coinbase.BlockchainAddress {
+		t.Fatalf("template holds %d transactions paying %s", len(template.Transactions), template.Coinbase.RecipientBlockChainAddress)
+	}
+	nonce := solve(t, template)
+	all := append(template.Transactions, template.Coinbase)
+	if got, want := miner.HeaderHash(template.HeaderPrefix, template.HeaderSuffix)(nonce), proofHash(nonce, template.PreviousHash, template.Timestamp, all); got != want {
+		t.Fatalf("header hash %x, the node hashes %x", got, want)
+	}
+
+	// a block spending the transaction twice, with proof-of-work for its doubled fee
+	spent := append(template.Transactions, template.Transactions[0])
+	spent = append(spent, bc.coinbaseTransaction(coinbase.BlockchainAddress, template.Height, spent))
+	duplicateNonce, err := miner.New(1).Search(context.Background(), miner.Template{
+		Difficulty: template.Difficulty,
+		Hash: func(nonce int) [32]byte {
+			return proofHash(nonce, template.PreviousHash, template.Timestamp, spent)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bc.SubmitBlock(template.PreviousHash, template.Timestamp, coinbase.BlockchainAddress, hashes(spent[:2]), duplicateNonce); ok {
+		t.Fatal("accepted a block listing a transaction twice")
+	}
+
+	b, ok := bc.SubmitBlock(template.PreviousHash, template.Timestamp, coinbase.BlockchainAddress, hashes(template.Transactions), nonce)
+	if !ok {
+		t.Fatal("rejected the solved block")
+	}
+	if b.Index != template.Height || bc.LastBlock().Hash != b.Hash {
+		t.Fatalf("block %d is not the tip at height %d", b.Index, template.Height)
+	}
+	if len(bc.MemPool) != 0 {
+		t.Fatalf("%d transactions left in the memory pool", len(bc.MemPool))
+	}
+	if got := bc.CalculateWalletBalance(coinbase.BlockchainAddress); got != template.Coinbase.Value {
+		t.Fatalf("coinbase balance = %v, want %v", got, template.Coinbase.Value)
+	}
+
+	if _, ok := bc.SubmitBlock(template.PreviousHash, template.Timestamp, coinbase.BlockchainAddress, nil, nonce); ok {
+		t.Fatal("accepted a block on top of a stale tip")
+	}
+}
@@ -0,0 +1,132 @@
+package blockchain
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	MAX_TIME_ADJUSTMENT = 70 * time.Minute // peer clocks further off than this are not trusted
+	MIN_TIME_SAMPLES    = 5                // clocks, ours included, needed before time is adjusted
+	HANDSHAKE_TIMEOUT   = 5 * time.Second
+)
+
+// timeData holds how far each neighbor's clock was ahead of ours at its last handshake.
+type timeData struct {
+	mut     sync.Mutex
+	offsets map[string]time.Duration
+}
+
+// TimeOffset is the median of the neighbors' clock offsets, our own clock counting as
+// zero, or zero when fewer than MIN_TIME_SAMPLES clocks were sampled, so one or two
+// neighbors can't move our clock, or when the median is more than MAX_TIME_ADJUSTMENT
+// off.
+func (bc *BlockChain) TimeOffset() time.Duration {
+	bc.timeData.mut.Lock()
+	offsets := []time.Duration{0}
+	for _, o := range bc.timeData.offsets {
+		offsets = append(offsets, o)
+	}
+	bc.timeData.mut.Unlock()
+	if len(offsets) < MIN_TIME_SAMPLES {
+		return 0
+	}
+
+	slices.Sort(offsets)
+	median := offsets[len(offsets)/2]
+	if len(offsets)%2 == 0 {
+		median = (offsets[len(offsets)/2-1] + median) / 2
+	}
+	if median > MAX_TIME_ADJUSTMENT || median < -MAX_TIME_ADJUSTMENT {
+		return 0
+	}
+	return median
+}
+
+// AdjustedTime is the local clock corrected by the neighbors' median clock offset.
+func (bc *BlockChain) AdjustedTime() time.Time {
+	return time.Now().Add(bc.TimeOffset())
+}
+
+// MedianTimePast returns the median timestamp of the last span blocks of chain; a block
+// on top of it must be newer.
+func MedianTimePast(chain []*Block, span int) int64 {
+	start := max(len(chain)-span, 0)
+	timestamps := make([]int64, 0, span)
+	for _, b := range chain[start:] {
+		timestamps = append(timestamps, b.TimeStamp)
+	}
+	slices.Sort(timestamps)
+	return timestamps[len(timestamps)/2]
+}
+
+// validTimestamp reports whether b's timestamp is after the median-time-past of the
+// blocks before it and not too far ahead of network-adjusted time.
+func (bc *BlockChain) validTimestamp(b *Block, previous []*Block) bool {
+	if mtp := MedianTimePast(previous, bc.Params.MedianTimeSpan); b.TimeStamp <= mtp {
+		log.Printf("invalid block %d: timestamp %d is not after the median time past %d", b.Index, b.TimeStamp, mtp)
+		return false
+	}
+	if limit := bc.AdjustedTime().Add(bc.Params.MaxFutureBlockTime).Unix(); b.TimeStamp > limit {
+		log.Printf("invalid block %d: timestamp %d is more than %v ahead of network time", b.Index, b.TimeStamp, bc.Params.MaxFutureBlockTime)
+		return false
+	}
+	return true
+}
+
+// nextTimestamp returns the timestamp for a block on top of the chain: network-adjusted
+// time, or one second past the median-time-past if that is later. The caller must hold
+// bc.mut.
+func (bc *BlockChain) nextTimestamp() int64 {
+	return max(bc.AdjustedTime().Unix(), MedianTimePast(bc.Chain, bc.Params.MedianTimeSpan)+1)
+}
+
+// sampleClocks handshakes with every neighbor and records how far its clock is from
+// ours, assuming the reply was sent halfway through the round trip. Neighbors that are
+// gone are forgotten.
+func (bc *BlockChain) sampleClocks(neighbors []string) {
+	var wg sync.WaitGroup
+	for _, n := range neighbors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := grpc.NewClient(n, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				log.Printf("handshake: failed to create grpc client on %s node: %v", n, err)
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), HANDSHAKE_TIMEOUT)
+			defer cancel()
+			sent := time.Now()
+			resp, err := protogen.NewBlockChainServiceClient(conn).Handshake(ctx, &protogen.Empty{})
+			if err != nil {
+				log.Printf("handshake: failed on %s node: %v", n, err)
+				return
+			}
+			midpoint := sent.Add(time.Since(sent) / 2)
+			offset := time.UnixMilli(resp.GetTimestampMs()).Sub(midpoint)
+
+			bc.timeData.mut.Lock()
+			bc.timeData.offsets[n] = offset
+			bc.timeData.mut.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	bc.timeData.mut.Lock()
+	for n := range bc.timeData.offsets {
+		if !slices.Contains(neighbors, n) {
+			delete(bc.timeData.offsets, n)
+		}
+	}
+	bc.timeData.mut.Unlock()
+}
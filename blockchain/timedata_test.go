@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+)
+
+func blocksAt(timestamps ...int64) []*Block {
+	chain := make([]*Block, len(timestamps))
+	for i, ts := range timestamps {
+		chain[i] = &Block{Index: i, TimeStamp: ts}
+	}
+	return chain
+}
+
+func TestMedianTimePast(t *testing.T) {
+	tests := []struct {
+		timestamps []int64
+		want       int64
+	}{
+		{[]int64{100}, 100},
+		{[]int64{100, 300, 200}, 200},
+		// only the last 11 blocks count, out of order timestamps included
+		{[]int64{1, 1, 1, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 15}, 50},
+	}
+	for _, tt := range tests {
+		if got := MedianTimePast(blocksAt(tt.timestamps...), 11); got != tt.want {
+			t.Errorf("MedianTimePast(%v) = %d, want %d", tt.timestamps, got, tt.want)
+		}
+	}
+}
+
+func TestValidTimestamp(t *testing.T) {
+	bc := newTestChain(t)
+	now := time.Now().Unix()
+	previous := blocksAt(now-30, now-20, now-10)
+
+	for _, tt := range []struct {
+		timestamp int64
+		valid     bool
+	}{
+		{now - 20, false}, // the median-time-past itself
+		{now - 19, true},
+		{now + int64(bc.Params.MaxFutureBlockTime/time.Second) - 5, true},
+		{now + int64(bc.Params.MaxFutureBlockTime/time.Second) + 5, false},
+	} {
+		b := &Block{Index: 3, TimeStamp: tt.timestamp}
+		if got := bc.validTimestamp(b, previous); got != tt.valid {
+			t.Errorf("validTimestamp(%d) = %t, want %t", tt.timestamp-now, got, tt.valid)
+		}
+	}
+}
+
+func TestTimeOffset(t *testing.T) {
+	bc := newTestChain(t)
+	setOffsets := func(offsets ...time.Duration) {
+		bc.timeData.mut.Lock()
+		defer bc.timeData.mut.Unlock()
+		bc.timeData.offsets = make(map[string]time.Duration)
+		for i, o := range offsets {
+			bc.timeData.offsets[string(rune('a'+i))] = o
+		}
+	}
+
+	setOffsets(time.Hour)
+	if got := bc.TimeOffset(); got != 0 {
+		t.Fatalf("one neighbor moved the clock by %v", got)
+	}
+	setOffsets(time.Minute, 2*time.Minute, 3*time.Minute, -time.Minute)
+	if got := bc.TimeOffset(); got != time.Minute {
+		t.Fatalf("offset = %v, want the median of 1m", got)
+	}
+	setOffsets(2*time.Hour, 2*time.Hour, 2*time.Hour, 2*time.Hour)
+	if got := bc.TimeOffset(); got != 0 {
+		t.Fatalf("offset = %v, want clocks more than %v off ignored", got, MAX_TIME_ADJUSTMENT)
+	}
+
+	setOffsets(time.Minute, time.Minute, time.Minute, time.Minute, time.Minute)
+	if d := bc.AdjustedTime().Sub(time.Now()); d < 59*time.Second || d > 61*time.Second {
+		t.Fatalf("adjusted time is %v ahead, want 1m", d)
+	}
+}
@@ -0,0 +1,71 @@
+package chainparams
+
+import (
+	"fmt"
+	"math"
+)
+
+const MAX_HALVINGS = 64 // the subsidy is zero after this many halvings
+
+// Emission is the schedule new coins are created on. The genesis block creates
+// GenesisAllocation coins; the block at height h pays a subsidy of InitialSubsidy halved
+// every HalvingInterval blocks, cut short so the coins ever issued never exceed
+// MaxSupply, and its reward can't be spent until CoinbaseMaturity more blocks are on top
+// of it.
+type Emission struct {
+	InitialSubsidy    float64
+	HalvingInterval   int
+	MaxSupply         float64
+	CoinbaseMaturity  int
+	GenesisAllocation float64 // the sum of the genesis allocations
+}
+
+func (e Emission) Validate() error {
+	if e.InitialSubsidy <= 0 {
+		return fmt.Errorf("chainparams: initial subsidy must be positive")
+	}
+	if e.HalvingInterval <= 0 {
+		return fmt.Errorf("chainparams: halving interval must be positive")
+	}
+	if e.MaxSupply <= 0 {
+		return fmt.Errorf("chainparams: max supply must be positive")
+	}
+	if e.CoinbaseMaturity < 0 {
+		return fmt.Errorf("chainparams: coinbase maturity must not be negative")
+	}
+	if e.GenesisAllocation > e.MaxSupply {
+		return fmt.Errorf("chainparams: genesis allocations of %v exceed the max supply of %v", e.GenesisAllocation, e.MaxSupply)
+	}
+	return nil
+}
+
+// Issued returns the coins created by the blocks up to and including height.
+func (e Emission) Issued(height int) float64 {
+	issued := e.GenesisAllocation
+	for era := 0; era < MAX_HALVINGS && height > 0; era++ {
+		blocks := min(height, e.HalvingInterval)
+		issued += float64(blocks) * e.InitialSubsidy / math.Pow(2, float64(era))
+		height -= blocks
+	}
+	return min(issued, e.MaxSupply)
+}
+
+// Subsidy returns the coins the block at height may create on top of its fees.
+func (e Emission) Subsidy(height int) float32 {
+	if height <= 0 { // the genesis block only holds the allocations
+		return 0
+	}
+	return float32(e.Issued(height) - e.Issued(height-1))
+}
+
+// NextHalving returns the height of the first block after height with a halved subsidy.
+func (e Emission) NextHalving(height int) int {
+	era := max(height-1, 0) / e.HalvingInterval
+	return (era+1)*e.HalvingInterval + 1
+}
+
+// Mature reports whether a coinbase transaction in the block at height can be spent
+// in the block at spendHeight.
+func (e Emission) Mature(height, spendHeight int) bool {
+	return spendHeight-height >= e.CoinbaseMaturity
+}
@@ -0,0 +1,76 @@
+package chainparams
+
+import "testing"
+
+func TestEmission(t *testing.T) {
+	e := Emission{InitialSubsidy: 2, HalvingInterval: 10, MaxSupply: 35, GenesisAllocation: 10}
+	if err := e.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		height  int
+		subsidy float32
+		issued  float64
+	}{
+		{0, 0, 10},
+		{1, 2, 12},
+		{10, 2, 30},
+		{11, 1, 31},
+		{15, 1, 35},
+		{16, 0, 35}, // capped before the second era ends
+		{1000, 0, 35},
+	}
+	for _, tt := range tests {
+		if got := e.Subsidy(tt.height); got != tt.subsidy {
+			t.Errorf("Subsidy(%d) = %v, want %v", tt.height, got, tt.subsidy)
+		}
+		if got := e.Issued(tt.height); got != tt.issued {
+			t.Errorf("Issued(%d) = %v, want %v", tt.height, got, tt.issued)
+		}
+	}
+
+	for height, want := range map[int]int{0: 11, 1: 11, 10: 11, 11: 21, 20: 21} {
+		if got := e.NextHalving(height); got != want {
+			t.Errorf("NextHalving(%d) = %d, want %d", height, got, want)
+		}
+	}
+}
+
+func TestEmissionUncapped(t *testing.T) {
+	// the mainnet supply is what the halvings converge to, so it is never cut short
+	e := Mainnet.Emission
+	if got := e.Issued(MAX_HALVINGS * e.HalvingInterval); got > e.MaxSupply {
+		t.Fatalf("issued %v, above the max supply of %v", got, e.MaxSupply)
+	}
+	if got := e.Subsidy(e.HalvingInterval + 1); got != float32(e.InitialSubsidy/2) {
+		t.Fatalf("subsidy after the first halving = %v, want %v", got, e.InitialSubsidy/2)
+	}
+}
+
+func TestMature(t *testing.T) {
+	e := Emission{CoinbaseMaturity: 3}
+	if e.Mature(5, 7) {
+		t.Fatal("reward spendable two blocks after it was mined")
+	}
+	if !e.Mature(5, 8) {
+		t.Fatal("reward not spendable three blocks after it was mined")
+	}
+}
+
+func TestEmissionValidate(t *testing.T) {
+	valid := Emission{InitialSubsidy: 2, HalvingInterval: 10, MaxSupply: 40}
+	for name, edit := range map[string]func(*Emission){
+		"zero subsidy":         func(e *Emission) { e.InitialSubsidy = 0 },
+		"zero interval":        func(e *Emission) { e.HalvingInterval = 0 },
+		"zero supply":          func(e *Emission) { e.MaxSupply = 0 },
+		"negative maturity":    func(e *Emission) { e.CoinbaseMaturity = -1 },
+		"allocations too high": func(e *Emission) { e.GenesisAllocation = 41 },
+	} {
+		e := valid
+		edit(&e)
+		if e.Validate() == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
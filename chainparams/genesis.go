@@ -0,0 +1,125 @@
+package chainparams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/zde37/Zero-Chain/address"
+)
+
+// Genesis describes a network's first block: its timestamp and the coins it allocates.
+type Genesis struct {
+	Timestamp   int64 // unix seconds
+	Allocations []Allocation
+}
+
+// Allocation pre-funds an address in the genesis block. Allocated coins can be spent
+// right away.
+type Allocation struct {
+	Address string  `json:"address"`
+	Value   float32 `json:"value"`
+}
+
+// genesisFile is the format of a genesis file. Fields left out are taken from the base
+// network.
+type genesisFile struct {
+	Name             string       `json:"name"`
+	Network          string       `json:"network"` // base network: mainnet, testnet or regtest
+	Timestamp        int64        `json:"timestamp"`
+	Difficulty       *int         `json:"difficulty"`
+	InitialSubsidy   *float64     `json:"initial_subsidy"`
+	HalvingInterval  *int         `json:"halving_interval"`
+	MaxSupply        *float64     `json:"max_supply"`
+	CoinbaseMaturity *int         `json:"coinbase_maturity"`
+	PortRangeStart   *uint16      `json:"port_range_start"`
+	PortRangeEnd     *uint16      `json:"port_range_end"`
+	Checkpoints      []Checkpoint `json:"checkpoints"`
+	AssumeValid      string       `json:"assume_valid"`
+	Allocations      []Allocation `json:"allocations"`
+}
+
+// LoadGenesisFile reads the parameters of a private network from a JSON genesis file,
+// for example:
+//
+//	{
+//	  "name": "devnet",
+//	  "network": "testnet",
+//	  "timestamp": 1718000000,
+//	  "difficulty": 3,
+//	  "coinbase_maturity": 10,
+//	  "allocations": [{"address": "mg9xRxRRvqtBcWRut1htGQG6vC6WVrV38d", "value": 1000}]
+//	}
+//
+// The network's addresses use the version bytes of its base network.
+func LoadGenesisFile(path string) (Params, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Params{}, fmt.Errorf("chainparams: failed to read genesis file: %w", err)
+	}
+	var f genesisFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return Params{}, fmt.Errorf("chainparams: failed to parse genesis file: %w", err)
+	}
+
+	p, err := ByName(f.Network)
+	if err != nil {
+		return Params{}, err
+	}
+	if _, err := ByName(f.Name); err == nil {
+		return Params{}, fmt.Errorf("chainparams: network name %q is taken by a built-in network", f.Name)
+	}
+	if f.Timestamp <= 0 {
+		return Params{}, fmt.Errorf("chainparams: genesis timestamp must be positive")
+	}
+	p.Name = f.Name
+	p.Genesis = Genesis{Timestamp: f.Timestamp, Allocations: f.Allocations}
+	p.GenesisHash = ""
+	p.Checkpoints = f.Checkpoints
+	p.AssumeValid = f.AssumeValid
+	if f.Difficulty != nil {
+		p.Difficulty = *f.Difficulty
+	}
+	if f.InitialSubsidy != nil {
+		p.Emission.InitialSubsidy = *f.InitialSubsidy
+	}
+	if f.HalvingInterval != nil {
+		p.Emission.HalvingInterval = *f.HalvingInterval
+	}
+	if f.MaxSupply != nil {
+		p.Emission.MaxSupply = *f.MaxSupply
+	}
+	if f.CoinbaseMaturity != nil {
+		p.Emission.CoinbaseMaturity = *f.CoinbaseMaturity
+	}
+	if f.PortRangeStart != nil {
+		p.PortRangeStart = *f.PortRangeStart
+	}
+	if f.PortRangeEnd != nil {
+		p.PortRangeEnd = *f.PortRangeEnd
+	}
+
+	allocated := make(map[string]bool, len(f.Allocations))
+	p.Emission.GenesisAllocation = 0
+	for _, a := range f.Allocations {
+		parsed, err := address.Parse(a.Address)
+		if err != nil {
+			return Params{}, fmt.Errorf("chainparams: invalid allocation address %s: %w", a.Address, err)
+		}
+		if parsed.Network != p.Network {
+			return Params{}, fmt.Errorf("chainparams: allocation address %s is not a %s address", a.Address, p.Network.Name)
+		}
+		if a.Value <= 0 {
+			return Params{}, fmt.Errorf("chainparams: allocation to %s must be positive", a.Address)
+		}
+		if allocated[a.Address] {
+			return Params{}, fmt.Errorf("chainparams: %s is allocated more than once", a.Address)
+		}
+		allocated[a.Address] = true
+		p.Emission.GenesisAllocation += float64(a.Value)
+	}
+	return p, p.Validate()
+}
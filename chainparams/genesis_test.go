@@ -0,0 +1,101 @@
+package chainparams
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/address"
+)
+
+func writeGenesis(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadGenesisFile(t *testing.T) {
+	alice := address.Address{Network: address.Testnet, Hash: [address.HASH_LEN]byte{1}}.String()
+	bob := address.Address{Network: address.Testnet, Hash: [address.HASH_LEN]byte{2}}.String()
+	path := writeGenesis(t, fmt.Sprintf(`{
+		"name": "devnet",
+		"network": "testnet",
+		"timestamp": 1718000000,
+		"difficulty": 3,
+		"coinbase_maturity": 10,
+		"allocations": [{"address": %q, "value": 1000}, {"address": %q, "value": 500}]
+	}`, alice, bob))
+
+	p, err := LoadGenesisFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "devnet" || p.Network != address.Testnet || p.GenesisHash != "" {
+		t.Fatalf("network = %s on %s with genesis hash %q", p.Name, p.Network.Name, p.GenesisHash)
+	}
+	if p.Difficulty != 3 || p.Emission.CoinbaseMaturity != 10 || p.Emission.GenesisAllocation != 1500 {
+		t.Fatalf("difficulty %d, maturity %d, allocated %v", p.Difficulty, p.Emission.CoinbaseMaturity, p.Emission.GenesisAllocation)
+	}
+	// fields left out come from the base network
+	if p.Emission.InitialSubsidy != Testnet.Emission.InitialSubsidy || p.PortRangeStart != Testnet.PortRangeStart {
+		t.Fatalf("subsidy %v and port %d not taken from testnet", p.Emission.InitialSubsidy, p.PortRangeStart)
+	}
+	if len(p.Genesis.Allocations) != 2 || p.Genesis.Timestamp != 1718000000 {
+		t.Fatalf("genesis = %+v", p.Genesis)
+	}
+}
+
+func TestLoadGenesisFileErrors(t *testing.T) {
+	mainnet := address.Address{Network: address.Mainnet, Hash: [address.HASH_LEN]byte{1}}.String()
+	testnet := address.Address{Network: address.Testnet, Hash: [address.HASH_LEN]byte{1}}.String()
+	tests := map[string]string{
+		"unknown base network": `{"name": "devnet", "network": "othernet", "timestamp": 1}`,
+		"built-in name":        `{"name": "mainnet", "network": "testnet", "timestamp": 1}`,
+		"missing timestamp":    `{"name": "devnet", "network": "testnet"}`,
+		"unknown field":        `{"name": "devnet", "network": "testnet", "timestamp": 1, "reward": 5}`,
+		"invalid difficulty":   `{"name": "devnet", "network": "testnet", "timestamp": 1, "difficulty": 65}`,
+		"other network address": fmt.Sprintf(`{"name": "devnet", "network": "testnet", "timestamp": 1,
+			"allocations": [{"address": %q, "value": 1}]}`, mainnet),
+		"zero allocation": fmt.Sprintf(`{"name": "devnet", "network": "testnet", "timestamp": 1,
+			"allocations": [{"address": %q, "value": 0}]}`, testnet),
+		"allocated twice": fmt.Sprintf(`{"name": "devnet", "network": "testnet", "timestamp": 1,
+			"allocations": [{"address": %q, "value": 1}, {"address": %q, "value": 2}]}`, testnet, testnet),
+		"allocations above max supply": fmt.Sprintf(`{"name": "devnet", "network": "testnet", "timestamp": 1, "max_supply": 10,
+			"allocations": [{"address": %q, "value": 11}]}`, testnet),
+	}
+	for name, contents := range tests {
+		if _, err := LoadGenesisFile(writeGenesis(t, contents)); err == nil {
+			t.Errorf("%s: loaded", name)
+		}
+	}
+}
+
+func TestBuiltInNetworks(t *testing.T) {
+	for _, p := range networks {
+		if err := p.Validate(); err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+		}
+		if got, err := ByName(p.Name); err != nil || got.Name != p.Name {
+			t.Errorf("ByName(%s) = %s, %v", p.Name, got.Name, err)
+		}
+	}
+	if _, err := ByName("othernet"); err == nil {
+		t.Error("found an unknown network")
+	}
+}
+
+func TestParseCheckpoint(t *testing.T) {
+	c, err := ParseCheckpoint("12:" + Mainnet.GenesisHash)
+	if err != nil || c.Height != 12 || c.Hash != Mainnet.GenesisHash {
+		t.Fatalf("ParseCheckpoint = %+v, %v", c, err)
+	}
+	for _, s := range []string{"12", "x:" + Mainnet.GenesisHash, "-1:" + Mainnet.GenesisHash, "12:abcd"} {
+		if _, err := ParseCheckpoint(s); err == nil {
+			t.Errorf("ParseCheckpoint(%q) succeeded", s)
+		}
+	}
+}
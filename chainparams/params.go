@@ -0,0 +1,204 @@
+// Package chainparams defines the networks a node can run on: each network's fixed
+// genesis block, its consensus rules (proof-of-work difficulty and emission schedule)
+// and the ports its nodes find each other on. Private networks are described by a
+// genesis file, see LoadGenesisFile.
+package chainparams
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+)
+
+const MAX_DIFFICULTY = 64 // a sha256 hash has 64 hex digits
+
+// Params are the parameters every node of a network must agree on.
+type Params struct {
+	Name        string
+	Network     address.Network // version bytes of the network's addresses and keys
+	Genesis     Genesis
+	GenesisHash string // hex; empty for networks loaded from a genesis file
+	Difficulty  int    // leading zero hex digits a block's proof hash needs
+	Emission    Emission
+
+	// block timestamps must be after the median of the last MedianTimeSpan blocks and
+	// at most MaxFutureBlockTime ahead of network-adjusted time
+	MedianTimeSpan     int
+	MaxFutureBlockTime time.Duration
+	// the difficulty doesn't retarget, so continuous miners wait MinBlockInterval after
+	// the tip's timestamp before searching, bounding how fast the chain grows
+	MinBlockInterval time.Duration
+
+	// MineOnDemand networks are for tests: nodes don't start mining by themselves and
+	// blocks are mined with GenerateBlocks instead
+	MineOnDemand bool
+
+	Checkpoints []Checkpoint
+	AssumeValid string // hex hash of a block whose ancestors' proof-of-work and signatures aren't re-checked; empty to check every block
+
+	// nodes look for neighbors on these gRPC ports of the hosts in the IP range
+	PortRangeStart       uint16
+	PortRangeEnd         uint16
+	NeighborIPRangeStart uint8
+	NeighborIPRangeEnd   uint8
+}
+
+var (
+	Mainnet = Params{
+		Name:        "mainnet",
+		Network:     address.Mainnet,
+		Genesis:     Genesis{Timestamp: 1717200000}, // 2024-06-01 00:00:00 UTC
+		GenesisHash: "a3c5af70c15a747b0c3e023a63a67ffad22f6bb6ada0b0642ef7a05c9f80628b",
+		Difficulty:  4,
+		Emission: Emission{
+			InitialSubsidy:   2.0,
+			HalvingInterval:  100000, // blocks
+			MaxSupply:        400000, // what the halvings converge to
+			CoinbaseMaturity: 100,    // confirmations before a block reward can be spent
+		},
+		MedianTimeSpan:       11,
+		MaxFutureBlockTime:   10 * time.Minute,
+		MinBlockInterval:     30 * time.Second,
+		PortRangeStart:       7000,
+		PortRangeEnd:         7003,
+		NeighborIPRangeStart: 0,
+		NeighborIPRangeEnd:   1,
+		Checkpoints:          []Checkpoint{{Height: 0, Hash: "a3c5af70c15a747b0c3e023a63a67ffad22f6bb6ada0b0642ef7a05c9f80628b"}},
+	}
+
+	Testnet = Params{
+		Name:        "testnet",
+		Network:     address.Testnet,
+		Genesis:     Genesis{Timestamp: 1717286400}, // 2024-06-02 00:00:00 UTC
+		GenesisHash: "493f2b3268328150c819a30e27895c3b9ffba2b95d341398f0ea3d945b433734",
+		Difficulty:  4,
+		Emission: Emission{
+			InitialSubsidy:   2.0,
+			HalvingInterval:  100000,
+			MaxSupply:        400000,
+			CoinbaseMaturity: 100,
+		},
+		MedianTimeSpan:       11,
+		MaxFutureBlockTime:   10 * time.Minute,
+		MinBlockInterval:     30 * time.Second,
+		PortRangeStart:       17000,
+		PortRangeEnd:         17003,
+		NeighborIPRangeStart: 0,
+		NeighborIPRangeEnd:   1,
+		Checkpoints:          []Checkpoint{{Height: 0, Hash: "493f2b3268328150c819a30e27895c3b9ffba2b95d341398f0ea3d945b433734"}},
+	}
+
+	Regtest = Params{
+		Name:        "regtest",
+		Network:     address.Regtest,
+		Genesis:     Genesis{Timestamp: 1717372800}, // 2024-06-03 00:00:00 UTC
+		GenesisHash: "2c22eb8a86cc16607c93c859edbb4540de35b38866e38080f78be0e986dd48d6",
+		Difficulty:  1,
+		Emission: Emission{
+			InitialSubsidy:   2.0,
+			HalvingInterval:  100000,
+			MaxSupply:        400000,
+			CoinbaseMaturity: 100,
+		},
+		MedianTimeSpan:       11,
+		MaxFutureBlockTime:   10 * time.Minute,
+		MinBlockInterval:     30 * time.Second,
+		MineOnDemand:         true,
+		PortRangeStart:       27000,
+		PortRangeEnd:         27003,
+		NeighborIPRangeStart: 0,
+		NeighborIPRangeEnd:   1,
+		Checkpoints:          []Checkpoint{{Height: 0, Hash: "2c22eb8a86cc16607c93c859edbb4540de35b38866e38080f78be0e986dd48d6"}},
+	}
+
+	networks = []Params{Mainnet, Testnet, Regtest}
+)
+
+func ByName(name string) (Params, error) {
+	for _, p := range networks {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Params{}, fmt.Errorf("chainparams: unknown network %q", name)
+}
+
+// Checkpoint pins the block at Height; chains with another block there are rejected.
+type Checkpoint struct {
+	Height int    `json:"height"`
+	Hash   string `json:"hash"` // hex
+}
+
+// ParseCheckpoint parses a checkpoint written as height:hash.
+func ParseCheckpoint(s string) (Checkpoint, error) {
+	height, hash, ok := strings.Cut(s, ":")
+	if !ok {
+		return Checkpoint{}, fmt.Errorf("chainparams: checkpoint %q is not height:hash", s)
+	}
+	h, err := strconv.Atoi(height)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("chainparams: invalid checkpoint height %q", height)
+	}
+	c := Checkpoint{Height: h, Hash: hash}
+	return c, c.validate()
+}
+
+func (c Checkpoint) validate() error {
+	if c.Height < 0 {
+		return fmt.Errorf("chainparams: checkpoint height must not be negative")
+	}
+	return validHash(c.Hash)
+}
+
+func validHash(s string) error {
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 32 {
+		return fmt.Errorf("chainparams: %q is not a hex block hash", s)
+	}
+	return nil
+}
+
+// DefaultPort is the gRPC port a node of the network listens on unless told otherwise.
+func (p Params) DefaultPort() uint16 {
+	return p.PortRangeStart
+}
+
+func (p Params) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("chainparams: network name must not be empty")
+	}
+	if p.Difficulty < 1 || p.Difficulty > MAX_DIFFICULTY {
+		return fmt.Errorf("chainparams: difficulty must be between 1 and %d", MAX_DIFFICULTY)
+	}
+	if p.MedianTimeSpan < 1 {
+		return fmt.Errorf("chainparams: median time span must be at least 1 block")
+	}
+	if p.MaxFutureBlockTime <= 0 || p.MinBlockInterval < 0 {
+		return fmt.Errorf("chainparams: invalid block time limits %v and %v", p.MaxFutureBlockTime, p.MinBlockInterval)
+	}
+	if p.PortRangeStart == 0 || p.PortRangeEnd < p.PortRangeStart {
+		return fmt.Errorf("chainparams: invalid port range %d-%d", p.PortRangeStart, p.PortRangeEnd)
+	}
+	if p.NeighborIPRangeEnd < p.NeighborIPRangeStart {
+		return fmt.Errorf("chainparams: invalid neighbor ip range %d-%d", p.NeighborIPRangeStart, p.NeighborIPRangeEnd)
+	}
+	pinned := make(map[int]string, len(p.Checkpoints))
+	for _, c := range p.Checkpoints {
+		if err := c.validate(); err != nil {
+			return err
+		}
+		if h, ok := pinned[c.Height]; ok && h != c.Hash {
+			return fmt.Errorf("chainparams: conflicting checkpoints at height %d", c.Height)
+		}
+		pinned[c.Height] = c.Hash
+	}
+	if p.AssumeValid != "" {
+		if err := validHash(p.AssumeValid); err != nil {
+			return err
+		}
+	}
+	return p.Emission.Validate()
+}
@@ -0,0 +1,147 @@
+// Package client is a Go SDK for the wallet service. Transactions are prepared by the
+// wallet server but signed in the caller's process, so private keys are never sent
+// over the network.
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	conn   *grpc.ClientConn
+	wallet protogen.WalletServiceClient
+}
+
+// New connects to the wallet gRPC server at addr (e.g. "127.0.0.1:5000").
+func New(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to create grpc client: %v", err)
+	}
+	return &Client{conn: conn, wallet: protogen.NewWalletServiceClient(conn)}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Send transfers value to recipient from the address owned by key and returns the
+// nonce the transaction was sent with, which is needed to replace or cancel it.
+func (c *Client) Send(ctx context.Context, key *ecdsa.PrivateKey, sender, recipient string, value, fee float32) (uint64, error) {
+	prepared, err := c.wallet.PrepareTransaction(ctx, &protogen.PrepareTransactionRequest{
+		SenderBlockchainAddress:    sender,
+		RecipientBlockchainAddress: recipient,
+		Value:                      value,
+		Fee:                        fee,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("client: failed to prepare transaction: %v", err)
+	}
+
+	signature, err := Sign(key, []byte(prepared.GetPayload()))
+	if err != nil {
+		return 0, err
+	}
+	if err := c.submit(ctx, key, prepared.GetSenderBlockchainAddress(), prepared.GetRecipientBlockchainAddress(),
+		prepared.GetValue(), prepared.GetFee(), prepared.GetNonce(), signature); err != nil {
+		return 0, err
+	}
+	return prepared.GetNonce(), nil
+}
+
+// Cancel replaces the pending transaction with the given nonce by a zero-value
+// transfer back to sender; fee must be higher than the pending transaction's fee.
+func (c *Client) Cancel(ctx context.Context, key *ecdsa.PrivateKey, sender string, nonce uint64, fee float32) error {
+	payload, err := transaction.NewMetaData(nil, nil, sender, sender, 0, fee, nonce).MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("client: failed to build signing payload: %v", err)
+	}
+	signature, err := Sign(key, payload)
+	if err != nil {
+		return err
+	}
+	return c.submit(ctx, key, sender, sender, 0, fee, nonce, signature)
+}
+
+func (c *Client) submit(ctx context.Context, key *ecdsa.PrivateKey, sender, recipient string, value, fee float32,
+	nonce uint64, signature *helpers.Signature) error {
+	_, err := c.wallet.SubmitSignedTransaction(ctx, &protogen.TransactionRequest{
+		SenderBlockchainAddress:    sender,
+		RecipientBlockchainAddress: recipient,
+		SenderPublicKey:            PublicKeyHex(&key.PublicKey),
+		Value:                      value,
+		Fee:                        fee,
+		Nonce:                      nonce,
+		Signature:                  signature.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("client: failed to submit transaction: %v", err)
+	}
+	return nil
+}
+
+// Sign signs a transaction payload the way the chain verifies it: ECDSA over SHA-256(payload).
+func Sign(key *ecdsa.PrivateKey, payload []byte) (*helpers.Signature, error) {
+	hash := sha256.Sum256(payload)
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to sign payload: %v", err)
+	}
+	return &helpers.Signature{R: r, S: s}, nil
+}
+
+// PublicKeyHex encodes a public key as the wallet service expects it: hex X followed by hex Y.
+func PublicKeyHex(publicKey *ecdsa.PublicKey) string {
+	return fmt.Sprintf("%064x%064x", publicKey.X.Bytes(), publicKey.Y.Bytes())
+}
+
+// ImportWallet stores privateKey (hex, PEM or WIF, see wallet.ParsePrivateKey) in the
+// wallet server's keystore under name.
+func (c *Client) ImportWallet(ctx context.Context, name, passphrase, privateKey, format string) (*protogen.KeystoreWallet, error) {
+	resp, err := c.wallet.ImportWallet(ctx, &protogen.ImportWalletRequest{
+		Name:       name,
+		Passphrase: passphrase,
+		PrivateKey: privateKey,
+		Format:     format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to import wallet: %v", err)
+	}
+	return resp.GetWallet(), nil
+}
+
+// ExportWallet returns the private key of a keystore wallet encoded in format.
+func (c *Client) ExportWallet(ctx context.Context, name, passphrase, format string) (string, error) {
+	resp, err := c.wallet.ExportWallet(ctx, &protogen.ExportWalletRequest{
+		Name:       name,
+		Passphrase: passphrase,
+		Format:     format,
+	})
+	if err != nil {
+		return "", fmt.Errorf("client: failed to export wallet: %v", err)
+	}
+	return resp.GetPrivateKey(), nil
+}
+
+// ExportPublicKey returns the public key, address and (for HD wallets) public account
+// key of a keystore wallet for watch-only use.
+func (c *Client) ExportPublicKey(ctx context.Context, name, format string) (*protogen.ExportPublicKeyResponse, error) {
+	resp, err := c.wallet.ExportPublicKey(ctx, &protogen.ExportPublicKeyRequest{
+		Name:   name,
+		Format: format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to export public key: %v", err)
+	}
+	return resp, nil
+}
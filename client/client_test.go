@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestSign(t *testing.T) {
+	w := wallet.New()
+	md := transaction.NewMetaData(nil, w.PublicKey, w.BlockchainAddress, "recipient", 1, 0.1, 3)
+	payload, err := md.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := Sign(w.PrivateKey, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the chain sees the signature and public key only in their hex encodings
+	md.SenderPublicKey = helpers.PublicKeyFromString(PublicKeyHex(w.PublicKey))
+	if !md.VerifySignature(helpers.SignatureFromString(signature.String())) {
+		t.Fatal("the chain rejects a signature made by the client")
+	}
+	md.Nonce++
+	if md.VerifySignature(signature) {
+		t.Fatal("the signature verifies a different transaction")
+	}
+}
+
+// TestPublicKeyHex encodes keys whose coordinates have leading zero bytes.
+func TestPublicKeyHex(t *testing.T) {
+	for found := 0; found < 2; {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key.X.Bytes()) == 32 && len(key.Y.Bytes()) == 32 {
+			continue
+		}
+		found++
+		s := PublicKeyHex(&key.PublicKey)
+		if len(s) != 128 {
+			t.Fatalf("encoded a public key in %d characters, want 128", len(s))
+		}
+		if got := helpers.PublicKeyFromString(s); got.X.Cmp(key.X) != 0 || got.Y.Cmp(key.Y) != 0 {
+			t.Fatalf("%s decodes to a different key", s)
+		}
+	}
+}
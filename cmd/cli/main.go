@@ -0,0 +1,190 @@
+// Command cli manages keystore wallets on a wallet server, controls a node's miner and
+// converts keys between formats offline.
+//
+//	cli [-wallet addr] [-node addr] [-network name] [-admin-token token] <command> [flags]
+//
+// Commands:
+//
+//	import        store a private key (from -key or stdin) in the keystore
+//	export        print a keystore wallet's private key
+//	export-public print a keystore wallet's public key and address for watch-only use
+//	convert       re-encode a private key (from -key or stdin) without contacting a server
+//	mining        start, stop or report on the node's miner, or set its workers (-n) or
+//	              coinbase address (-address): mining start|stop|status|workers|coinbase;
+//	              mining generate -n N -address addr mines N blocks on a regtest node
+//
+// Passphrases are taken from -passphrase or the ZERO_CHAIN_PASSPHRASE environment variable.
+// Changing the node's miner needs the node's admin token, taken from -admin-token or the
+// ZERO_CHAIN_ADMIN_TOKEN environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/client"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+const (
+	PASSPHRASE_ENV  = "ZERO_CHAIN_PASSPHRASE"
+	ADMIN_TOKEN_ENV = "ZERO_CHAIN_ADMIN_TOKEN"
+)
+
+func main() {
+	log.SetFlags(0)
+	walletAddr := flag.String("wallet", "127.0.0.1:5000", "wallet grpc server address")
+	nodeAddr := flag.String("node", "127.0.0.1:7000", "blockchain grpc server address")
+	network := flag.String("network", "mainnet", "network for addresses and WIF keys: mainnet, testnet or regtest")
+	adminToken := flag.String("admin-token", os.Getenv(ADMIN_TOKEN_ENV), "node admin token, required to start, stop or configure the miner")
+	flag.Usage = usage
+	flag.Parse()
+
+	net, err := address.NetworkByName(*network)
+	if err != nil {
+		log.Fatal(err)
+	}
+	address.SetNetwork(net)
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	args := flag.Args()[1:]
+	switch flag.Arg(0) {
+	case "import":
+		err = importWallet(*walletAddr, args)
+	case "export":
+		err = exportWallet(*walletAddr, args)
+	case "export-public":
+		err = exportPublicKey(*walletAddr, args)
+	case "convert":
+		err = convert(args)
+	case "mining":
+		err = mining(*nodeAddr, *adminToken, args)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [-wallet addr] [-node addr] [-network name] [-admin-token token] import|export|export-public|convert|mining [flags]\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+func importWallet(walletAddr string, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	name := fs.String("name", "", "wallet name")
+	passphrase := fs.String("passphrase", os.Getenv(PASSPHRASE_ENV), "keystore passphrase")
+	key := fs.String("key", "", "private key; read from stdin when empty")
+	format := fs.String("format", "", "key format: hex, sec1, pkcs8 or wif (detected when empty)")
+	fs.Parse(args)
+
+	privateKey, err := readKey(*key)
+	if err != nil {
+		return err
+	}
+	return withClient(walletAddr, func(ctx context.Context, c *client.Client) error {
+		w, err := c.ImportWallet(ctx, *name, *passphrase, privateKey, *format)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported %s: %s\n", w.GetName(), w.GetBlockchainAddress())
+		return nil
+	})
+}
+
+func exportWallet(walletAddr string, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	name := fs.String("name", "", "wallet name")
+	passphrase := fs.String("passphrase", os.Getenv(PASSPHRASE_ENV), "keystore passphrase")
+	format := fs.String("format", wallet.FORMAT_HEX, "key format: hex, sec1, pkcs8 or wif")
+	fs.Parse(args)
+
+	return withClient(walletAddr, func(ctx context.Context, c *client.Client) error {
+		key, err := c.ExportWallet(ctx, *name, *passphrase, *format)
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.TrimSpace(key))
+		return nil
+	})
+}
+
+func exportPublicKey(walletAddr string, args []string) error {
+	fs := flag.NewFlagSet("export-public", flag.ExitOnError)
+	name := fs.String("name", "", "wallet name")
+	format := fs.String("format", wallet.FORMAT_HEX, "public key format: hex or pem")
+	fs.Parse(args)
+
+	return withClient(walletAddr, func(ctx context.Context, c *client.Client) error {
+		resp, err := c.ExportPublicKey(ctx, *name, *format)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("address: %s\n", resp.GetWallet().GetBlockchainAddress())
+		fmt.Printf("public key: %s\n", strings.TrimSpace(resp.GetPublicKey()))
+		if resp.GetAccountKey() != "" {
+			fmt.Printf("account key: %s\n", resp.GetAccountKey())
+		}
+		return nil
+	})
+}
+
+func convert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	key := fs.String("key", "", "private key; read from stdin when empty")
+	from := fs.String("from", "", "input format: hex, sec1, pkcs8 or wif (detected when empty)")
+	to := fs.String("to", wallet.FORMAT_WIF, "output format: hex, sec1, pkcs8 or wif")
+	fs.Parse(args)
+
+	s, err := readKey(*key)
+	if err != nil {
+		return err
+	}
+	privateKey, err := wallet.ParsePrivateKey(s, *from)
+	if err != nil {
+		return err
+	}
+	encoded, err := wallet.EncodePrivateKey(privateKey, *to)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.TrimSpace(encoded))
+	fmt.Printf("address: %s\n", wallet.Address(&privateKey.PublicKey))
+	return nil
+}
+
+func readKey(key string) (string, error) {
+	if key != "" {
+		return key, nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key from stdin: %v", err)
+	}
+	return string(b), nil
+}
+
+func withClient(walletAddr string, f func(ctx context.Context, c *client.Client) error) error {
+	c, err := client.New(walletAddr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return f(ctx, c)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func mining(nodeAddr, adminToken string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mining start|stop|status|workers|coinbase|generate [flags]")
+	}
+	if args[0] == "generate" {
+		return generate(nodeAddr, args[1:])
+	}
+
+	var call func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error)
+	switch args[0] {
+	case "start":
+		call = func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error) {
+			return c.StartMining(ctx, &protogen.Empty{})
+		}
+	case "stop":
+		call = func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error) {
+			return c.StopMining(ctx, &protogen.Empty{})
+		}
+	case "status":
+		call = func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error) {
+			return c.GetMiningStatus(ctx, &protogen.Empty{})
+		}
+	case "workers":
+		fs := flag.NewFlagSet("mining workers", flag.ExitOnError)
+		workers := fs.Int("n", 0, "number of mining goroutines, one per CPU when 0")
+		fs.Parse(args[1:])
+		call = func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error) {
+			return c.SetMiningWorkers(ctx, &protogen.MiningWorkersRequest{Workers: int32(*workers)})
+		}
+	case "coinbase":
+		fs := flag.NewFlagSet("mining coinbase", flag.ExitOnError)
+		addr := fs.String("address", "", "address to pay block rewards to")
+		fs.Parse(args[1:])
+		call = func(ctx context.Context, c protogen.BlockChainServiceClient) (*protogen.MiningStatus, error) {
+			return c.SetCoinbaseAddress(ctx, &protogen.CoinbaseAddressRequest{BlockchainAddress: *addr})
+		}
+	default:
+		return fmt.Errorf("unknown mining command %q", args[0])
+	}
+
+	conn, err := grpc.NewClient(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if adminToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+adminToken)
+	}
+	s, err := call(ctx, protogen.NewBlockChainServiceClient(conn))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("running: %t (%s)\n", s.GetRunning(), s.GetMode())
+	fmt.Printf("workers: %d\n", s.GetWorkers())
+	fmt.Printf("hash rate: %.0f hashes/s\n", s.GetHashRate())
+	fmt.Printf("coinbase address: %s\n", s.GetCoinbaseAddress())
+	if s.GetTemplateHeight() > 0 {
+		fmt.Printf("template height: %d\n", s.GetTemplateHeight())
+	}
+	fmt.Printf("blocks found: %d\n", s.GetBlocksFound())
+	return nil
+}
+
+// generate mines blocks on demand on a regtest node.
+func generate(nodeAddr string, args []string) error {
+	fs := flag.NewFlagSet("mining generate", flag.ExitOnError)
+	blocks := fs.Int("n", 1, "number of blocks to generate")
+	addr := fs.String("address", "", "address to pay block rewards to")
+	fs.Parse(args)
+	if *addr == "" {
+		return fmt.Errorf("-address is required")
+	}
+
+	conn, err := grpc.NewClient(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	resp, err := protogen.NewBlockChainServiceClient(conn).GenerateBlocks(ctx, &protogen.GenerateBlocksRequest{
+		Blocks:            int32(*blocks),
+		BlockchainAddress: *addr,
+	})
+	if err != nil {
+		return err
+	}
+	for _, h := range resp.GetHashes() {
+		fmt.Println(h)
+	}
+	fmt.Printf("height: %d\n", resp.GetHeight())
+	return nil
+}
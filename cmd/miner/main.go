@@ -0,0 +1,114 @@
+// Command miner does proof-of-work for a node from outside its process. It fetches block
+// templates with GetBlockTemplate, searches nonces on every CPU and hands found blocks
+// back with SubmitBlock.
+//
+//	miner [-node addr] [-address coinbase] [-workers n] [-refresh interval]
+//
+// The search restarts on a fresh template whenever the node's chain tip moves and every
+// -refresh interval, so new memory pool transactions and their fees are picked up.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TIP_POLL_INTERVAL is how often the node's chain tip is checked during a search.
+const TIP_POLL_INTERVAL = time.Second
+
+func main() {
+	nodeAddr := flag.String("node", "127.0.0.1:7000", "blockchain grpc server address")
+	coinbase := flag.String("address", "", "address to pay block rewards to; the node's own address when empty")
+	workers := flag.Int("workers", 0, "number of mining goroutines, one per CPU when 0")
+	refresh := flag.Duration("refresh", 30*time.Second, "how long to work on one template")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+	client := protogen.NewBlockChainServiceClient(conn)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	m := miner.New(*workers)
+	log.Printf("mining for %s with %d workers", *nodeAddr, m.Workers())
+	for ctx.Err() == nil {
+		if err := mine(ctx, client, m, *coinbase, *refresh); err != nil && ctx.Err() == nil {
+			log.Print(err)
+			time.Sleep(TIP_POLL_INTERVAL)
+		}
+	}
+}
+
+// mine works on one template until it finds a block, the tip moves or refresh elapses.
+func mine(ctx context.Context, client protogen.BlockChainServiceClient, m *miner.Miner, coinbase string, refresh time.Duration) error {
+	t, err := client.GetBlockTemplate(ctx, &protogen.BlockTemplateRequest{CoinbaseAddress: coinbase})
+	if err != nil {
+		return err
+	}
+
+	searchCtx, cancel := context.WithTimeout(ctx, refresh)
+	defer cancel()
+	go watchTip(searchCtx, cancel, client, t.GetPreviousHash())
+
+	nonce, err := m.Search(searchCtx, miner.Template{
+		Height:     int(t.GetHeight()),
+		Difficulty: int(t.GetDifficulty()),
+		Hash:       miner.HeaderHash([]byte(t.GetHeaderPrefix()), []byte(t.GetHeaderSuffix())),
+	})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil // refresh the template
+	}
+	if err != nil {
+		return err
+	}
+
+	hashes := make([]string, len(t.GetTransactions()))
+	for i, tx := range t.GetTransactions() {
+		hashes[i] = tx.GetHash()
+	}
+	resp, err := client.SubmitBlock(ctx, &protogen.SubmitBlockRequest{
+		PreviousHash:      t.GetPreviousHash(),
+		Timestamp:         t.GetTimestamp(),
+		CoinbaseAddress:   t.GetCoinbase().GetRecipientBlockchainAddress(),
+		TransactionHashes: hashes,
+		Nonce:             int64(nonce),
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("found block %d (%s) at %.0f hashes/s, reward %.2f",
+		resp.GetBlock().GetIndex(), resp.GetBlock().GetHash(), m.HashRate(), t.GetCoinbaseValue())
+	return nil
+}
+
+// watchTip cancels the search once the node's chain tip is no longer previousHash.
+func watchTip(ctx context.Context, cancel context.CancelFunc, client protogen.BlockChainServiceClient, previousHash string) {
+	ticker := time.NewTicker(TIP_POLL_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tip, err := client.GetChainTip(ctx, &protogen.Empty{})
+			if err == nil && tip.GetHash() != previousHash {
+				cancel()
+				return
+			}
+		}
+	}
+}
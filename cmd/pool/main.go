@@ -0,0 +1,116 @@
+// Command pool runs a mining pool for a node. Miners connect to -listen with the
+// Stratum-like protocol described in package pool, using their payout address, or
+// "address.rig", as worker name.
+//
+//	pool [-node addr] [-listen addr] [-key key] [-scheme pplns|proportional] [-simulate n] [flags]
+//
+// The pool's private key is taken from -key or the ZERO_CHAIN_POOL_KEY environment
+// variable; a new one is generated and printed when neither is set. -simulate starts a
+// fleet of n simulated miners, each with its own wallet, against the pool.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/pool"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+const KEY_ENV = "ZERO_CHAIN_POOL_KEY"
+
+func main() {
+	nodeAddr := flag.String("node", "127.0.0.1:7000", "blockchain grpc server address")
+	listen := flag.String("listen", "127.0.0.1:3333", "address miners connect to")
+	key := flag.String("key", os.Getenv(KEY_ENV), "pool private key: hex, sec1, pkcs8 or wif")
+	network := flag.String("network", "mainnet", "network of the node: mainnet, testnet or regtest")
+	shareDifficulty := flag.Int("share-difficulty", pool.DEFAULT_SHARE_DIFFICULTY, "leading zero hex digits a share needs")
+	scheme := flag.String("scheme", pool.SCHEME_PPLNS, "payout scheme: pplns or proportional")
+	window := flag.Int("window", pool.DEFAULT_WINDOW, "shares a PPLNS reward is split over")
+	fee := flag.Float64("fee", 0.01, "fraction of each block reward the pool keeps")
+	minPayout := flag.Float64("min-payout", 0.1, "balance a miner is owed before it is paid")
+	refresh := flag.Duration("refresh", pool.DEFAULT_REFRESH, "how long a job lasts before it is rebuilt with the latest memory pool")
+	stateFile := flag.String("state", "./data/pool.json", "file shares and miner balances are kept in across restarts, empty to keep none")
+	simulate := flag.Int("simulate", 0, "number of simulated miners to run against the pool")
+	statsInterval := flag.Duration("stats", time.Minute, "how often to log pool statistics, 0 to never")
+	flag.Parse()
+
+	net, err := address.NetworkByName(*network)
+	if err != nil {
+		log.Fatal(err)
+	}
+	address.SetNetwork(net)
+
+	var w *wallet.Wallet
+	if *key == "" {
+		w = wallet.New()
+		log.Printf("generated pool key %s (set %s to reuse it)", w.PrivateKeyStr(), KEY_ENV)
+	} else {
+		privateKey, err := wallet.ParsePrivateKey(*key, "")
+		if err != nil {
+			log.Fatalf("invalid pool key: %v", err)
+		}
+		w = &wallet.Wallet{PrivateKey: privateKey, PublicKey: &privateKey.PublicKey, BlockchainAddress: wallet.Address(&privateKey.PublicKey)}
+	}
+
+	p, err := pool.New(pool.Config{
+		Node:            *nodeAddr,
+		Listen:          *listen,
+		PrivateKey:      w.PrivateKey,
+		ShareDifficulty: *shareDifficulty,
+		Scheme:          *scheme,
+		Window:          *window,
+		Fee:             float32(*fee),
+		MinPayout:       float32(*minPayout),
+		Refresh:         *refresh,
+		StateFile:       *stateFile,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *simulate > 0 {
+		fleet := pool.NewFleet(*listen, *simulate)
+		for _, fw := range fleet.Wallets {
+			log.Printf("simulated miner %s", fw.BlockchainAddress)
+		}
+		go func() {
+			time.Sleep(time.Second) // let the pool start listening
+			fleet.Run(ctx)
+		}()
+	}
+	if *statsInterval > 0 {
+		go logStats(ctx, p, *statsInterval)
+	}
+
+	if err := p.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func logStats(ctx context.Context, p *pool.Pool, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b, err := json.MarshalIndent(p.Stats(), "", "  ")
+			if err != nil {
+				continue
+			}
+			fmt.Println(string(b))
+		}
+	}
+}
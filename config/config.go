@@ -5,17 +5,29 @@ type Config struct {
 	WalletGatewayServerAddr     string
 	BlockChainGrpcServerAddr    string
 	BlockChainGatewayServerAddr string
+	DataDir                     string
+	Network                     string
+	MiningMode                  string
+	AdminToken                  string // required by the mining control RPCs; they are refused when empty
 }
 
 func LoadConfig(
 	walletGrpcServerAddr,
 	walletGatewayServerAddr,
 	blockChainGrpcServerAddr,
-	blockChainGatewayServerAddr string) Config {
+	blockChainGatewayServerAddr,
+	dataDir,
+	network,
+	miningMode,
+	adminToken string) Config {
 	return Config{
 		WalletGrpcServerAddr:        walletGrpcServerAddr,
 		WalletGatewayServerAddr:     walletGatewayServerAddr,
 		BlockChainGrpcServerAddr:    blockChainGrpcServerAddr,
 		BlockChainGatewayServerAddr: blockChainGatewayServerAddr,
+		DataDir:                     dataDir,
+		Network:                     network,
+		MiningMode:                  miningMode,
+		AdminToken:                  adminToken,
 	}
 }
@@ -4,8 +4,9 @@ go 1.22.2
 
 require (
 	github.com/btcsuite/btcd/btcutil v1.1.5
-	github.com/golang/protobuf v1.5.4
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.20.0
+	github.com/joho/godotenv v1.5.1
+	github.com/tyler-smith/go-bip39 v1.1.0
 	golang.org/x/crypto v0.25.0
 	google.golang.org/genproto/googleapis/api v0.0.0-20240701130421-f6361c86f094
 	google.golang.org/grpc v1.65.0
@@ -13,7 +14,6 @@ require (
 )
 
 require (
-	github.com/joho/godotenv v1.5.1 // indirect
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/sys v0.22.0 // indirect
 	golang.org/x/text v0.16.0 // indirect
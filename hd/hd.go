@@ -0,0 +1,261 @@
+// Package hd derives hierarchies of P-256 wallet keys from a single seed, following
+// BIP32 with the SLIP-0010 rules for the NIST P-256 curve.
+package hd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+const (
+	HARDENED = 1 << 31
+
+	// BIP44 layout: m/PURPOSE'/COIN_TYPE'/account'/chain/index
+	PURPOSE        = 44
+	COIN_TYPE      = 37 // unregistered, only used by Zero-Chain wallets
+	EXTERNAL_CHAIN = 0  // receive addresses
+	INTERNAL_CHAIN = 1  // change addresses
+
+	// GAP_LIMIT is the number of consecutive unused addresses after which address
+	// discovery stops.
+	GAP_LIMIT = 20
+
+	PUBLIC_KEY_VERSION = 0x04
+	SEED_KEY           = "Nist256p1 seed"
+)
+
+var (
+	ErrHardenedPublic = errors.New("hd: cannot derive a hardened child from a public key")
+	ErrInvalidKey     = errors.New("hd: invalid extended key")
+)
+
+// ExtendedKey is a private or public key together with the chain code needed to derive
+// its children.
+type ExtendedKey struct {
+	private   *big.Int // nil for public-only keys
+	x, y      *big.Int
+	chainCode []byte
+	depth     uint8
+	index     uint32
+}
+
+// NewMaster derives the root key of the hierarchy from a seed.
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("hd: seed must be between 16 and 64 bytes")
+	}
+	n := curve().Params().N
+
+	data := seed
+	for {
+		mac := hmac.New(sha512.New, []byte(SEED_KEY))
+		mac.Write(data)
+		I := mac.Sum(nil)
+		k := new(big.Int).SetBytes(I[:32])
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return newPrivate(k, I[32:], 0, 0), nil
+		}
+		data = I
+	}
+}
+
+// Child derives the child key at index; indexes from HARDENED upwards need a private key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, fmt.Errorf("hd: maximum derivation depth reached")
+	}
+	hardened := index >= HARDENED
+	if hardened && k.private == nil {
+		return nil, ErrHardenedPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.private.FillBytes(make([]byte, 32))...)
+	} else {
+		data = append(data, k.compressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	c := curve()
+	n := c.Params().N
+	for {
+		mac := hmac.New(sha512.New, k.chainCode)
+		mac.Write(data)
+		I := mac.Sum(nil)
+		IL, IR := new(big.Int).SetBytes(I[:32]), I[32:]
+
+		if IL.Cmp(n) < 0 {
+			if k.private != nil {
+				child := new(big.Int).Add(IL, k.private)
+				child.Mod(child, n)
+				if child.Sign() != 0 {
+					return newPrivate(child, IR, k.depth+1, index), nil
+				}
+			} else {
+				x, y := c.ScalarBaseMult(I[:32])
+				x, y = c.Add(x, y, k.x, k.y)
+				if x.Sign() != 0 || y.Sign() != 0 { // (0, 0) is the point at infinity
+					return &ExtendedKey{x: x, y: y, chainCode: IR, depth: k.depth + 1, index: index}, nil
+				}
+			}
+		}
+		// invalid child, retry with the next candidate as SLIP-0010 requires
+		data = append([]byte{0x01}, IR...)
+		data = binary.BigEndian.AppendUint32(data, index)
+	}
+}
+
+// Derive walks path (for example "m/44'/37'/0'/0/3") starting at k.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indexes {
+		if k, err = k.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Neuter returns the public half of k, which can derive non-hardened children only.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	return &ExtendedKey{x: k.x, y: k.y, chainCode: k.chainCode, depth: k.depth, index: k.index}
+}
+
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private != nil
+}
+
+// PrivateKey returns the ECDSA key of k, or nil for a public-only key.
+func (k *ExtendedKey) PrivateKey() *ecdsa.PrivateKey {
+	if k.private == nil {
+		return nil
+	}
+	return &ecdsa.PrivateKey{PublicKey: *k.PublicKey(), D: new(big.Int).Set(k.private)}
+}
+
+func (k *ExtendedKey) PublicKey() *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{Curve: curve(), X: k.x, Y: k.y}
+}
+
+// Address is the blockchain address of k's public key.
+func (k *ExtendedKey) Address() string {
+	return wallet.Address(k.PublicKey())
+}
+
+// PublicString encodes the public half of k as base58check so it can be stored or
+// shared for watch-only derivation.
+func (k *ExtendedKey) PublicString() string {
+	b := make([]byte, 0, 70)
+	b = append(b, k.depth)
+	b = binary.BigEndian.AppendUint32(b, k.index)
+	b = append(b, k.chainCode...)
+	b = append(b, k.compressed()...)
+	return base58.CheckEncode(b, PUBLIC_KEY_VERSION)
+}
+
+// ParsePublic decodes a key produced by PublicString.
+func ParsePublic(s string) (*ExtendedKey, error) {
+	b, version, err := base58.CheckDecode(s)
+	if err != nil || version != PUBLIC_KEY_VERSION || len(b) != 70 {
+		return nil, ErrInvalidKey
+	}
+	x, y := elliptic.UnmarshalCompressed(curve(), b[37:])
+	if x == nil {
+		return nil, ErrInvalidKey
+	}
+	return &ExtendedKey{
+		x:         x,
+		y:         y,
+		chainCode: append([]byte(nil), b[5:37]...),
+		depth:     b[0],
+		index:     binary.BigEndian.Uint32(b[1:5]),
+	}, nil
+}
+
+// Discover scans the receive chain of account for used addresses and returns the
+// index following the last used one, stopping after GAP_LIMIT unused addresses in a row.
+func Discover(account *ExtendedKey, used func(address string) (bool, error)) (uint32, error) {
+	var next uint32
+	for i, gap := uint32(0), 0; gap < GAP_LIMIT; i++ {
+		child, err := ReceiveKey(account, i)
+		if err != nil {
+			return 0, err
+		}
+		ok, err := used(child.Address())
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			next, gap = i+1, 0
+		} else {
+			gap++
+		}
+	}
+	return next, nil
+}
+
+// ReceiveKey derives the receive key at index of an account key.
+func ReceiveKey(account *ExtendedKey, index uint32) (*ExtendedKey, error) {
+	receive, err := account.Child(EXTERNAL_CHAIN)
+	if err != nil {
+		return nil, err
+	}
+	return receive.Child(index)
+}
+
+// AccountPath is the BIP44 path of an account; its receive addresses live under
+// AccountPath(account)/EXTERNAL_CHAIN/index.
+func AccountPath(account uint32) string {
+	return fmt.Sprintf("m/%d'/%d'/%d'", PURPOSE, COIN_TYPE, account)
+}
+
+// ParsePath parses a derivation path such as "m/44'/37'/0'/0/1". Hardened indexes are
+// marked with ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("hd: path %q must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			offset, p = HARDENED, p[:len(p)-1]
+		}
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("hd: invalid path element %q in %q", p, path)
+		}
+		indexes = append(indexes, uint32(i)+offset)
+	}
+	return indexes, nil
+}
+
+func newPrivate(k *big.Int, chainCode []byte, depth uint8, index uint32) *ExtendedKey {
+	x, y := curve().ScalarBaseMult(k.FillBytes(make([]byte, 32)))
+	return &ExtendedKey{private: k, x: x, y: y, chainCode: append([]byte(nil), chainCode...), depth: depth, index: index}
+}
+
+func (k *ExtendedKey) compressed() []byte {
+	return elliptic.MarshalCompressed(curve(), k.x, k.y)
+}
+
+func curve() elliptic.Curve {
+	return elliptic.P256()
+}
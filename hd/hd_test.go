@@ -0,0 +1,133 @@
+package hd
+
+import (
+	"encoding/hex"
+	"slices"
+	"testing"
+)
+
+// TestSLIP10 checks test vector 1 for nist256p1 from SLIP-0010.
+func TestSLIP10(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path, chainCode, private string
+	}{
+		{"m", "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2"},
+		{"m/0'", "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+	}
+	for _, tt := range tests {
+		k, err := master.Derive(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(k.chainCode); got != tt.chainCode {
+			t.Errorf("%s: chain code %s, want %s", tt.path, got, tt.chainCode)
+		}
+		if got := hex.EncodeToString(k.private.FillBytes(make([]byte, 32))); got != tt.private {
+			t.Errorf("%s: private key %s, want %s", tt.path, got, tt.private)
+		}
+	}
+}
+
+// TestPublicDerivation derives receive keys from the public account key, as a locked
+// or watch-only wallet does, and compares them with the private derivation.
+func TestPublicDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542")
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive(AccountPath(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := ParsePublic(account.Neuter().PublicString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.IsPrivate() {
+		t.Fatal("a parsed public key holds a private key")
+	}
+	for i := range uint32(3) {
+		private, err := ReceiveKey(account, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := ReceiveKey(public, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.Address() != private.Address() {
+			t.Fatalf("receive address %d differs between public and private derivation", i)
+		}
+	}
+	if _, err := public.Child(HARDENED); err != ErrHardenedPublic {
+		t.Fatalf("hardened child of a public key: %v, want %v", err, ErrHardenedPublic)
+	}
+
+	other, _ := master.Derive(AccountPath(0))
+	first, _ := ReceiveKey(other, 0)
+	same, _ := ReceiveKey(account, 0)
+	if first.Address() == same.Address() {
+		t.Fatal("accounts 0 and 1 share receive addresses")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	got, err := ParsePath("m/44'/37h/0'/0/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{44 + HARDENED, 37 + HARDENED, HARDENED, 0, 7}; !slices.Equal(got, want) {
+		t.Fatalf("ParsePath = %v, want %v", got, want)
+	}
+	for _, path := range []string{"44'/0", "m/x", "m/2147483648", "m//1"} {
+		if _, err := ParsePath(path); err == nil {
+			t.Errorf("ParsePath(%q) succeeded", path)
+		}
+	}
+	if got := AccountPath(3); got != "m/44'/37'/3'" {
+		t.Fatalf("AccountPath(3) = %s", got)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMaster(seed)
+	account, _ := master.Derive(AccountPath(0))
+	used := map[string]bool{}
+	for _, i := range []uint32{0, 4, 4 + GAP_LIMIT} {
+		k, _ := ReceiveKey(account, i)
+		used[k.Address()] = true
+	}
+
+	next, err := Discover(account.Neuter(), func(address string) (bool, error) { return used[address], nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != 5+GAP_LIMIT {
+		t.Fatalf("next index = %d, want %d", next, 5+GAP_LIMIT)
+	}
+}
+
+func TestMnemonic(t *testing.T) {
+	m, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := Seed(m, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Seed(m, "extra")
+	if len(a) != 64 || slices.Equal(a, b) {
+		t.Fatal("the mnemonic passphrase doesn't change the seed")
+	}
+	if _, err := Seed("abandon abandon abandon", ""); err == nil {
+		t.Fatal("accepted an invalid mnemonic")
+	}
+}
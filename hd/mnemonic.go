@@ -0,0 +1,28 @@
+package hd
+
+import (
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// MNEMONIC_ENTROPY_BITS gives 24-word mnemonics.
+const MNEMONIC_ENTROPY_BITS = 256
+
+// NewMnemonic generates a random BIP39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MNEMONIC_ENTROPY_BITS)
+	if err != nil {
+		return "", fmt.Errorf("hd: failed to generate entropy: %v", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Seed turns a BIP39 mnemonic and optional passphrase into the seed of NewMaster.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("hd: invalid mnemonic: %v", err)
+	}
+	return seed, nil
+}
@@ -47,6 +47,8 @@ func PublicKeyFromString(s string) *ecdsa.PublicKey {
 	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: &x, Y: &y}
 }
 
+// Deprecated: use wallet.ParsePrivateKeyHex, which derives the public key itself and
+// returns errors instead of logging them.
 func PrivateKeyFromString(s string, publicKey *ecdsa.PublicKey) *ecdsa.PrivateKey {
 	b, err := hex.DecodeString(s[:])
 	if err != nil {
@@ -0,0 +1,145 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// version 2 added hierarchical deterministic wallets; version 1 files are plain keys
+	FILE_VERSION = 2
+
+	TYPE_KEY = "key" // the secret is a single private key
+	TYPE_HD  = "hd"  // the secret is a BIP39 seed
+
+	KDF_SCRYPT     = "scrypt"
+	CIPHER_AES_GCM = "aes-256-gcm"
+
+	// scrypt cost: 32 MiB of memory and well under a second per unlock
+	SCRYPT_N      = 1 << 15
+	SCRYPT_R      = 8
+	SCRYPT_P      = 1
+	SCRYPT_KEYLEN = 32
+	SALT_LEN      = 32
+)
+
+var ErrWrongPassphrase = errors.New("keystore: wrong passphrase")
+
+// File is the on-disk JSON layout of an encrypted wallet. The address and public key
+// are kept in the clear so wallets can be listed without a passphrase; the address is
+// also the AES-GCM additional data, so a key cannot be moved under another address.
+// For HD wallets the address is the first receive address.
+type File struct {
+	Version   int       `json:"version"`
+	Type      string    `json:"type,omitempty"` // empty in version 1 files, meaning TYPE_KEY
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	PublicKey string    `json:"public_key"`
+	HD        *HDInfo   `json:"hd,omitempty"`
+	Crypto    Crypto    `json:"crypto"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HDInfo holds the public account key of an HD wallet so receive addresses can be
+// derived while the wallet is locked.
+type HDInfo struct {
+	Account    uint32 `json:"account"` // BIP44 account the wallet derives its keys under
+	Path       string `json:"path"`
+	AccountKey string `json:"account_key"`
+	NextIndex  uint32 `json:"next_index"` // receive addresses handed out so far
+}
+
+type Crypto struct {
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+	Cipher     string    `json:"cipher"`
+	Nonce      string    `json:"nonce"`
+	CipherText string    `json:"ciphertext"`
+}
+
+type KDFParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"dklen"`
+	Salt   string `json:"salt"`
+}
+
+// seal encrypts secret under a key derived from passphrase.
+func seal(secret []byte, passphrase, address string) (Crypto, error) {
+	salt := make([]byte, SALT_LEN)
+	if _, err := rand.Read(salt); err != nil {
+		return Crypto{}, fmt.Errorf("keystore: failed to generate salt: %v", err)
+	}
+	params := KDFParams{N: SCRYPT_N, R: SCRYPT_R, P: SCRYPT_P, KeyLen: SCRYPT_KEYLEN, Salt: hex.EncodeToString(salt)}
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return Crypto{}, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return Crypto{}, fmt.Errorf("keystore: failed to generate nonce: %v", err)
+	}
+	return Crypto{
+		KDF:        KDF_SCRYPT,
+		KDFParams:  params,
+		Cipher:     CIPHER_AES_GCM,
+		Nonce:      hex.EncodeToString(nonce),
+		CipherText: hex.EncodeToString(aead.Seal(nil, nonce, secret, []byte(address))),
+	}, nil
+}
+
+// open decrypts the secret of f with passphrase.
+func (f *File) open(passphrase string) ([]byte, error) {
+	if f.Version < 1 || f.Version > FILE_VERSION {
+		return nil, fmt.Errorf("keystore: unsupported file version %d", f.Version)
+	}
+	if f.Crypto.KDF != KDF_SCRYPT || f.Crypto.Cipher != CIPHER_AES_GCM {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q or cipher %q", f.Crypto.KDF, f.Crypto.Cipher)
+	}
+	aead, err := newAEAD(passphrase, f.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(f.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid nonce: %v", err)
+	}
+	cipherText, err := hex.DecodeString(f.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid ciphertext: %v", err)
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("keystore: invalid nonce length %d", len(nonce))
+	}
+
+	secret, err := aead.Open(nil, nonce, cipherText, []byte(f.Address))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return secret, nil
+}
+
+func newAEAD(passphrase string, params KDFParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid salt: %v", err)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to derive key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,495 @@
+// Package keystore keeps wallets on disk encrypted with a passphrase and holds
+// unlocked private keys in memory for a limited time.
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zde37/Zero-Chain/hd"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+const DEFAULT_UNLOCK_TIMEOUT = 5 * time.Minute
+
+var (
+	ErrLocked   = errors.New("keystore: wallet is locked")
+	ErrNotFound = errors.New("keystore: wallet not found")
+	ErrExists   = errors.New("keystore: wallet already exists")
+	ErrNotHD    = errors.New("keystore: not an HD wallet")
+	ErrTampered = errors.New("keystore: account key or path does not match the wallet's seed")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+// Entry describes a stored wallet. UnlockedUntil is zero while the wallet is locked;
+// Account, Path and NextIndex are only set for HD wallets.
+type Entry struct {
+	Name          string
+	Type          string
+	Address       string
+	PublicKey     string
+	Account       uint32
+	Path          string
+	NextIndex     uint32
+	CreatedAt     time.Time
+	UnlockedUntil time.Time
+}
+
+type KeyStore struct {
+	dir      string
+	mut      sync.Mutex
+	unlocked map[string]*unlockedKey
+}
+
+type unlockedKey struct {
+	key     *ecdsa.PrivateKey
+	account *hd.ExtendedKey // HD wallets only
+	until   time.Time
+	timer   *time.Timer
+}
+
+func New(dir string) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("keystore: failed to create %s: %v", dir, err)
+	}
+	return &KeyStore{dir: dir, unlocked: make(map[string]*unlockedKey)}, nil
+}
+
+// Create generates a new wallet and stores it under name.
+func (ks *KeyStore) Create(name, passphrase string) (Entry, error) {
+	w := wallet.New()
+	if w == nil {
+		return Entry{}, fmt.Errorf("keystore: failed to generate wallet")
+	}
+	return ks.Import(name, passphrase, w.PrivateKey)
+}
+
+// Import stores an existing private key under name.
+func (ks *KeyStore) Import(name, passphrase string, key *ecdsa.PrivateKey) (Entry, error) {
+	if err := validNew(name, passphrase); err != nil {
+		return Entry{}, err
+	}
+
+	w := &wallet.Wallet{PrivateKey: key, PublicKey: &key.PublicKey, BlockchainAddress: wallet.Address(&key.PublicKey)}
+	crypto, err := seal(key.D.FillBytes(make([]byte, 32)), passphrase, w.BlockchainAddress)
+	if err != nil {
+		return Entry{}, err
+	}
+	return ks.create(&File{
+		Version:   FILE_VERSION,
+		Type:      TYPE_KEY,
+		Name:      name,
+		Address:   w.BlockchainAddress,
+		PublicKey: w.PublicKeyStr(),
+		Crypto:    crypto,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// CreateHD stores an HD wallet whose keys are derived from a BIP39 mnemonic under the
+// given BIP44 account. Only the seed is encrypted; the public account key is kept in
+// the clear so that NextAddress works on a locked wallet.
+func (ks *KeyStore) CreateHD(name, passphrase, mnemonic, mnemonicPassphrase string, accountIndex uint32) (Entry, error) {
+	if err := validNew(name, passphrase); err != nil {
+		return Entry{}, err
+	}
+	if accountIndex >= hd.HARDENED {
+		return Entry{}, fmt.Errorf("keystore: account must be below %d", uint32(hd.HARDENED))
+	}
+	seed, err := hd.Seed(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return Entry{}, err
+	}
+	account, err := deriveAccount(seed, accountIndex)
+	if err != nil {
+		return Entry{}, err
+	}
+	first, err := hd.ReceiveKey(account, 0)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	crypto, err := seal(seed, passphrase, first.Address())
+	if err != nil {
+		return Entry{}, err
+	}
+	return ks.create(&File{
+		Version:   FILE_VERSION,
+		Type:      TYPE_HD,
+		Name:      name,
+		Address:   first.Address(),
+		PublicKey: publicKeyString(first.PublicKey()),
+		HD: &HDInfo{
+			Account:    accountIndex,
+			Path:       hd.AccountPath(accountIndex),
+			AccountKey: account.Neuter().PublicString(),
+			NextIndex:  1,
+		},
+		Crypto:    crypto,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// NextAddress hands out the next unused receive address of an HD wallet along with
+// its derivation path.
+func (ks *KeyStore) NextAddress(name string) (string, string, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, account, err := ks.readHD(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	index := f.HD.NextIndex
+	child, err := hd.ReceiveKey(account, index)
+	if err != nil {
+		return "", "", err
+	}
+	f.HD.NextIndex++
+	if err := ks.update(f); err != nil {
+		return "", "", err
+	}
+	return child.Address(), fmt.Sprintf("%s/%d/%d", f.HD.Path, hd.EXTERNAL_CHAIN, index), nil
+}
+
+// AccountKey returns the public account key of an HD wallet for address discovery.
+func (ks *KeyStore) AccountKey(name string) (*hd.ExtendedKey, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	_, account, err := ks.readHD(name)
+	return account, err
+}
+
+// SetNextIndex records that receive addresses below next are in use; it never moves
+// the index backwards.
+func (ks *KeyStore) SetNextIndex(name string, next uint32) (Entry, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, _, err := ks.readHD(name)
+	if err != nil {
+		return Entry{}, err
+	}
+	if next > f.HD.NextIndex {
+		f.HD.NextIndex = next
+		if err := ks.update(f); err != nil {
+			return Entry{}, err
+		}
+	}
+	return ks.entry(f), nil
+}
+
+func (ks *KeyStore) List() ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(ks.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to list wallets: %v", err)
+	}
+	sort.Strings(paths)
+
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	entries := make([]Entry, 0, len(paths))
+	for _, p := range paths {
+		f, err := ks.read(strings.TrimSuffix(filepath.Base(p), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, ks.entry(f))
+	}
+	return entries, nil
+}
+
+// Unlock decrypts the wallet and keeps its key in memory for timeout
+// (DEFAULT_UNLOCK_TIMEOUT when zero). Unlocking again extends the timeout.
+func (ks *KeyStore) Unlock(name, passphrase string, timeout time.Duration) (Entry, error) {
+	if timeout <= 0 {
+		timeout = DEFAULT_UNLOCK_TIMEOUT
+	}
+
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, err := ks.read(name)
+	if err != nil {
+		return Entry{}, err
+	}
+	secret, err := f.open(passphrase)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	u := &unlockedKey{until: time.Now().Add(timeout)}
+	if f.Type == TYPE_HD {
+		if u.account, err = f.deriveAccount(secret); err != nil {
+			return Entry{}, err
+		}
+		first, err := hd.ReceiveKey(u.account, 0)
+		if err != nil {
+			return Entry{}, err
+		}
+		u.key = first.PrivateKey()
+	} else if u.key, err = wallet.PrivateKeyFromBytes(secret); err != nil {
+		return Entry{}, err
+	}
+
+	if old, ok := ks.unlocked[name]; ok {
+		old.timer.Stop()
+	}
+	u.timer = time.AfterFunc(timeout, func() {
+		ks.mut.Lock()
+		defer ks.mut.Unlock()
+		if ks.unlocked[name] == u { // a later unlock may have replaced this one
+			delete(ks.unlocked, name)
+		}
+	})
+	ks.unlocked[name] = u
+	return ks.entry(f), nil
+}
+
+// Lock forgets the decrypted key of a wallet.
+func (ks *KeyStore) Lock(name string) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	ks.lock(name)
+}
+
+func (ks *KeyStore) lock(name string) {
+	if u, ok := ks.unlocked[name]; ok {
+		u.timer.Stop()
+		delete(ks.unlocked, name)
+	}
+}
+
+// Get returns the entry of a stored wallet.
+func (ks *KeyStore) Get(name string) (Entry, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, err := ks.read(name)
+	if err != nil {
+		return Entry{}, err
+	}
+	return ks.entry(f), nil
+}
+
+// Export decrypts and returns the private key of a wallet's own address; unlike Key it
+// needs the passphrase even while the wallet is unlocked.
+func (ks *KeyStore) Export(name, passphrase string) (*ecdsa.PrivateKey, Entry, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, err := ks.read(name)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	secret, err := f.open(passphrase)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+
+	if f.Type != TYPE_HD {
+		key, err := wallet.PrivateKeyFromBytes(secret)
+		return key, ks.entry(f), err
+	}
+	account, err := f.deriveAccount(secret)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	first, err := hd.ReceiveKey(account, 0)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	return first.PrivateKey(), ks.entry(f), nil
+}
+
+// Delete removes a wallet file; the passphrase is required so a wallet cannot be
+// destroyed by someone who could not have used it.
+func (ks *KeyStore) Delete(name, passphrase string) error {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, err := ks.read(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.open(passphrase); err != nil {
+		return err
+	}
+	ks.lock(name)
+	if err := os.Remove(ks.path(name)); err != nil {
+		return fmt.Errorf("keystore: failed to delete wallet file: %v", err)
+	}
+	return nil
+}
+
+// Key returns the private key of an unlocked wallet for address along with its entry.
+// An empty address selects the wallet's own address; HD wallets also sign for any
+// receive address handed out so far.
+func (ks *KeyStore) Key(name, address string) (*ecdsa.PrivateKey, Entry, error) {
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	f, err := ks.read(name)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	u, ok := ks.unlocked[name]
+	if !ok {
+		return nil, Entry{}, ErrLocked
+	}
+	// a wallet stored before fixed-length key hashing may keep a legacy address in
+	// its file; it can sign for that and for the current derivation
+	if address == "" || address == f.Address || address == wallet.Address(&u.key.PublicKey) {
+		return u.key, ks.entry(f), nil
+	}
+
+	if u.account != nil {
+		for i := uint32(1); i < f.HD.NextIndex; i++ {
+			child, err := hd.ReceiveKey(u.account, i)
+			if err != nil {
+				return nil, Entry{}, err
+			}
+			if child.Address() == address {
+				return child.PrivateKey(), ks.entry(f), nil
+			}
+		}
+	}
+	return nil, Entry{}, fmt.Errorf("keystore: address %s does not belong to wallet %s", address, name)
+}
+
+// create writes a new wallet file, failing if the name is taken.
+func (ks *KeyStore) create(f *File) (Entry, error) {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return Entry{}, fmt.Errorf("keystore: failed to encode wallet: %v", err)
+	}
+
+	ks.mut.Lock()
+	defer ks.mut.Unlock()
+	fd, err := os.OpenFile(ks.path(f.Name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return Entry{}, ErrExists
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("keystore: failed to create wallet file: %v", err)
+	}
+	defer fd.Close()
+	if _, err := fd.Write(b); err != nil {
+		return Entry{}, fmt.Errorf("keystore: failed to write wallet file: %v", err)
+	}
+	return ks.entry(f), nil
+}
+
+// update rewrites an existing wallet file atomically; the caller must hold ks.mut.
+func (ks *KeyStore) update(f *File) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("keystore: failed to encode wallet: %v", err)
+	}
+	tmp := ks.path(f.Name) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("keystore: failed to write wallet file: %v", err)
+	}
+	if err := os.Rename(tmp, ks.path(f.Name)); err != nil {
+		return fmt.Errorf("keystore: failed to replace wallet file: %v", err)
+	}
+	return nil
+}
+
+// readHD loads an HD wallet file and its public account key; the caller must hold ks.mut.
+func (ks *KeyStore) readHD(name string) (*File, *hd.ExtendedKey, error) {
+	f, err := ks.read(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.Type != TYPE_HD || f.HD == nil {
+		return nil, nil, ErrNotHD
+	}
+	account, err := hd.ParsePublic(f.HD.AccountKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("keystore: wallet %s: %v", name, err)
+	}
+	return f, account, nil
+}
+
+// read loads a wallet file; the caller must hold ks.mut.
+func (ks *KeyStore) read(name string) (*File, error) {
+	if !namePattern.MatchString(name) {
+		return nil, ErrNotFound
+	}
+	b, err := os.ReadFile(ks.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to read wallet file: %v", err)
+	}
+	f := new(File)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("keystore: failed to decode wallet file %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// entry builds the public view of a wallet file; the caller must hold ks.mut.
+func (ks *KeyStore) entry(f *File) Entry {
+	e := Entry{Name: f.Name, Type: f.Type, Address: f.Address, PublicKey: f.PublicKey, CreatedAt: f.CreatedAt}
+	if e.Type == "" {
+		e.Type = TYPE_KEY
+	}
+	if f.HD != nil {
+		e.Account, e.Path, e.NextIndex = f.HD.Account, f.HD.Path, f.HD.NextIndex
+	}
+	if u, ok := ks.unlocked[f.Name]; ok {
+		e.UnlockedUntil = u.until
+	}
+	return e
+}
+
+func (ks *KeyStore) path(name string) string {
+	return filepath.Join(ks.dir, name+".json")
+}
+
+func validNew(name, passphrase string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("keystore: name must be 1-64 letters, digits, '-' or '_'")
+	}
+	if passphrase == "" {
+		return fmt.Errorf("keystore: passphrase is required")
+	}
+	return nil
+}
+
+// deriveAccount derives the private key of an account from a seed.
+func deriveAccount(seed []byte, account uint32) (*hd.ExtendedKey, error) {
+	master, err := hd.NewMaster(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.Derive(hd.AccountPath(account))
+}
+
+// deriveAccount derives the account key of an HD wallet from its decrypted seed. The
+// public account key and path are stored in the clear, so they are checked against the
+// seed before the wallet hands out keys for them.
+func (f *File) deriveAccount(seed []byte) (*hd.ExtendedKey, error) {
+	if f.HD == nil {
+		return nil, ErrNotHD
+	}
+	account, err := deriveAccount(seed, f.HD.Account)
+	if err != nil {
+		return nil, err
+	}
+	if account.Neuter().PublicString() != f.HD.AccountKey || f.HD.Path != hd.AccountPath(f.HD.Account) {
+		return nil, ErrTampered
+	}
+	return account, nil
+}
+
+func publicKeyString(pub *ecdsa.PublicKey) string {
+	return fmt.Sprintf("%064x%064x", pub.X.Bytes(), pub.Y.Bytes())
+}
@@ -0,0 +1,221 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zde37/Zero-Chain/hd"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestKeyStore(t *testing.T) {
+	ks, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := ks.Create("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != TYPE_KEY || e.Address == "" || !e.UnlockedUntil.IsZero() {
+		t.Fatalf("created entry = %+v", e)
+	}
+	if _, err := ks.Create("alice", "secret"); !errors.Is(err, ErrExists) {
+		t.Fatalf("creating a taken name: %v, want %v", err, ErrExists)
+	}
+	if _, err := ks.Create("../alice", "secret"); err == nil {
+		t.Fatal("accepted a name with a path in it")
+	}
+	if entries, err := ks.List(); err != nil || len(entries) != 1 || entries[0].Address != e.Address {
+		t.Fatalf("List = %+v, %v", entries, err)
+	}
+
+	if _, _, err := ks.Key("alice", ""); !errors.Is(err, ErrLocked) {
+		t.Fatalf("key of a locked wallet: %v, want %v", err, ErrLocked)
+	}
+	if _, err := ks.Unlock("alice", "wrong", 0); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("unlocking with a wrong passphrase: %v, want %v", err, ErrWrongPassphrase)
+	}
+	if e, err := ks.Unlock("alice", "secret", 0); err != nil || e.UnlockedUntil.IsZero() {
+		t.Fatalf("Unlock = %+v, %v", e, err)
+	}
+	key, _, err := ks.Key("alice", e.Address)
+	if err != nil || wallet.Address(&key.PublicKey) != e.Address {
+		t.Fatalf("key of the unlocked wallet: %v", err)
+	}
+	if _, _, err := ks.Key("alice", wallet.New().BlockchainAddress); err == nil {
+		t.Fatal("returned a key for another wallet's address")
+	}
+
+	ks.Lock("alice")
+	if _, _, err := ks.Key("alice", ""); !errors.Is(err, ErrLocked) {
+		t.Fatalf("key after Lock: %v, want %v", err, ErrLocked)
+	}
+
+	if err := ks.Delete("alice", "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("deleting with a wrong passphrase: %v", err)
+	}
+	if err := ks.Delete("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Get("alice"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestUnlockTimeout(t *testing.T) {
+	ks, _ := New(t.TempDir())
+	if _, err := ks.Create("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Unlock("alice", "secret", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, _, err := ks.Key("alice", ""); !errors.Is(err, ErrLocked) {
+		t.Fatalf("key after the unlock timeout: %v, want %v", err, ErrLocked)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	ks, _ := New(t.TempDir())
+	w := wallet.New()
+	e, err := ks.Import("alice", "secret", w.PrivateKey)
+	if err != nil || e.Address != w.BlockchainAddress {
+		t.Fatalf("Import = %+v, %v", e, err)
+	}
+
+	// a fresh keystore reads the wallet back from disk
+	reopened, _ := New(ks.dir)
+	key, _, err := reopened.Export("alice", "secret")
+	if err != nil || key.D.Cmp(w.PrivateKey.D) != 0 {
+		t.Fatalf("exported a different key: %v", err)
+	}
+}
+
+// TestTamperedFile moves an encrypted key under another address: the address is
+// authenticated, so the file no longer decrypts.
+func TestTamperedFile(t *testing.T) {
+	ks, _ := New(t.TempDir())
+	if _, err := ks.Create("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(ks.path("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f File
+	if err := json.Unmarshal(b, &f); err != nil {
+		t.Fatal(err)
+	}
+	if f.Version != FILE_VERSION || f.Crypto.KDF != KDF_SCRYPT || f.Crypto.Cipher != CIPHER_AES_GCM {
+		t.Fatalf("file header = version %d, %s, %s", f.Version, f.Crypto.KDF, f.Crypto.Cipher)
+	}
+	f.Address = wallet.New().BlockchainAddress
+	b, _ = json.Marshal(f)
+	if err := os.WriteFile(ks.path("alice"), b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Unlock("alice", "secret", 0); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("unlocking a file moved to another address: %v", err)
+	}
+}
+
+// TestTamperedHDFile swaps the clear-text account details of an HD wallet: they are
+// checked against the seed, so the wallet refuses to unlock or export.
+func TestTamperedHDFile(t *testing.T) {
+	mnemonic, err := hd.NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := hd.NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed, err := hd.Seed(other, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := deriveAccount(seed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]func(*HDInfo){
+		"account key": func(info *HDInfo) { info.AccountKey = foreign.Neuter().PublicString() },
+		"path":        func(info *HDInfo) { info.Path = hd.AccountPath(1) },
+		"account":     func(info *HDInfo) { info.Account = 1 },
+	}
+	for name, tamper := range tests {
+		ks, _ := New(t.TempDir())
+		if _, err := ks.CreateHD("alice", "secret", mnemonic, "", 0); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(ks.path("alice"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var f File
+		if err := json.Unmarshal(b, &f); err != nil {
+			t.Fatal(err)
+		}
+		tamper(f.HD)
+		b, _ = json.Marshal(f)
+		if err := os.WriteFile(ks.path("alice"), b, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ks.Unlock("alice", "secret", 0); !errors.Is(err, ErrTampered) {
+			t.Errorf("unlocking a file with a changed %s: %v", name, err)
+		}
+		if _, _, err := ks.Export("alice", "secret"); !errors.Is(err, ErrTampered) {
+			t.Errorf("exporting a file with a changed %s: %v", name, err)
+		}
+	}
+}
+
+func TestHDAccounts(t *testing.T) {
+	ks, _ := New(t.TempDir())
+	mnemonic, err := hd.NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := ks.CreateHD("first", "secret", mnemonic, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ks.CreateHD("second", "secret", mnemonic, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Account != 0 || second.Account != 1 || second.Path != hd.AccountPath(1) {
+		t.Fatalf("entries = %+v and %+v", first, second)
+	}
+	if first.Address == second.Address {
+		t.Fatal("two accounts of one mnemonic share an address")
+	}
+	if _, err := ks.CreateHD("third", "secret", mnemonic, "", hd.HARDENED); err == nil {
+		t.Fatal("accepted a hardened account index")
+	}
+
+	// addresses handed out while locked are signed for once unlocked
+	address, path, err := ks.NextAddress("second")
+	if err != nil || path != hd.AccountPath(1)+"/0/1" {
+		t.Fatalf("NextAddress = %s, %s, %v", address, path, err)
+	}
+	if _, err := ks.Unlock("second", "secret", 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []string{second.Address, address} {
+		key, _, err := ks.Key("second", a)
+		if err != nil || wallet.Address(&key.PublicKey) != a {
+			t.Fatalf("key for %s: %v", a, err)
+		}
+	}
+	key, _, err := ks.Export("second", "secret")
+	if err != nil || wallet.Address(&key.PublicKey) != second.Address {
+		t.Fatalf("exported the key of another address: %v", err)
+	}
+}
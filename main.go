@@ -4,26 +4,80 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/chainparams"
 	"github.com/zde37/Zero-Chain/config"
 	"github.com/zde37/Zero-Chain/server"
 	"github.com/zde37/Zero-Chain/service"
 )
 
+const ADMIN_TOKEN_ENV = "ZERO_CHAIN_ADMIN_TOKEN"
+
 func main() {
-	blockchainGRPCPort := flag.Uint("bch-grpc", 7000, "blockchain grpc server port") // the range is from 7000-7003. Adjust according to your needs
+	blockchainGRPCPort := flag.Uint("bch-grpc", 0, "blockchain grpc server port; defaults to the first port of the network's range, which neighbors are looked for in")
 	blockchainGatewayPort := flag.Uint("bch-gateway", 7070, "blockchain gateway server port")
 	host := flag.String("bch-host", "127.0.0.1", "blockchain server host")
 	walletGRPCPort := flag.Uint("wal-grpc", 5000, "wallet grpc server port")
 	walletGatewayPort := flag.Uint("wal-gateway", 5050, "wallet gateway server port")
+	dataDir := flag.String("data-dir", "./data", "directory for node state such as webhook registrations and keystores")
+	network := flag.String("network", "mainnet", "network to run on: mainnet, testnet or regtest")
+	genesisFile := flag.String("genesis", "", "genesis file of a private network to run on instead of -network")
+	miningMode := flag.String("mining-mode", blockchain.MINING_MODE_CONTINUOUS, "continuous: mine the next block as soon as one is found or received, at most one every 30 seconds; interval: start mining on a fixed timer")
+	adminToken := flag.String("admin-token", os.Getenv(ADMIN_TOKEN_ENV), "token the mining control RPCs require as \"authorization: Bearer <token>\" gRPC metadata; they are refused when empty")
+	var checkpoints checkpointFlags
+	flag.Var(&checkpoints, "checkpoint", "pin a block as height:hash on top of the network's checkpoints; repeatable")
+	assumeValid := flag.String("assume-valid", "", "hash of a block whose ancestors' proof-of-work and signatures aren't re-checked during sync, instead of the network's")
+	var emission chainparams.Emission // overrides of the network's schedule, applied only when set
+	flag.Float64Var(&emission.InitialSubsidy, "initial-subsidy", 0, "override the coins created by each block before the first halving")
+	flag.IntVar(&emission.HalvingInterval, "halving-interval", 0, "override the blocks between subsidy halvings")
+	flag.Float64Var(&emission.MaxSupply, "max-supply", 0, "override the coins that will ever be created")
+	flag.IntVar(&emission.CoinbaseMaturity, "coinbase-maturity", 0, "override the confirmations before a block reward can be spent")
 	flag.Parse()
 
+	params, err := chainparams.ByName(*network)
+	if *genesisFile != "" {
+		params, err = chainparams.LoadGenesisFile(*genesisFile)
+	}
+	if err != nil {
+		log.Fatalf("invalid network: %v", err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "initial-subsidy":
+			params.Emission.InitialSubsidy = emission.InitialSubsidy
+		case "halving-interval":
+			params.Emission.HalvingInterval = emission.HalvingInterval
+		case "max-supply":
+			params.Emission.MaxSupply = emission.MaxSupply
+		case "coinbase-maturity":
+			params.Emission.CoinbaseMaturity = emission.CoinbaseMaturity
+		}
+	})
+	params.Checkpoints = append(params.Checkpoints, checkpoints...)
+	if *assumeValid != "" {
+		params.AssumeValid = *assumeValid
+	}
+	if err := params.Validate(); err != nil {
+		log.Fatalf("invalid network parameters: %v", err)
+	}
+	if *blockchainGRPCPort == 0 {
+		*blockchainGRPCPort = uint(params.DefaultPort())
+	}
+
 	config := config.LoadConfig(fmt.Sprintf("0.0.0.0:%d", *walletGRPCPort), fmt.Sprintf("0.0.0.0:%d", *walletGatewayPort),
-		fmt.Sprintf("%s:%d", *host, *blockchainGRPCPort), fmt.Sprintf("%s:%d", *host, *blockchainGatewayPort))
+		fmt.Sprintf("%s:%d", *host, *blockchainGRPCPort), fmt.Sprintf("%s:%d", *host, *blockchainGatewayPort), *dataDir, params.Name, *miningMode, *adminToken)
 
-	blockchainService := service.NewBlockChainServiceImpl(uint16(*blockchainGRPCPort))
-	walletService, err := service.NewWalletServiceImpl(uint16(*walletGRPCPort), fmt.Sprintf("%s:%d", *host, *blockchainGRPCPort))
+	address.SetNetwork(params.Network) // before any wallet is created
+	if !blockchain.ValidMiningMode(config.MiningMode) {
+		log.Fatalf("invalid mining mode %q", config.MiningMode)
+	}
+
+	blockchainService := service.NewBlockChainServiceImpl(uint16(*blockchainGRPCPort), config.DataDir, config.MiningMode, params)
+	walletService, err := service.NewWalletServiceImpl(uint16(*walletGRPCPort), fmt.Sprintf("%s:%d", *host, *blockchainGRPCPort), config.DataDir)
 	if err != nil {
 		log.Fatalf("failed to create wallet service: %v", err)
 	}
@@ -34,6 +88,22 @@ func main() {
 	go bcGRPCServer.RunGatewayServer()
 	go bcGRPCServer.RunGrpcServer()
 
-	// go walletGRPCServer.RunGrpcServer()
+	go walletGRPCServer.RunGrpcServer() // used by the client SDK
 	walletGRPCServer.RunGatewayServer()
 }
+
+// checkpointFlags collects repeated -checkpoint flags.
+type checkpointFlags []chainparams.Checkpoint
+
+func (c *checkpointFlags) String() string {
+	return fmt.Sprint([]chainparams.Checkpoint(*c))
+}
+
+func (c *checkpointFlags) Set(s string) error {
+	checkpoint, err := chainparams.ParseCheckpoint(s)
+	if err != nil {
+		return err
+	}
+	*c = append(*c, checkpoint)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package miner searches proof-of-work nonces across several goroutines. It knows
+// nothing about blocks: a Template supplies the hash of a candidate block for a given
+// nonce, and the search stops as soon as one worker finds a nonce whose hash meets the
+// difficulty or the caller cancels the context, for example when the chain tip moves.
+package miner
+
+import (
+	"context"
+	"crypto/sha256"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CHECK_INTERVAL is how many nonces a worker tries between checks for cancellation.
+const CHECK_INTERVAL = 1024
+
+// Template is the work for one block.
+type Template struct {
+	Height     int
+	Difficulty int                      // leading zero hex digits the hash needs
+	Start      int                      // first nonce tried, so miners sharing a template can split the nonces
+	Hash       func(nonce int) [32]byte // must be safe for concurrent use
+}
+
+type Miner struct {
+	hashes atomic.Uint64 // nonces tried in the current search
+
+	mut      sync.Mutex
+	workers  int
+	started  time.Time // zero while idle
+	lastRate float64   // hashes per second of the last finished search
+}
+
+// New returns a miner with the given number of workers, one per CPU when workers is
+// not positive.
+func New(workers int) *Miner {
+	m := new(Miner)
+	m.SetWorkers(workers)
+	return m
+}
+
+func (m *Miner) Workers() int {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.workers
+}
+
+// SetWorkers changes the number of workers, one per CPU when workers is not positive.
+// A running search keeps its workers; the next one uses the new count.
+func (m *Miner) SetWorkers(workers int) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	m.mut.Lock()
+	m.workers = workers
+	m.mut.Unlock()
+}
+
+// Search tries nonces until one meets t.Difficulty, returning ctx.Err() if ctx is done
+// first. Worker i tries nonces Start+i, Start+i+N, Start+i+2N and so on.
+func (m *Miner) Search(ctx context.Context, t Template) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.mut.Lock()
+	workers := m.workers
+	m.started = time.Now()
+	m.hashes.Store(0)
+	m.mut.Unlock()
+	defer m.finish()
+
+	found := make(chan int, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(nonce int) {
+			defer wg.Done()
+			for tried := 1; ; tried++ {
+				if Meets(t.Hash(nonce), t.Difficulty) {
+					select {
+					case found <- nonce:
+						cancel()
+					default:
+					}
+					m.hashes.Add(uint64(tried % CHECK_INTERVAL))
+					return
+				}
+				if tried%CHECK_INTERVAL == 0 {
+					m.hashes.Add(CHECK_INTERVAL)
+					if ctx.Err() != nil {
+						return
+					}
+				}
+				nonce += workers
+			}
+		}(t.Start + i)
+	}
+	wg.Wait()
+
+	select {
+	case nonce := <-found:
+		return nonce, nil
+	default:
+		return 0, ctx.Err()
+	}
+}
+
+// HashRate returns the hashes per second of the running search, or of the last one
+// while idle.
+func (m *Miner) HashRate() float64 {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if m.started.IsZero() {
+		return m.lastRate
+	}
+	return rate(m.hashes.Load(), time.Since(m.started))
+}
+
+func (m *Miner) finish() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.lastRate = rate(m.hashes.Load(), time.Since(m.started))
+	m.started = time.Time{}
+}
+
+func rate(hashes uint64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(hashes) / elapsed.Seconds()
+}
+
+// HeaderHash returns the hash of templates handed out by a node's GetBlockTemplate:
+// sha256(prefix || nonce in decimal || suffix).
+func HeaderHash(prefix, suffix []byte) func(nonce int) [32]byte {
+	return func(nonce int) [32]byte {
+		preimage := make([]byte, 0, len(prefix)+20+len(suffix))
+		preimage = append(preimage, prefix...)
+		preimage = strconv.AppendInt(preimage, int64(nonce), 10)
+		preimage = append(preimage, suffix...)
+		return sha256.Sum256(preimage)
+	}
+}
+
+// Meets reports whether hash starts with difficulty zero hex digits.
+func Meets(hash [32]byte, difficulty int) bool {
+	for i := 0; i < difficulty; i++ {
+		b := hash[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		if b&0x0f != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Target returns the largest hash that meets difficulty, for miners that compare hashes
+// numerically instead of counting zero digits.
+func Target(difficulty int) [32]byte {
+	var target [32]byte
+	for i := range target {
+		target[i] = 0xff
+	}
+	for i := 0; i < difficulty; i++ {
+		if i%2 == 0 {
+			target[i/2] &= 0x0f
+		} else {
+			target[i/2] = 0
+		}
+	}
+	return target
+}
@@ -0,0 +1,134 @@
+package miner
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// template returns work whose hashes depend on seed, so every test searches afresh.
+func template(seed string, difficulty int) Template {
+	return Template{
+		Height:     1,
+		Difficulty: difficulty,
+		Hash:       HeaderHash([]byte(seed+`{"Nonce":`), []byte(`}`)),
+	}
+}
+
+func TestSearch(t *testing.T) {
+	m := New(4)
+	tmpl := template("search", 3)
+	nonce, err := m.Search(context.Background(), tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Meets(tmpl.Hash(nonce), tmpl.Difficulty) {
+		t.Fatalf("nonce %d does not meet difficulty %d", nonce, tmpl.Difficulty)
+	}
+	if m.HashRate() <= 0 {
+		t.Fatal("no hash rate reported after a search")
+	}
+}
+
+func TestSearchStart(t *testing.T) {
+	tmpl := template("start", 1)
+	tmpl.Start = 1 << 20
+	nonce, err := New(1).Search(context.Background(), tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce < tmpl.Start {
+		t.Fatalf("nonce %d is below the template's start %d", nonce, tmpl.Start)
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	m := New(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.Search(ctx, template("cancel", 64)) // never met
+		done <- err
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for m.HashRate() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("cancelled search returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("search did not stop when its context was cancelled")
+	}
+}
+
+func TestSearchSplitsNonces(t *testing.T) {
+	// Record every nonce tried; with N workers no nonce may be tried twice.
+	var mut sync.Mutex
+	tried := make(map[int]int)
+	hash := HeaderHash([]byte("split"), nil)
+	tmpl := Template{
+		Difficulty: 2,
+		Hash: func(nonce int) [32]byte {
+			mut.Lock()
+			tried[nonce]++
+			mut.Unlock()
+			return hash(nonce)
+		},
+	}
+	if _, err := New(3).Search(context.Background(), tmpl); err != nil {
+		t.Fatal(err)
+	}
+	for nonce, n := range tried {
+		if n > 1 {
+			t.Fatalf("nonce %d tried %d times", nonce, n)
+		}
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	m := New(0)
+	if got := m.Workers(); got != runtime.NumCPU() {
+		t.Fatalf("default workers = %d, want one per CPU (%d)", got, runtime.NumCPU())
+	}
+	m.SetWorkers(3)
+	if got := m.Workers(); got != 3 {
+		t.Fatalf("workers = %d, want 3", got)
+	}
+}
+
+func TestMeetsTarget(t *testing.T) {
+	for difficulty := range 65 {
+		target := Target(difficulty)
+		if !Meets(target, difficulty) {
+			t.Fatalf("target for difficulty %d does not meet it", difficulty)
+		}
+		if difficulty > 0 {
+			// a nonzero last required digit
+			above, digit := target, difficulty-1
+			above[digit/2] |= 0x10 >> (4 * (digit % 2))
+			if Meets(above, difficulty) {
+				t.Fatalf("hash above the target meets difficulty %d", difficulty)
+			}
+		}
+	}
+	if !Meets([32]byte{0x00, 0x0f}, 3) || Meets([32]byte{0x00, 0x0f}, 4) {
+		t.Fatal("Meets miscounts zero hex digits")
+	}
+}
+
+func TestHeaderHash(t *testing.T) {
+	got := HeaderHash([]byte("prefix"), []byte("suffix"))(1234)
+	if want := sha256.Sum256([]byte("prefix1234suffix")); !bytes.Equal(got[:], want[:]) {
+		t.Fatalf("HeaderHash = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,175 @@
+package pool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// Client is a miner's connection to a pool. Jobs and share difficulty changes pushed by
+// the pool arrive on Jobs.
+type Client struct {
+	conn net.Conn
+	Jobs chan Job
+
+	shareDifficulty atomic.Int64
+
+	mut     sync.Mutex // guards enc, nextID and pending
+	enc     *json.Encoder
+	nextID  uint64
+	pending map[uint64]chan response
+	err     error // why the connection closed
+}
+
+// Dial connects to the pool at addr and subscribes.
+func Dial(addr, agent string) (*Client, Subscription, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, Subscription{}, fmt.Errorf("pool: failed to connect to %s: %v", addr, err)
+	}
+	c := &Client{
+		conn:    conn,
+		Jobs:    make(chan Job, 8),
+		enc:     json.NewEncoder(conn),
+		pending: make(map[uint64]chan response),
+	}
+	c.shareDifficulty.Store(DEFAULT_SHARE_DIFFICULTY)
+	go c.read()
+
+	var sub Subscription
+	if err := c.call(METHOD_SUBSCRIBE, &sub, agent); err != nil {
+		conn.Close()
+		return nil, Subscription{}, err
+	}
+	return c, sub, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ShareDifficulty is the last share difficulty the pool set.
+func (c *Client) ShareDifficulty() int {
+	return int(c.shareDifficulty.Load())
+}
+
+func (c *Client) Authorize(worker, password string) error {
+	return c.call(METHOD_AUTHORIZE, nil, worker, password)
+}
+
+// Submit sends a share; a rejected share returns an *Error.
+func (c *Client) Submit(worker, jobID string, nonce int64) error {
+	return c.call(METHOD_SUBMIT, nil, worker, jobID, nonce)
+}
+
+func (c *Client) Stats() (Stats, error) {
+	var s Stats
+	err := c.call(METHOD_STATS, &s)
+	return s, err
+}
+
+func (c *Client) call(method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	ch := make(chan response, 1)
+
+	c.mut.Lock()
+	if c.err != nil {
+		c.mut.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	err := c.enc.Encode(struct {
+		ID     uint64 `json:"id"`
+		Method string `json:"method"`
+		Params []any  `json:"params"`
+	}{id, method, params})
+	c.mut.Unlock()
+	if err != nil {
+		return fmt.Errorf("pool: failed to send %s: %v", method, err)
+	}
+
+	resp, ok := <-ch
+	if !ok {
+		return c.err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result != nil {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
+
+// read dispatches responses to their callers and notifications to Jobs until the
+// connection closes.
+func (c *Client) read() {
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 4096), MAX_MESSAGE)
+	for scanner.Scan() {
+		var msg struct {
+			response
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		if msg.ID != nil {
+			c.mut.Lock()
+			ch := c.pending[*msg.ID]
+			delete(c.pending, *msg.ID)
+			c.mut.Unlock()
+			if ch != nil {
+				ch <- msg.response
+			}
+			continue
+		}
+		if len(msg.Params) == 0 {
+			continue
+		}
+		switch msg.Method {
+		case METHOD_SET_DIFFICULTY:
+			var d int64
+			if json.Unmarshal(msg.Params[0], &d) == nil {
+				c.shareDifficulty.Store(d)
+			}
+		case METHOD_NOTIFY:
+			var j Job
+			if json.Unmarshal(msg.Params[0], &j) != nil {
+				continue
+			}
+			// never block here: a miner waiting on a Submit reply would never get it.
+			// A miner that falls behind only needs the newest job anyway
+			select {
+			case c.Jobs <- j:
+			default:
+				select {
+				case <-c.Jobs:
+				default:
+				}
+				c.Jobs <- j
+			}
+		}
+	}
+
+	c.mut.Lock()
+	c.err = fmt.Errorf("pool: connection closed")
+	if err := scanner.Err(); err != nil {
+		c.err = fmt.Errorf("pool: connection closed: %v", err)
+	}
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.mut.Unlock()
+	close(c.Jobs)
+}
@@ -0,0 +1,106 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// Fleet is a set of simulated miners, each with its own payout wallet and one hashing
+// goroutine, for trying a pool out on one machine.
+type Fleet struct {
+	Wallets []*wallet.Wallet
+	addr    string
+}
+
+// NewFleet returns size simulated miners for the pool at addr.
+func NewFleet(addr string, size int) *Fleet {
+	f := &Fleet{addr: addr}
+	for i := 0; i < size; i++ {
+		f.Wallets = append(f.Wallets, wallet.New())
+	}
+	return f
+}
+
+// Run mines with every miner of the fleet until ctx is done.
+func (f *Fleet) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, w := range f.Wallets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := fmt.Sprintf("%s.sim%d", w.BlockchainAddress, i)
+			if err := Mine(ctx, f.addr, worker, 1); err != nil && ctx.Err() == nil {
+				log.Printf("fleet: %s stopped: %v", worker, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Mine connects to the pool at addr as worker and submits shares found with the given
+// number of hashing goroutines until ctx is done or the connection fails.
+func Mine(ctx context.Context, addr, worker string, workers int) error {
+	c, sub, err := Dial(addr, "zero-chain-miner")
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	go func() {
+		<-ctx.Done()
+		c.Close()
+	}()
+	if err := c.Authorize(worker, ""); err != nil {
+		return err
+	}
+
+	m := miner.New(workers)
+	job, ok := <-c.Jobs
+	for ok {
+		jobCtx, cancel := context.WithCancel(ctx)
+		next := make(chan Job, 1)
+		go func() {
+			j, ok := <-c.Jobs
+			if ok {
+				next <- j
+			}
+			close(next)
+			cancel()
+		}()
+
+		// a job is worked on until the next one arrives, resuming after every share
+		start := sub.NonceStart
+		for {
+			nonce, err := m.Search(jobCtx, jobTemplate(job, c.ShareDifficulty(), start))
+			if err != nil {
+				break
+			}
+			var perr *Error
+			if err := c.Submit(worker, job.ID, int64(nonce)); err != nil && !errors.As(err, &perr) {
+				cancel()
+				return err
+			}
+			start = int64(nonce) + 1
+		}
+		cancel()
+		job, ok = <-next
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("pool: connection closed")
+}
+
+func jobTemplate(j Job, shareDifficulty int, start int64) miner.Template {
+	return miner.Template{
+		Height:     j.Height,
+		Difficulty: shareDifficulty,
+		Start:      int(start),
+		Hash:       miner.HeaderHash([]byte(j.HeaderPrefix), []byte(j.HeaderSuffix)),
+	}
+}
@@ -0,0 +1,709 @@
+// Package pool runs a mining pool in front of a node. Miners connect over TCP and speak
+// a Stratum-like JSON-RPC protocol: the pool turns the node's block templates into jobs,
+// accepts shares that meet a share difficulty below the block difficulty, counts them
+// per worker and, when a share is also a block, submits it to the node and splits the
+// reward between the miners with payout transactions from the pool's wallet.
+package pool
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/client"
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	SCHEME_PPLNS        = "pplns"        // split each reward over the last Window shares
+	SCHEME_PROPORTIONAL = "proportional" // split each reward over the shares since the previous block
+
+	DEFAULT_SHARE_DIFFICULTY = 2
+	DEFAULT_WINDOW           = 1024
+	DEFAULT_REFRESH          = 30 * time.Second
+
+	NONCE_RANGE       = 1 << 40 // nonces reserved for each connection
+	JOB_POLL_INTERVAL = time.Second
+	WRITE_TIMEOUT     = 10 * time.Second
+	MAX_MESSAGE       = 1 << 20
+)
+
+type Config struct {
+	Node            string            // blockchain grpc server address
+	Listen          string            // tcp address miners connect to
+	PrivateKey      *ecdsa.PrivateKey // the pool's wallet: block rewards go to it and payouts come from it
+	ShareDifficulty int               // leading zero hex digits a share needs
+	Scheme          string            // SCHEME_PPLNS or SCHEME_PROPORTIONAL
+	Window          int               // shares a PPLNS reward is split over
+	Fee             float32           // fraction of each block reward the pool keeps
+	MinPayout       float32           // balance a miner is owed before it is paid
+	Refresh         time.Duration     // how long a job lasts before it is rebuilt with the latest memory pool
+	StateFile       string            // where shares and balances are kept across restarts; not kept when empty
+}
+
+// WorkerStats counts one worker's shares. Rejected shares are duplicates or miss the
+// share difficulty; stale ones were for a job on an old chain tip.
+type WorkerStats struct {
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Accepted  int       `json:"accepted"`
+	Rejected  int       `json:"rejected"`
+	Stale     int       `json:"stale"`
+	Blocks    int       `json:"blocks"`
+	LastShare time.Time `json:"last_share"`
+}
+
+type Stats struct {
+	Address         string             `json:"address"`
+	Scheme          string             `json:"scheme"`
+	ShareDifficulty int                `json:"share_difficulty"`
+	Height          int                `json:"height"`
+	Blocks          int                `json:"blocks"`
+	Workers         []WorkerStats      `json:"workers"`
+	Immature        map[string]float32 `json:"immature"` // shares of block rewards that are not spendable yet
+	Owed            map[string]float32 `json:"owed"`
+	Paid            map[string]float32 `json:"paid"`
+}
+
+type job struct {
+	Job
+	previousHash  string
+	timestamp     int64
+	hashes        []string // the template's transactions, in block order
+	coinbaseValue float32
+	nonces        map[int64]bool // shares already submitted
+	hash          func(nonce int) [32]byte
+}
+
+// reward is a found block's reward split between payout addresses. It is owed to them
+// once the block's coinbase matures, or dropped if the block leaves the chain first.
+type reward struct {
+	Height  int                `json:"height"`
+	Hash    string             `json:"hash"`
+	Amounts map[string]float32 `json:"amounts"`
+}
+
+type Pool struct {
+	cfg     Config
+	address string
+	conn    *grpc.ClientConn
+	node    protogen.BlockChainServiceClient
+
+	mut         sync.Mutex
+	current     *job
+	jobs        map[string]*job // jobs on the current chain tip
+	nextJob     int
+	sessions    map[*session]struct{}
+	nextSession int64
+	workers     map[string]*WorkerStats
+	round       map[string]int // shares per payout address since the last block
+	window      []string       // payout addresses of the last cfg.Window shares, a ring
+	windowNext  int
+	immature    []reward // oldest first
+	owed        map[string]float32
+	paid        map[string]float32
+	blocks      int
+	dirty       bool // shares or balances changed since the state file was written
+
+	payMut   sync.Mutex // one payout run at a time, so account nonces don't collide
+	stateMut sync.Mutex // one state file write at a time
+}
+
+// New returns a pool working for the node at cfg.Node. Zero ShareDifficulty, Window and
+// Refresh take their defaults. Shares and balances are restored from cfg.StateFile.
+func New(cfg Config) (*Pool, error) {
+	if cfg.PrivateKey == nil {
+		return nil, fmt.Errorf("pool: a private key is required")
+	}
+	if cfg.Scheme != SCHEME_PPLNS && cfg.Scheme != SCHEME_PROPORTIONAL {
+		return nil, fmt.Errorf("pool: unknown payout scheme %q", cfg.Scheme)
+	}
+	if cfg.Fee < 0 || cfg.Fee >= 1 {
+		return nil, fmt.Errorf("pool: fee must be at least 0 and below 1")
+	}
+	if cfg.ShareDifficulty <= 0 {
+		cfg.ShareDifficulty = DEFAULT_SHARE_DIFFICULTY
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DEFAULT_WINDOW
+	}
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = DEFAULT_REFRESH
+	}
+
+	st := new(state)
+	if cfg.StateFile != "" {
+		var err error
+		if st, err = loadState(cfg.StateFile); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := grpc.NewClient(cfg.Node, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("pool: failed to create grpc client: %v", err)
+	}
+	p := &Pool{
+		cfg:      cfg,
+		address:  wallet.Address(&cfg.PrivateKey.PublicKey),
+		conn:     conn,
+		node:     protogen.NewBlockChainServiceClient(conn),
+		jobs:     make(map[string]*job),
+		sessions: make(map[*session]struct{}),
+		workers:  make(map[string]*WorkerStats),
+		round:    make(map[string]int),
+		owed:     make(map[string]float32),
+		paid:     make(map[string]float32),
+	}
+	p.restore(st)
+	return p, nil
+}
+
+// Address is the pool's wallet address.
+func (p *Pool) Address() string {
+	return p.address
+}
+
+// Run accepts miners on cfg.Listen and keeps them supplied with jobs until ctx is done.
+func (p *Pool) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", p.cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("pool: failed to listen on %s: %v", p.cfg.Listen, err)
+	}
+	defer p.conn.Close()
+	log.Printf("pool: listening on %s, paying from %s", listener.Addr(), p.address)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+		p.mut.Lock()
+		for s := range p.sessions {
+			s.conn.Close()
+		}
+		p.mut.Unlock()
+	}()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					log.Printf("pool: accept failed: %v", err)
+				}
+				return
+			}
+			go p.serve(ctx, conn)
+		}
+	}()
+
+	p.pollJobs(ctx)
+	p.saveState()
+	return nil
+}
+
+// pollJobs builds a new job whenever the node's chain tip moves or the current job is
+// older than cfg.Refresh.
+func (p *Pool) pollJobs(ctx context.Context) {
+	ticker := time.NewTicker(JOB_POLL_INTERVAL)
+	defer ticker.Stop()
+	var built time.Time
+	for {
+		p.saveState() // shares since the last tick
+		tip, err := p.node.GetChainTip(ctx, &protogen.Empty{})
+		if err != nil {
+			log.Printf("pool: failed to get chain tip: %v", err)
+		} else {
+			p.mut.Lock()
+			current, immature := p.current, len(p.immature)
+			p.mut.Unlock()
+			if current != nil && current.previousHash != tip.GetHash() && immature > 0 {
+				go p.payOut(ctx) // the new block may have matured a reward
+			}
+			if current == nil || current.previousHash != tip.GetHash() || time.Since(built) >= p.cfg.Refresh {
+				if err := p.newJob(ctx); err != nil {
+					log.Printf("pool: %v", err)
+				} else {
+					built = time.Now()
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newJob turns a fresh block template into a job and sends it to every subscribed miner.
+func (p *Pool) newJob(ctx context.Context) error {
+	t, err := p.node.GetBlockTemplate(ctx, &protogen.BlockTemplateRequest{CoinbaseAddress: p.address})
+	if err != nil {
+		return fmt.Errorf("failed to get block template: %v", err)
+	}
+	hashes := make([]string, len(t.GetTransactions()))
+	for i, tx := range t.GetTransactions() {
+		hashes[i] = tx.GetHash()
+	}
+
+	p.mut.Lock()
+	clean := p.current == nil || p.current.previousHash != t.GetPreviousHash()
+	if clean {
+		p.jobs = make(map[string]*job)
+	}
+	p.nextJob++
+	j := &job{
+		Job: Job{
+			ID:           strconv.FormatInt(int64(p.nextJob), 16),
+			Height:       int(t.GetHeight()),
+			HeaderPrefix: t.GetHeaderPrefix(),
+			HeaderSuffix: t.GetHeaderSuffix(),
+			Difficulty:   int(t.GetDifficulty()),
+			Clean:        clean,
+		},
+		previousHash:  t.GetPreviousHash(),
+		timestamp:     t.GetTimestamp(),
+		hashes:        hashes,
+		coinbaseValue: t.GetCoinbaseValue(),
+		nonces:        make(map[int64]bool),
+		hash:          miner.HeaderHash([]byte(t.GetHeaderPrefix()), []byte(t.GetHeaderSuffix())),
+	}
+	p.jobs[j.ID] = j
+	p.current = j
+	sessions := make([]*session, 0, len(p.sessions))
+	for s := range p.sessions {
+		sessions = append(sessions, s)
+	}
+	p.mut.Unlock()
+
+	for _, s := range sessions {
+		s.notify(METHOD_NOTIFY, j.Job)
+	}
+	return nil
+}
+
+// submitShare checks a share from s and counts it for worker, submitting it to the node
+// when it is also a block.
+func (p *Pool) submitShare(ctx context.Context, s *session, worker, jobID string, nonce int64) *Error {
+	p.mut.Lock()
+	w := p.workers[worker]
+	if nonce < s.nonceStart || nonce-s.nonceStart >= NONCE_RANGE {
+		w.Rejected++
+		p.mut.Unlock()
+		return &Error{ERR_NONCE_RANGE, "nonce outside the connection's range"}
+	}
+	j, ok := p.jobs[jobID]
+	if !ok {
+		w.Stale++
+		p.mut.Unlock()
+		return &Error{ERR_STALE, "stale job"}
+	}
+	if j.nonces[nonce] {
+		w.Rejected++
+		p.mut.Unlock()
+		return &Error{ERR_DUPLICATE, "duplicate share"}
+	}
+	hash := j.hash(int(nonce))
+	if !miner.Meets(hash, p.cfg.ShareDifficulty) {
+		w.Rejected++
+		p.mut.Unlock()
+		return &Error{ERR_LOW_DIFFICULTY, "low difficulty share"}
+	}
+
+	j.nonces[nonce] = true
+	w.Accepted++
+	w.LastShare = time.Now()
+	p.recordShare(w.Address)
+	isBlock := miner.Meets(hash, j.Difficulty)
+	p.mut.Unlock()
+
+	if isBlock {
+		p.submitBlock(ctx, w.Name, j, nonce)
+	}
+	return nil
+}
+
+// recordShare counts a share for the current round and the PPLNS window. The caller
+// must hold p.mut.
+func (p *Pool) recordShare(payoutAddress string) {
+	p.dirty = true
+	p.round[payoutAddress]++
+	if len(p.window) < p.cfg.Window {
+		p.window = append(p.window, payoutAddress)
+		return
+	}
+	p.window[p.windowNext] = payoutAddress
+	p.windowNext = (p.windowNext + 1) % p.cfg.Window
+}
+
+func (p *Pool) submitBlock(ctx context.Context, worker string, j *job, nonce int64) {
+	resp, err := p.node.SubmitBlock(ctx, &protogen.SubmitBlockRequest{
+		PreviousHash:      j.previousHash,
+		Timestamp:         j.timestamp,
+		CoinbaseAddress:   p.address,
+		TransactionHashes: j.hashes,
+		Nonce:             nonce,
+	})
+	if err != nil {
+		log.Printf("pool: block %d from %s rejected: %v", j.Height, worker, err)
+		return
+	}
+	log.Printf("pool: block %d (%s) found by %s", resp.GetBlock().GetIndex(), resp.GetBlock().GetHash(), worker)
+
+	p.mut.Lock()
+	p.blocks++
+	p.workers[worker].Blocks++
+	shares := p.round
+	if p.cfg.Scheme == SCHEME_PPLNS {
+		shares = make(map[string]int)
+		for _, a := range p.window {
+			shares[a]++
+		}
+	}
+	p.immature = append(p.immature, reward{
+		Height:  int(resp.GetBlock().GetIndex()),
+		Hash:    resp.GetBlock().GetHash(),
+		Amounts: split(j.coinbaseValue*(1-p.cfg.Fee), shares),
+	})
+	p.round = make(map[string]int)
+	p.dirty = true
+	p.mut.Unlock()
+	p.saveState()
+
+	if err := p.newJob(ctx); err != nil {
+		log.Printf("pool: %v", err)
+	}
+	go p.payOut(ctx)
+}
+
+// split divides amount between payout addresses in proportion to their shares.
+func split(amount float32, shares map[string]int) map[string]float32 {
+	var total int
+	for _, n := range shares {
+		total += n
+	}
+	amounts := make(map[string]float32, len(shares))
+	if total == 0 {
+		return amounts
+	}
+	for a, n := range shares {
+		amounts[a] = amount * float32(n) / float32(total)
+	}
+	return amounts
+}
+
+// creditMatured moves the rewards of blocks whose coinbase has matured into what the
+// miners are owed: the pool can't pay them out of its wallet any sooner. Rewards of
+// blocks that were replaced on the chain are dropped.
+func (p *Pool) creditMatured(ctx context.Context) error {
+	p.mut.Lock()
+	pending := slices.Clone(p.immature)
+	p.mut.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	supply, err := p.node.GetSupplyInfo(ctx, &protogen.Empty{})
+	if err != nil {
+		return fmt.Errorf("failed to get coinbase maturity: %v", err)
+	}
+	next := int(supply.GetHeight()) + 1
+	settled := make(map[string]bool)
+	for _, r := range pending {
+		if next-r.Height < int(supply.GetCoinbaseMaturity()) {
+			break // later rewards are younger still
+		}
+		b, err := p.node.GetBlockByHeight(ctx, &protogen.BlockHeightRequest{Height: int64(r.Height)})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("failed to get block %d: %v", r.Height, err)
+		}
+		settled[r.Hash] = true
+		if b.GetBlock().GetHash() != r.Hash {
+			log.Printf("pool: block %d (%s) left the chain, its reward is not paid out", r.Height, r.Hash)
+			continue
+		}
+		p.mut.Lock()
+		for a, v := range r.Amounts {
+			p.owed[a] += v
+		}
+		p.mut.Unlock()
+	}
+
+	p.mut.Lock()
+	p.immature = slices.DeleteFunc(p.immature, func(r reward) bool { return settled[r.Hash] })
+	p.dirty = len(settled) > 0 || p.dirty
+	p.mut.Unlock()
+	p.saveState()
+	return nil
+}
+
+// payOut credits matured rewards and sends every miner owed at least cfg.MinPayout its
+// balance. A failed payment stays owed and is retried after the next block.
+func (p *Pool) payOut(ctx context.Context) {
+	p.payMut.Lock()
+	defer p.payMut.Unlock()
+
+	if err := p.creditMatured(ctx); err != nil {
+		log.Printf("pool: %v", err)
+	}
+	p.mut.Lock()
+	due := make(map[string]float32)
+	for a, v := range p.owed {
+		if v >= p.cfg.MinPayout && v > 0 && a != p.address { // the pool's own shares are already in its wallet
+			due[a] = v
+		}
+	}
+	p.mut.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	resp, err := p.node.AccountNonce(ctx, &protogen.NonceRequest{BlockchainAddress: p.address})
+	if err != nil {
+		log.Printf("pool: failed to get account nonce: %v", err)
+		return
+	}
+	nonce := resp.GetNonce()
+
+	recipients := make([]string, 0, len(due))
+	for a := range due {
+		recipients = append(recipients, a)
+	}
+	slices.Sort(recipients)
+	for _, a := range recipients {
+		if err := p.pay(ctx, a, due[a], nonce); err != nil {
+			log.Printf("pool: failed to pay %.4f to %s: %v", due[a], a, err)
+			return
+		}
+		nonce++
+
+		p.mut.Lock()
+		p.owed[a] -= due[a]
+		if p.owed[a] <= 0 {
+			delete(p.owed, a)
+		}
+		p.paid[a] += due[a]
+		p.dirty = true
+		p.mut.Unlock()
+		p.saveState() // before the next payment, so a restart never pays this one twice
+		log.Printf("pool: paid %.4f to %s", due[a], a)
+	}
+}
+
+func (p *Pool) pay(ctx context.Context, recipient string, value float32, nonce uint64) error {
+	payload, err := transaction.NewMetaData(nil, nil, p.address, recipient, value, 0, nonce).MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to build signing payload: %v", err)
+	}
+	signature, err := client.Sign(p.cfg.PrivateKey, payload)
+	if err != nil {
+		return err
+	}
+	_, err = p.node.CreateTransaction(ctx, &protogen.TransactionRequest{
+		SenderBlockchainAddress:    p.address,
+		RecipientBlockchainAddress: recipient,
+		SenderPublicKey:            client.PublicKeyHex(&p.cfg.PrivateKey.PublicKey),
+		Value:                      value,
+		Nonce:                      nonce,
+		Signature:                  signature.String(),
+	})
+	return err
+}
+
+func (p *Pool) Stats() Stats {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	s := Stats{
+		Address:         p.address,
+		Scheme:          p.cfg.Scheme,
+		ShareDifficulty: p.cfg.ShareDifficulty,
+		Blocks:          p.blocks,
+		Workers:         make([]WorkerStats, 0, len(p.workers)),
+		Immature:        make(map[string]float32),
+		Owed:            make(map[string]float32, len(p.owed)),
+		Paid:            make(map[string]float32, len(p.paid)),
+	}
+	if p.current != nil {
+		s.Height = p.current.Height
+	}
+	for _, w := range p.workers {
+		s.Workers = append(s.Workers, *w)
+	}
+	slices.SortFunc(s.Workers, func(a, b WorkerStats) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	for _, r := range p.immature {
+		for a, v := range r.Amounts {
+			s.Immature[a] += v
+		}
+	}
+	for a, v := range p.owed {
+		s.Owed[a] = v
+	}
+	for a, v := range p.paid {
+		s.Paid[a] = v
+	}
+	return s
+}
+
+// session is one miner's connection.
+type session struct {
+	conn       net.Conn
+	mut        sync.Mutex // serializes writes
+	enc        *json.Encoder
+	nonceStart int64
+
+	// only used by the connection's read loop
+	subscribed bool
+	workers    map[string]bool
+}
+
+func (s *session) send(v any) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.conn.SetWriteDeadline(time.Now().Add(WRITE_TIMEOUT))
+	return s.enc.Encode(v)
+}
+
+func (s *session) notify(method string, params ...any) {
+	if err := s.send(struct {
+		ID     *uint64 `json:"id"`
+		Method string  `json:"method"`
+		Params []any   `json:"params"`
+	}{Method: method, Params: params}); err != nil {
+		s.conn.Close() // the read loop ends and drops the session
+	}
+}
+
+func (p *Pool) serve(ctx context.Context, conn net.Conn) {
+	s := &session{conn: conn, enc: json.NewEncoder(conn), workers: make(map[string]bool)}
+	defer func() {
+		conn.Close()
+		p.mut.Lock()
+		delete(p.sessions, s)
+		p.mut.Unlock()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), MAX_MESSAGE)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			s.send(response{Error: &Error{ERR_OTHER, "malformed request"}})
+			continue
+		}
+
+		result, perr := p.handle(ctx, s, req)
+		if req.ID == nil {
+			continue
+		}
+		resp := response{ID: req.ID, Error: perr}
+		if perr == nil {
+			resp.Result, _ = json.Marshal(result)
+		}
+		if err := s.send(resp); err != nil {
+			return
+		}
+
+		if req.Method == METHOD_SUBSCRIBE && perr == nil {
+			p.mut.Lock()
+			current := p.current
+			p.mut.Unlock()
+			s.notify(METHOD_SET_DIFFICULTY, p.cfg.ShareDifficulty)
+			if current != nil {
+				s.notify(METHOD_NOTIFY, current.Job)
+			}
+		}
+	}
+}
+
+func (p *Pool) handle(ctx context.Context, s *session, req request) (any, *Error) {
+	var params []json.RawMessage
+	if len(req.Params) > 0 && string(req.Params) != "null" {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &Error{ERR_OTHER, "params must be an array"}
+		}
+	}
+
+	switch req.Method {
+	case METHOD_SUBSCRIBE:
+		p.mut.Lock()
+		if !s.subscribed {
+			p.nextSession++
+			s.nonceStart = p.nextSession * NONCE_RANGE
+			p.sessions[s] = struct{}{}
+			s.subscribed = true
+		}
+		p.mut.Unlock()
+		return Subscription{
+			ID:         strconv.FormatInt(s.nonceStart/NONCE_RANGE, 16),
+			NonceStart: s.nonceStart,
+		}, nil
+
+	case METHOD_AUTHORIZE:
+		var worker string
+		if len(params) == 0 || json.Unmarshal(params[0], &worker) != nil {
+			return nil, &Error{ERR_OTHER, "worker name is required"}
+		}
+		if err := address.Validate(payoutAddress(worker)); err != nil {
+			return nil, &Error{ERR_UNAUTHORIZED, fmt.Sprintf("worker must be named after a payout address: %v", err)}
+		}
+		s.workers[worker] = true
+		p.mut.Lock()
+		if _, ok := p.workers[worker]; !ok {
+			p.workers[worker] = &WorkerStats{Name: worker, Address: payoutAddress(worker)}
+		}
+		p.mut.Unlock()
+		return true, nil
+
+	case METHOD_SUBMIT:
+		var worker, jobID string
+		var nonce int64
+		if len(params) < 3 ||
+			json.Unmarshal(params[0], &worker) != nil ||
+			json.Unmarshal(params[1], &jobID) != nil ||
+			json.Unmarshal(params[2], &nonce) != nil {
+			return nil, &Error{ERR_OTHER, "params must be [worker, job id, nonce]"}
+		}
+		if !s.subscribed {
+			return nil, &Error{ERR_NOT_SUBSCRIBED, "not subscribed"}
+		}
+		if !s.workers[worker] {
+			return nil, &Error{ERR_UNAUTHORIZED, "unauthorized worker"}
+		}
+		if perr := p.submitShare(ctx, s, worker, jobID, nonce); perr != nil {
+			return nil, perr
+		}
+		return true, nil
+
+	case METHOD_STATS:
+		return p.Stats(), nil
+
+	default:
+		return nil, &Error{ERR_OTHER, fmt.Sprintf("unknown method %q", req.Method)}
+	}
+}
@@ -0,0 +1,448 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testPrefix        = `{"timestamp":1,"nonce":`
+	testSuffix        = `,"previous_hash":"00"}`
+	testDifficulty    = 2
+	testCoinbaseValue = 10.0
+)
+
+// fakeNode hands out one template and records the blocks and payouts the pool sends.
+// Submitted blocks are appended to its chain of block hashes.
+type fakeNode struct {
+	protogen.BlockChainServiceClient
+
+	mut      sync.Mutex
+	chain    []string
+	maturity int64
+	blocks   []*protogen.SubmitBlockRequest
+	payments []*protogen.TransactionRequest
+}
+
+func (n *fakeNode) GetBlockTemplate(ctx context.Context, in *protogen.BlockTemplateRequest, opts ...grpc.CallOption) (*protogen.BlockTemplate, error) {
+	return &protogen.BlockTemplate{
+		Height:        1,
+		PreviousHash:  "00",
+		Difficulty:    testDifficulty,
+		CoinbaseValue: testCoinbaseValue,
+		HeaderPrefix:  testPrefix,
+		HeaderSuffix:  testSuffix,
+		Timestamp:     1,
+	}, nil
+}
+
+func (n *fakeNode) SubmitBlock(ctx context.Context, in *protogen.SubmitBlockRequest, opts ...grpc.CallOption) (*protogen.BlockResponse, error) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	n.blocks = append(n.blocks, in)
+	n.chain = append(n.chain, fmt.Sprintf("pool-%d", len(n.blocks)))
+	return &protogen.BlockResponse{Block: &protogen.Block{Index: int64(len(n.chain) - 1), Hash: n.chain[len(n.chain)-1]}}, nil
+}
+
+func (n *fakeNode) GetSupplyInfo(ctx context.Context, in *protogen.Empty, opts ...grpc.CallOption) (*protogen.SupplyInfoResponse, error) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	return &protogen.SupplyInfoResponse{Height: int64(len(n.chain) - 1), CoinbaseMaturity: n.maturity}, nil
+}
+
+func (n *fakeNode) GetBlockByHeight(ctx context.Context, in *protogen.BlockHeightRequest, opts ...grpc.CallOption) (*protogen.BlockResponse, error) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	if in.GetHeight() >= int64(len(n.chain)) {
+		return nil, status.Errorf(codes.NotFound, "no block at height %d", in.GetHeight())
+	}
+	return &protogen.BlockResponse{Block: &protogen.Block{Index: in.GetHeight(), Hash: n.chain[in.GetHeight()]}}, nil
+}
+
+// extend adds blocks found by someone else on top of the chain.
+func (n *fakeNode) extend(blocks int) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	for range blocks {
+		n.chain = append(n.chain, fmt.Sprintf("other-%d", len(n.chain)))
+	}
+}
+
+func (n *fakeNode) AccountNonce(ctx context.Context, in *protogen.NonceRequest, opts ...grpc.CallOption) (*protogen.NonceResponse, error) {
+	return &protogen.NonceResponse{}, nil
+}
+
+func (n *fakeNode) CreateTransaction(ctx context.Context, in *protogen.TransactionRequest, opts ...grpc.CallOption) (*protogen.StatusResponse, error) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	n.payments = append(n.payments, in)
+	return &protogen.StatusResponse{}, nil
+}
+
+// newTestPool returns a pool with a share difficulty of 1 working on the fake node's
+// template.
+func newTestPool(t *testing.T, scheme string, window int) (*Pool, *fakeNode) {
+	t.Helper()
+	p, err := New(Config{
+		Node:            "127.0.0.1:0",
+		PrivateKey:      wallet.New().PrivateKey,
+		ShareDifficulty: 1,
+		Scheme:          scheme,
+		Window:          window,
+		Fee:             0.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &fakeNode{chain: []string{"00"}}
+	p.node = node
+	if err := p.newJob(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return p, node
+}
+
+// nonces returns n nonces that are shares but not blocks and one that is a block.
+func nonces(n int) ([]int64, int64) {
+	hash := miner.HeaderHash([]byte(testPrefix), []byte(testSuffix))
+	var shares []int64
+	block := int64(-1)
+	for nonce := 0; len(shares) < n || block < 0; nonce++ {
+		h := hash(nonce)
+		switch {
+		case miner.Meets(h, testDifficulty):
+			if block < 0 {
+				block = int64(nonce)
+			}
+		case miner.Meets(h, 1) && len(shares) < n:
+			shares = append(shares, int64(nonce))
+		}
+	}
+	return shares, block
+}
+
+// submit sends a mining.submit for worker on the current job through the protocol
+// handler.
+func submit(p *Pool, s *session, worker string, nonce int64) *Error {
+	params, _ := json.Marshal([]any{worker, p.current.ID, nonce})
+	_, perr := p.handle(context.Background(), s, request{Method: METHOD_SUBMIT, Params: params})
+	return perr
+}
+
+// authorize returns a subscribed session with one authorized worker per wallet. The
+// session is not registered for job notifications, so it needs no connection.
+func authorize(t *testing.T, p *Pool, wallets ...*wallet.Wallet) *session {
+	t.Helper()
+	s := &session{subscribed: true, workers: make(map[string]bool)}
+	for _, w := range wallets {
+		params, _ := json.Marshal([]string{w.BlockchainAddress + ".rig", ""})
+		if _, perr := p.handle(context.Background(), s, request{Method: METHOD_AUTHORIZE, Params: params}); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+	return s
+}
+
+// waitPaid waits for the payout run started by a block to pay every address in want.
+func waitPaid(t *testing.T, p *Pool, want map[string]float32) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(p.Stats().Paid) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	paid := p.Stats().Paid
+	for a, v := range want {
+		if paid[a] != v {
+			t.Fatalf("paid %s %v, want %v", a, paid[a], v)
+		}
+	}
+}
+
+func TestShares(t *testing.T) {
+	p, node := newTestPool(t, SCHEME_PROPORTIONAL, 0)
+	alice := wallet.New()
+	s := authorize(t, p, alice)
+	worker := alice.BlockchainAddress + ".rig"
+	shares, _ := nonces(1)
+
+	params, _ := json.Marshal([]string{"not-an-address", ""})
+	if _, perr := p.handle(context.Background(), s, request{Method: METHOD_AUTHORIZE, Params: params}); perr == nil || perr.Code != ERR_UNAUTHORIZED {
+		t.Fatalf("authorizing a worker without a payout address: %v", perr)
+	}
+	if perr := submit(p, s, "someone-else", shares[0]); perr == nil || perr.Code != ERR_UNAUTHORIZED {
+		t.Fatalf("share from an unauthorized worker: %v", perr)
+	}
+	if perr := submit(p, s, worker, shares[0]); perr != nil {
+		t.Fatalf("share rejected: %v", perr)
+	}
+	if perr := submit(p, s, worker, shares[0]); perr == nil || perr.Code != ERR_DUPLICATE {
+		t.Fatalf("duplicate share: %v", perr)
+	}
+	low := int64(0)
+	for hash := miner.HeaderHash([]byte(testPrefix), []byte(testSuffix)); miner.Meets(hash(int(low)), 1); low++ {
+	}
+	if perr := submit(p, s, worker, low); perr == nil || perr.Code != ERR_LOW_DIFFICULTY {
+		t.Fatalf("low difficulty share: %v", perr)
+	}
+	params, _ = json.Marshal([]any{worker, "unknown", shares[0]})
+	if _, perr := p.handle(context.Background(), s, request{Method: METHOD_SUBMIT, Params: params}); perr == nil || perr.Code != ERR_STALE {
+		t.Fatalf("share for an unknown job: %v", perr)
+	}
+
+	stats := p.Stats()
+	if len(stats.Workers) != 1 {
+		t.Fatalf("%d workers, want 1", len(stats.Workers))
+	}
+	w := stats.Workers[0]
+	if w.Address != alice.BlockchainAddress || w.Accepted != 1 || w.Rejected != 2 || w.Stale != 1 || w.Blocks != 0 {
+		t.Fatalf("worker stats = %+v", w)
+	}
+	if len(node.blocks) != 0 {
+		t.Fatalf("submitted %d blocks without a block share", len(node.blocks))
+	}
+}
+
+func TestProportionalPayout(t *testing.T) {
+	p, node := newTestPool(t, SCHEME_PROPORTIONAL, 0)
+	alice, bob := wallet.New(), wallet.New()
+	s := authorize(t, p, alice, bob)
+	shares, block := nonces(3)
+
+	for _, nonce := range shares[:2] {
+		if perr := submit(p, s, alice.BlockchainAddress+".rig", nonce); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+	if perr := submit(p, s, bob.BlockchainAddress+".rig", shares[2]); perr != nil {
+		t.Fatal(perr)
+	}
+	if perr := submit(p, s, bob.BlockchainAddress+".rig", block); perr != nil {
+		t.Fatal(perr)
+	}
+
+	// half the reward is the pool's fee; alice and bob found two shares each
+	waitPaid(t, p, map[string]float32{
+		alice.BlockchainAddress: testCoinbaseValue / 4,
+		bob.BlockchainAddress:   testCoinbaseValue / 4,
+	})
+	if len(node.blocks) != 1 || node.blocks[0].GetNonce() != block || node.blocks[0].GetCoinbaseAddress() != p.Address() {
+		t.Fatalf("submitted blocks = %v", node.blocks)
+	}
+	node.mut.Lock()
+	for _, tx := range node.payments {
+		if tx.GetSenderBlockchainAddress() != p.Address() || tx.GetSignature() == "" {
+			t.Fatalf("payout %v is not signed by the pool's wallet", tx)
+		}
+	}
+	node.mut.Unlock()
+	if got := p.Stats().Blocks; got != 1 {
+		t.Fatalf("pool found %d blocks, want 1", got)
+	}
+	if len(p.round) != 0 {
+		t.Fatalf("round still holds %d addresses after a block", len(p.round))
+	}
+}
+
+func TestPPLNSPayout(t *testing.T) {
+	p, _ := newTestPool(t, SCHEME_PPLNS, 2)
+	alice, bob := wallet.New(), wallet.New()
+	s := authorize(t, p, alice, bob)
+	shares, block := nonces(3)
+
+	// alice's shares fall out of the two share window before the block
+	for _, nonce := range shares[:2] {
+		if perr := submit(p, s, alice.BlockchainAddress+".rig", nonce); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+	if perr := submit(p, s, bob.BlockchainAddress+".rig", shares[2]); perr != nil {
+		t.Fatal(perr)
+	}
+	if perr := submit(p, s, bob.BlockchainAddress+".rig", block); perr != nil {
+		t.Fatal(perr)
+	}
+
+	waitPaid(t, p, map[string]float32{bob.BlockchainAddress: testCoinbaseValue / 2})
+	if got := p.Stats().Paid[alice.BlockchainAddress]; got != 0 {
+		t.Fatalf("paid %v for shares outside the window", got)
+	}
+}
+
+// TestPayoutWaitsForMaturity finds two blocks on a node whose coinbase rewards take
+// three blocks to mature: the first is paid once it matures, the second is dropped
+// because another block replaced it first.
+func TestPayoutWaitsForMaturity(t *testing.T) {
+	p, node := newTestPool(t, SCHEME_PROPORTIONAL, 0)
+	node.maturity = 3
+	alice := wallet.New()
+	s := authorize(t, p, alice)
+	_, block := nonces(0)
+	find := func() {
+		t.Helper()
+		if err := p.newJob(context.Background()); err != nil { // a fresh job, so the block nonce isn't a duplicate
+			t.Fatal(err)
+		}
+		if perr := submit(p, s, alice.BlockchainAddress+".rig", block); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+
+	find() // height 1
+	p.payOut(context.Background())
+	if stats := p.Stats(); stats.Immature[alice.BlockchainAddress] != testCoinbaseValue/2 || len(stats.Owed) != 0 || len(stats.Paid) != 0 {
+		t.Fatalf("immature %v, owed %v, paid %v; want the reward immature", stats.Immature, stats.Owed, stats.Paid)
+	}
+
+	node.extend(1) // height 2, the reward is spendable in block 4
+	p.payOut(context.Background())
+	if paid := p.Stats().Paid; len(paid) != 0 {
+		t.Fatalf("paid %v before the reward matured", paid)
+	}
+	node.extend(1)
+	p.payOut(context.Background())
+	if stats := p.Stats(); stats.Paid[alice.BlockchainAddress] != testCoinbaseValue/2 || len(stats.Immature) != 0 {
+		t.Fatalf("immature %v, paid %v; want the reward paid", stats.Immature, stats.Paid)
+	}
+
+	find() // height 4
+	node.mut.Lock()
+	node.chain[4] = "replaced"
+	node.mut.Unlock()
+	node.extend(3)
+	p.payOut(context.Background())
+	if stats := p.Stats(); stats.Paid[alice.BlockchainAddress] != testCoinbaseValue/2 || len(stats.Immature) != 0 || len(stats.Owed) != 0 {
+		t.Fatalf("immature %v, owed %v, paid %v; want the replaced block's reward dropped", stats.Immature, stats.Owed, stats.Paid)
+	}
+}
+
+// TestNonceRange submits shares from a connection's own nonce range and from the one
+// before it.
+func TestNonceRange(t *testing.T) {
+	p, _ := newTestPool(t, SCHEME_PROPORTIONAL, 0)
+	alice := wallet.New()
+	worker := alice.BlockchainAddress + ".rig"
+	s := &session{workers: make(map[string]bool)}
+	result, perr := p.handle(context.Background(), s, request{Method: METHOD_SUBSCRIBE})
+	if perr != nil {
+		t.Fatal(perr)
+	}
+	start := result.(Subscription).NonceStart
+	params, _ := json.Marshal([]string{worker, ""})
+	if _, perr := p.handle(context.Background(), s, request{Method: METHOD_AUTHORIZE, Params: params}); perr != nil {
+		t.Fatal(perr)
+	}
+
+	// the test template's shares lie below the first connection's range
+	shares, _ := nonces(1)
+	if perr := submit(p, s, worker, shares[0]); perr == nil || perr.Code != ERR_NONCE_RANGE {
+		t.Fatalf("share below the connection's range: %v", perr)
+	}
+	if perr := submit(p, s, worker, start+NONCE_RANGE); perr == nil || perr.Code != ERR_NONCE_RANGE {
+		t.Fatalf("share above the connection's range: %v", perr)
+	}
+	hash := miner.HeaderHash([]byte(testPrefix), []byte(testSuffix))
+	nonce := start
+	for ; !miner.Meets(hash(int(nonce)), 1) || miner.Meets(hash(int(nonce)), testDifficulty); nonce++ {
+	}
+	if perr := submit(p, s, worker, nonce); perr != nil {
+		t.Fatalf("share in the connection's range rejected: %v", perr)
+	}
+	if w := p.Stats().Workers[0]; w.Accepted != 1 || w.Rejected != 2 {
+		t.Fatalf("worker stats = %+v", w)
+	}
+}
+
+// TestStateSurvivesRestart restarts a pool holding an immature reward, a paid one and
+// shares of the next round, and checks they are all still there.
+func TestStateSurvivesRestart(t *testing.T) {
+	cfg := Config{
+		Node:            "127.0.0.1:0",
+		PrivateKey:      wallet.New().PrivateKey,
+		ShareDifficulty: 1,
+		Scheme:          SCHEME_PPLNS,
+		Window:          3,
+		Fee:             0.5,
+		StateFile:       filepath.Join(t.TempDir(), "pool.json"),
+	}
+	node := &fakeNode{chain: []string{"00"}, maturity: 2}
+	start := func(cfg Config) *Pool {
+		t.Helper()
+		p, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.node = node
+		if err := p.newJob(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	p := start(cfg)
+	alice, bob := wallet.New(), wallet.New()
+	s := authorize(t, p, alice, bob)
+	shares, block := nonces(5)
+
+	find := func(p *Pool, w *wallet.Wallet) {
+		t.Helper()
+		if err := p.newJob(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if perr := submit(p, s, w.BlockchainAddress+".rig", block); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+	find(p, alice) // height 1
+	node.extend(1)
+	p.payOut(context.Background())
+	find(p, bob) // height 3, immature
+	p.payOut(context.Background())
+	for i, nonce := range shares {
+		w := alice
+		if i%2 == 1 {
+			w = bob
+		}
+		if perr := submit(p, s, w.BlockchainAddress+".rig", nonce); perr != nil {
+			t.Fatal(perr)
+		}
+	}
+	p.saveState()
+	before := p.Stats()
+	if len(before.Paid) == 0 || len(before.Immature) == 0 {
+		t.Fatalf("paid %v, immature %v; want both", before.Paid, before.Immature)
+	}
+
+	restarted := start(cfg)
+	after := restarted.Stats()
+	if after.Blocks != before.Blocks || !maps.Equal(after.Immature, before.Immature) || !maps.Equal(after.Owed, before.Owed) || !maps.Equal(after.Paid, before.Paid) {
+		t.Fatalf("stats after restart = %+v, want %+v", after, before)
+	}
+	if !maps.Equal(restarted.round, p.round) {
+		t.Fatalf("round after restart = %v, want %v", restarted.round, p.round)
+	}
+	// the window holds the last three shares, oldest first: alice, bob, alice
+	want := []string{alice.BlockchainAddress, bob.BlockchainAddress, alice.BlockchainAddress}
+	if !slices.Equal(restarted.window, want) {
+		t.Fatalf("window after restart = %v, want %v", restarted.window, want)
+	}
+
+	// the immature reward is still paid once it matures
+	node.extend(1)
+	restarted.payOut(context.Background())
+	if paid := restarted.Stats().Paid[bob.BlockchainAddress]; paid <= before.Paid[bob.BlockchainAddress] {
+		t.Fatalf("paid bob %v after restart, want more than %v", paid, before.Paid[bob.BlockchainAddress])
+	}
+}
@@ -0,0 +1,112 @@
+package pool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// state is everything the pool persists between restarts: the shares that rewards are
+// still to be split over and what the miners are owed and were paid.
+type state struct {
+	Immature   []reward           `json:"immature"`
+	Owed       map[string]float32 `json:"owed"`
+	Paid       map[string]float32 `json:"paid"`
+	Round      map[string]int     `json:"round"`
+	Window     []string           `json:"window"`
+	WindowNext int                `json:"window_next"`
+	Blocks     int                `json:"blocks"`
+}
+
+func loadState(path string) (*state, error) {
+	s := new(state)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pool: failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("pool: failed to decode %s: %v", path, err)
+	}
+	return s, nil
+}
+
+// save writes the state to a temporary file and renames it over path so a crash
+// never leaves a half-written file behind.
+func (s *state) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("pool: failed to encode state: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("pool: failed to create state directory: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("pool: failed to write %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("pool: failed to replace %s: %v", path, err)
+	}
+	return nil
+}
+
+// restore takes over a loaded state. The window is unrolled oldest first, keeping the
+// most recent cfg.Window shares if it was saved with a larger one.
+func (p *Pool) restore(s *state) {
+	p.immature = s.Immature
+	if s.Owed != nil {
+		p.owed = s.Owed
+	}
+	if s.Paid != nil {
+		p.paid = s.Paid
+	}
+	if s.Round != nil {
+		p.round = s.Round
+	}
+	p.blocks = s.Blocks
+	if s.WindowNext >= 0 && s.WindowNext < len(s.Window) {
+		window := append(slices.Clone(s.Window[s.WindowNext:]), s.Window[:s.WindowNext]...)
+		p.window = window[max(len(window)-p.cfg.Window, 0):]
+	}
+}
+
+// saveState writes the state file if anything changed since it was last written.
+func (p *Pool) saveState() {
+	if p.cfg.StateFile == "" {
+		return
+	}
+	p.stateMut.Lock()
+	defer p.stateMut.Unlock()
+
+	p.mut.Lock()
+	if !p.dirty {
+		p.mut.Unlock()
+		return
+	}
+	s := &state{
+		Immature:   slices.Clone(p.immature),
+		Owed:       maps.Clone(p.owed),
+		Paid:       maps.Clone(p.paid),
+		Round:      maps.Clone(p.round),
+		Window:     slices.Clone(p.window),
+		WindowNext: p.windowNext,
+		Blocks:     p.blocks,
+	}
+	p.dirty = false
+	p.mut.Unlock()
+
+	if err := s.save(p.cfg.StateFile); err != nil {
+		log.Printf("%v", err)
+		p.mut.Lock()
+		p.dirty = true
+		p.mut.Unlock()
+	}
+}
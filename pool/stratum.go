@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Stratum-like methods. Every message is one JSON object per line; requests carry an
+// id, notifications from the pool carry a null id.
+const (
+	METHOD_SUBSCRIBE      = "mining.subscribe"      // params: [agent]; result: Subscription
+	METHOD_AUTHORIZE      = "mining.authorize"      // params: [worker, password]; result: true
+	METHOD_SUBMIT         = "mining.submit"         // params: [worker, job id, nonce]; result: true
+	METHOD_NOTIFY         = "mining.notify"         // params: [Job]
+	METHOD_SET_DIFFICULTY = "mining.set_difficulty" // params: [share difficulty]
+	METHOD_STATS          = "pool.stats"            // params: []; result: Stats
+
+	ERR_OTHER          = 20
+	ERR_STALE          = 21 // the job is unknown or belongs to an old chain tip
+	ERR_DUPLICATE      = 22
+	ERR_LOW_DIFFICULTY = 23
+	ERR_UNAUTHORIZED   = 24
+	ERR_NOT_SUBSCRIBED = 25
+	ERR_NONCE_RANGE    = 26 // the nonce is outside the range the connection was given
+)
+
+type request struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Error is a protocol error, sent as [code, message].
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("pool: %s (%d)", e.Message, e.Code)
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Code, e.Message})
+}
+
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var v []json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil || len(v) < 2 {
+		return fmt.Errorf("pool: malformed error %s", b)
+	}
+	if err := json.Unmarshal(v[0], &e.Code); err != nil {
+		return err
+	}
+	return json.Unmarshal(v[1], &e.Message)
+}
+
+// Subscription is the result of mining.subscribe. Miners search the NONCE_RANGE nonces
+// from NonceStart so that nobody on the pool repeats another miner's work; shares with
+// nonces outside them are rejected.
+type Subscription struct {
+	ID         string `json:"subscription_id"`
+	NonceStart int64  `json:"nonce_start"`
+}
+
+// Job is the work for one block. The proof-of-work hash of a nonce is
+// sha256(HeaderPrefix || nonce in decimal || HeaderSuffix); it is a share once it meets
+// the share difficulty and a block once it meets Difficulty.
+type Job struct {
+	ID           string `json:"job_id"`
+	Height       int    `json:"height"`
+	HeaderPrefix string `json:"header_prefix"`
+	HeaderSuffix string `json:"header_suffix"`
+	Difficulty   int    `json:"difficulty"`
+	Clean        bool   `json:"clean_jobs"` // the chain tip moved; abandon older jobs
+}
+
+// payoutAddress returns the address a worker named "address" or "address.rig" is paid to.
+func payoutAddress(worker string) string {
+	addr, _, _ := strings.Cut(worker, ".")
+	return addr
+}
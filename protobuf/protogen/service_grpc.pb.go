@@ -19,9 +19,42 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	WalletService_CreateTransaction_FullMethodName = "/WalletService/CreateTransaction"
-	WalletService_CreateWallet_FullMethodName      = "/WalletService/CreateWallet"
-	WalletService_WalletBalance_FullMethodName     = "/WalletService/WalletBalance"
+	WalletService_CreateTransaction_FullMethodName          = "/WalletService/CreateTransaction"
+	WalletService_PrepareTransaction_FullMethodName         = "/WalletService/PrepareTransaction"
+	WalletService_SubmitSignedTransaction_FullMethodName    = "/WalletService/SubmitSignedTransaction"
+	WalletService_CancelTransaction_FullMethodName          = "/WalletService/CancelTransaction"
+	WalletService_CreateWallet_FullMethodName               = "/WalletService/CreateWallet"
+	WalletService_WalletBalance_FullMethodName              = "/WalletService/WalletBalance"
+	WalletService_GetAddress_FullMethodName                 = "/WalletService/GetAddress"
+	WalletService_ValidateAddress_FullMethodName            = "/WalletService/ValidateAddress"
+	WalletService_CreateWatchGroup_FullMethodName           = "/WalletService/CreateWatchGroup"
+	WalletService_ListWatchGroups_FullMethodName            = "/WalletService/ListWatchGroups"
+	WalletService_AddWatchAddresses_FullMethodName          = "/WalletService/AddWatchAddresses"
+	WalletService_RemoveWatchAddresses_FullMethodName       = "/WalletService/RemoveWatchAddresses"
+	WalletService_DeleteWatchGroup_FullMethodName           = "/WalletService/DeleteWatchGroup"
+	WalletService_WatchGroupBalance_FullMethodName          = "/WalletService/WatchGroupBalance"
+	WalletService_ListWatchGroupTransactions_FullMethodName = "/WalletService/ListWatchGroupTransactions"
+	WalletService_SubscribeWatchGroup_FullMethodName        = "/WalletService/SubscribeWatchGroup"
+	WalletService_CreateKeystoreWallet_FullMethodName       = "/WalletService/CreateKeystoreWallet"
+	WalletService_ListKeystoreWallets_FullMethodName        = "/WalletService/ListKeystoreWallets"
+	WalletService_UnlockKeystoreWallet_FullMethodName       = "/WalletService/UnlockKeystoreWallet"
+	WalletService_LockKeystoreWallet_FullMethodName         = "/WalletService/LockKeystoreWallet"
+	WalletService_DeleteKeystoreWallet_FullMethodName       = "/WalletService/DeleteKeystoreWallet"
+	WalletService_ImportWallet_FullMethodName               = "/WalletService/ImportWallet"
+	WalletService_ExportWallet_FullMethodName               = "/WalletService/ExportWallet"
+	WalletService_ExportPublicKey_FullMethodName            = "/WalletService/ExportPublicKey"
+	WalletService_CreateHDWallet_FullMethodName             = "/WalletService/CreateHDWallet"
+	WalletService_DeriveAddress_FullMethodName              = "/WalletService/DeriveAddress"
+	WalletService_DiscoverAddresses_FullMethodName          = "/WalletService/DiscoverAddresses"
+	WalletService_ListAddressTransactions_FullMethodName    = "/WalletService/ListAddressTransactions"
+	WalletService_CreateMultisigAddress_FullMethodName      = "/WalletService/CreateMultisigAddress"
+	WalletService_SignMultisigTransaction_FullMethodName    = "/WalletService/SignMultisigTransaction"
+	WalletService_CombineMultisigTransaction_FullMethodName = "/WalletService/CombineMultisigTransaction"
+	WalletService_CreatePartialTransaction_FullMethodName   = "/WalletService/CreatePartialTransaction"
+	WalletService_DecodePartialTransaction_FullMethodName   = "/WalletService/DecodePartialTransaction"
+	WalletService_SignPartialTransaction_FullMethodName     = "/WalletService/SignPartialTransaction"
+	WalletService_CombinePartialTransactions_FullMethodName = "/WalletService/CombinePartialTransactions"
+	WalletService_FinalizePartialTransaction_FullMethodName = "/WalletService/FinalizePartialTransaction"
 )
 
 // WalletServiceClient is the client API for WalletService service.
@@ -29,8 +62,41 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WalletServiceClient interface {
 	CreateTransaction(ctx context.Context, in *WalletTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	PrepareTransaction(ctx context.Context, in *PrepareTransactionRequest, opts ...grpc.CallOption) (*PrepareTransactionResponse, error)
+	SubmitSignedTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CancelTransaction(ctx context.Context, in *CancelTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	CreateWallet(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CreateWalletResponse, error)
 	WalletBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	GetAddress(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error)
+	ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error)
+	CreateWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error)
+	ListWatchGroups(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWatchGroupsResponse, error)
+	AddWatchAddresses(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error)
+	RemoveWatchAddresses(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error)
+	DeleteWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	WatchGroupBalance(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupBalanceResponse, error)
+	ListWatchGroupTransactions(ctx context.Context, in *WatchGroupTransactionsRequest, opts ...grpc.CallOption) (*WatchGroupTransactionsResponse, error)
+	SubscribeWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (WalletService_SubscribeWatchGroupClient, error)
+	CreateKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error)
+	ListKeystoreWallets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListKeystoreWalletsResponse, error)
+	UnlockKeystoreWallet(ctx context.Context, in *UnlockKeystoreWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error)
+	LockKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ImportWallet(ctx context.Context, in *ImportWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error)
+	ExportWallet(ctx context.Context, in *ExportWalletRequest, opts ...grpc.CallOption) (*ExportWalletResponse, error)
+	ExportPublicKey(ctx context.Context, in *ExportPublicKeyRequest, opts ...grpc.CallOption) (*ExportPublicKeyResponse, error)
+	CreateHDWallet(ctx context.Context, in *CreateHDWalletRequest, opts ...grpc.CallOption) (*HDWalletResponse, error)
+	DeriveAddress(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*DeriveAddressResponse, error)
+	DiscoverAddresses(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*DiscoverAddressesResponse, error)
+	ListAddressTransactions(ctx context.Context, in *ListAddressTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error)
+	CreateMultisigAddress(ctx context.Context, in *CreateMultisigAddressRequest, opts ...grpc.CallOption) (*MultisigAddressResponse, error)
+	SignMultisigTransaction(ctx context.Context, in *SignMultisigTransactionRequest, opts ...grpc.CallOption) (*SignMultisigTransactionResponse, error)
+	CombineMultisigTransaction(ctx context.Context, in *CombineMultisigTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreatePartialTransaction(ctx context.Context, in *CreatePartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error)
+	DecodePartialTransaction(ctx context.Context, in *PartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error)
+	SignPartialTransaction(ctx context.Context, in *SignPartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error)
+	CombinePartialTransactions(ctx context.Context, in *CombinePartialTransactionsRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error)
+	FinalizePartialTransaction(ctx context.Context, in *PartialTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 }
 
 type walletServiceClient struct {
@@ -50,6 +116,33 @@ func (c *walletServiceClient) CreateTransaction(ctx context.Context, in *WalletT
 	return out, nil
 }
 
+func (c *walletServiceClient) PrepareTransaction(ctx context.Context, in *PrepareTransactionRequest, opts ...grpc.CallOption) (*PrepareTransactionResponse, error) {
+	out := new(PrepareTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_PrepareTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SubmitSignedTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_SubmitSignedTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CancelTransaction(ctx context.Context, in *CancelTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_CancelTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) CreateWallet(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CreateWalletResponse, error) {
 	out := new(CreateWalletResponse)
 	err := c.cc.Invoke(ctx, WalletService_CreateWallet_FullMethodName, in, out, opts...)
@@ -68,13 +161,339 @@ func (c *walletServiceClient) WalletBalance(ctx context.Context, in *BalanceRequ
 	return out, nil
 }
 
+func (c *walletServiceClient) GetAddress(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error) {
+	out := new(AddressResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error) {
+	out := new(ValidateAddressResponse)
+	err := c.cc.Invoke(ctx, WalletService_ValidateAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error) {
+	out := new(WatchGroupResponse)
+	err := c.cc.Invoke(ctx, WalletService_CreateWatchGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListWatchGroups(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWatchGroupsResponse, error) {
+	out := new(ListWatchGroupsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListWatchGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AddWatchAddresses(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error) {
+	out := new(WatchGroupResponse)
+	err := c.cc.Invoke(ctx, WalletService_AddWatchAddresses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RemoveWatchAddresses(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupResponse, error) {
+	out := new(WatchGroupResponse)
+	err := c.cc.Invoke(ctx, WalletService_RemoveWatchAddresses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeleteWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_DeleteWatchGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WatchGroupBalance(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (*WatchGroupBalanceResponse, error) {
+	out := new(WatchGroupBalanceResponse)
+	err := c.cc.Invoke(ctx, WalletService_WatchGroupBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListWatchGroupTransactions(ctx context.Context, in *WatchGroupTransactionsRequest, opts ...grpc.CallOption) (*WatchGroupTransactionsResponse, error) {
+	out := new(WatchGroupTransactionsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListWatchGroupTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SubscribeWatchGroup(ctx context.Context, in *WatchGroupRequest, opts ...grpc.CallOption) (WalletService_SubscribeWatchGroupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], WalletService_SubscribeWatchGroup_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceSubscribeWatchGroupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_SubscribeWatchGroupClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type walletServiceSubscribeWatchGroupClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceSubscribeWatchGroupClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) CreateKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error) {
+	out := new(KeystoreWalletResponse)
+	err := c.cc.Invoke(ctx, WalletService_CreateKeystoreWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListKeystoreWallets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListKeystoreWalletsResponse, error) {
+	out := new(ListKeystoreWalletsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListKeystoreWallets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) UnlockKeystoreWallet(ctx context.Context, in *UnlockKeystoreWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error) {
+	out := new(KeystoreWalletResponse)
+	err := c.cc.Invoke(ctx, WalletService_UnlockKeystoreWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LockKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_LockKeystoreWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeleteKeystoreWallet(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_DeleteKeystoreWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportWallet(ctx context.Context, in *ImportWalletRequest, opts ...grpc.CallOption) (*KeystoreWalletResponse, error) {
+	out := new(KeystoreWalletResponse)
+	err := c.cc.Invoke(ctx, WalletService_ImportWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExportWallet(ctx context.Context, in *ExportWalletRequest, opts ...grpc.CallOption) (*ExportWalletResponse, error) {
+	out := new(ExportWalletResponse)
+	err := c.cc.Invoke(ctx, WalletService_ExportWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExportPublicKey(ctx context.Context, in *ExportPublicKeyRequest, opts ...grpc.CallOption) (*ExportPublicKeyResponse, error) {
+	out := new(ExportPublicKeyResponse)
+	err := c.cc.Invoke(ctx, WalletService_ExportPublicKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateHDWallet(ctx context.Context, in *CreateHDWalletRequest, opts ...grpc.CallOption) (*HDWalletResponse, error) {
+	out := new(HDWalletResponse)
+	err := c.cc.Invoke(ctx, WalletService_CreateHDWallet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeriveAddress(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*DeriveAddressResponse, error) {
+	out := new(DeriveAddressResponse)
+	err := c.cc.Invoke(ctx, WalletService_DeriveAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DiscoverAddresses(ctx context.Context, in *KeystoreWalletRequest, opts ...grpc.CallOption) (*DiscoverAddressesResponse, error) {
+	out := new(DiscoverAddressesResponse)
+	err := c.cc.Invoke(ctx, WalletService_DiscoverAddresses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListAddressTransactions(ctx context.Context, in *ListAddressTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error) {
+	out := new(ListAddressTransactionsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListAddressTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateMultisigAddress(ctx context.Context, in *CreateMultisigAddressRequest, opts ...grpc.CallOption) (*MultisigAddressResponse, error) {
+	out := new(MultisigAddressResponse)
+	err := c.cc.Invoke(ctx, WalletService_CreateMultisigAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignMultisigTransaction(ctx context.Context, in *SignMultisigTransactionRequest, opts ...grpc.CallOption) (*SignMultisigTransactionResponse, error) {
+	out := new(SignMultisigTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_SignMultisigTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CombineMultisigTransaction(ctx context.Context, in *CombineMultisigTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_CombineMultisigTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreatePartialTransaction(ctx context.Context, in *CreatePartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error) {
+	out := new(PartialTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_CreatePartialTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DecodePartialTransaction(ctx context.Context, in *PartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error) {
+	out := new(PartialTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_DecodePartialTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignPartialTransaction(ctx context.Context, in *SignPartialTransactionRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error) {
+	out := new(PartialTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_SignPartialTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CombinePartialTransactions(ctx context.Context, in *CombinePartialTransactionsRequest, opts ...grpc.CallOption) (*PartialTransactionResponse, error) {
+	out := new(PartialTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_CombinePartialTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FinalizePartialTransaction(ctx context.Context, in *PartialTransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, WalletService_FinalizePartialTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletServiceServer is the server API for WalletService service.
 // All implementations must embed UnimplementedWalletServiceServer
 // for forward compatibility
 type WalletServiceServer interface {
 	CreateTransaction(context.Context, *WalletTransactionRequest) (*StatusResponse, error)
+	PrepareTransaction(context.Context, *PrepareTransactionRequest) (*PrepareTransactionResponse, error)
+	SubmitSignedTransaction(context.Context, *TransactionRequest) (*StatusResponse, error)
+	CancelTransaction(context.Context, *CancelTransactionRequest) (*StatusResponse, error)
 	CreateWallet(context.Context, *Empty) (*CreateWalletResponse, error)
 	WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	GetAddress(context.Context, *AddressRequest) (*AddressResponse, error)
+	ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error)
+	CreateWatchGroup(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error)
+	ListWatchGroups(context.Context, *Empty) (*ListWatchGroupsResponse, error)
+	AddWatchAddresses(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error)
+	RemoveWatchAddresses(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error)
+	DeleteWatchGroup(context.Context, *WatchGroupRequest) (*StatusResponse, error)
+	WatchGroupBalance(context.Context, *WatchGroupRequest) (*WatchGroupBalanceResponse, error)
+	ListWatchGroupTransactions(context.Context, *WatchGroupTransactionsRequest) (*WatchGroupTransactionsResponse, error)
+	SubscribeWatchGroup(*WatchGroupRequest, WalletService_SubscribeWatchGroupServer) error
+	CreateKeystoreWallet(context.Context, *KeystoreWalletRequest) (*KeystoreWalletResponse, error)
+	ListKeystoreWallets(context.Context, *Empty) (*ListKeystoreWalletsResponse, error)
+	UnlockKeystoreWallet(context.Context, *UnlockKeystoreWalletRequest) (*KeystoreWalletResponse, error)
+	LockKeystoreWallet(context.Context, *KeystoreWalletRequest) (*StatusResponse, error)
+	DeleteKeystoreWallet(context.Context, *KeystoreWalletRequest) (*StatusResponse, error)
+	ImportWallet(context.Context, *ImportWalletRequest) (*KeystoreWalletResponse, error)
+	ExportWallet(context.Context, *ExportWalletRequest) (*ExportWalletResponse, error)
+	ExportPublicKey(context.Context, *ExportPublicKeyRequest) (*ExportPublicKeyResponse, error)
+	CreateHDWallet(context.Context, *CreateHDWalletRequest) (*HDWalletResponse, error)
+	DeriveAddress(context.Context, *KeystoreWalletRequest) (*DeriveAddressResponse, error)
+	DiscoverAddresses(context.Context, *KeystoreWalletRequest) (*DiscoverAddressesResponse, error)
+	ListAddressTransactions(context.Context, *ListAddressTransactionsRequest) (*ListAddressTransactionsResponse, error)
+	CreateMultisigAddress(context.Context, *CreateMultisigAddressRequest) (*MultisigAddressResponse, error)
+	SignMultisigTransaction(context.Context, *SignMultisigTransactionRequest) (*SignMultisigTransactionResponse, error)
+	CombineMultisigTransaction(context.Context, *CombineMultisigTransactionRequest) (*StatusResponse, error)
+	CreatePartialTransaction(context.Context, *CreatePartialTransactionRequest) (*PartialTransactionResponse, error)
+	DecodePartialTransaction(context.Context, *PartialTransactionRequest) (*PartialTransactionResponse, error)
+	SignPartialTransaction(context.Context, *SignPartialTransactionRequest) (*PartialTransactionResponse, error)
+	CombinePartialTransactions(context.Context, *CombinePartialTransactionsRequest) (*PartialTransactionResponse, error)
+	FinalizePartialTransaction(context.Context, *PartialTransactionRequest) (*StatusResponse, error)
 	mustEmbedUnimplementedWalletServiceServer()
 }
 
@@ -85,12 +504,111 @@ type UnimplementedWalletServiceServer struct {
 func (UnimplementedWalletServiceServer) CreateTransaction(context.Context, *WalletTransactionRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
 }
+func (UnimplementedWalletServiceServer) PrepareTransaction(context.Context, *PrepareTransactionRequest) (*PrepareTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) SubmitSignedTransaction(context.Context, *TransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSignedTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) CancelTransaction(context.Context, *CancelTransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransaction not implemented")
+}
 func (UnimplementedWalletServiceServer) CreateWallet(context.Context, *Empty) (*CreateWalletResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateWallet not implemented")
 }
 func (UnimplementedWalletServiceServer) WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WalletBalance not implemented")
 }
+func (UnimplementedWalletServiceServer) GetAddress(context.Context, *AddressRequest) (*AddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddress not implemented")
+}
+func (UnimplementedWalletServiceServer) ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAddress not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateWatchGroup(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWatchGroup not implemented")
+}
+func (UnimplementedWalletServiceServer) ListWatchGroups(context.Context, *Empty) (*ListWatchGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatchGroups not implemented")
+}
+func (UnimplementedWalletServiceServer) AddWatchAddresses(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWatchAddresses not implemented")
+}
+func (UnimplementedWalletServiceServer) RemoveWatchAddresses(context.Context, *WatchGroupRequest) (*WatchGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWatchAddresses not implemented")
+}
+func (UnimplementedWalletServiceServer) DeleteWatchGroup(context.Context, *WatchGroupRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWatchGroup not implemented")
+}
+func (UnimplementedWalletServiceServer) WatchGroupBalance(context.Context, *WatchGroupRequest) (*WatchGroupBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchGroupBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) ListWatchGroupTransactions(context.Context, *WatchGroupTransactionsRequest) (*WatchGroupTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatchGroupTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) SubscribeWatchGroup(*WatchGroupRequest, WalletService_SubscribeWatchGroupServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeWatchGroup not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateKeystoreWallet(context.Context, *KeystoreWalletRequest) (*KeystoreWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateKeystoreWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ListKeystoreWallets(context.Context, *Empty) (*ListKeystoreWalletsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeystoreWallets not implemented")
+}
+func (UnimplementedWalletServiceServer) UnlockKeystoreWallet(context.Context, *UnlockKeystoreWalletRequest) (*KeystoreWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockKeystoreWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) LockKeystoreWallet(context.Context, *KeystoreWalletRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockKeystoreWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) DeleteKeystoreWallet(context.Context, *KeystoreWalletRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteKeystoreWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportWallet(context.Context, *ImportWalletRequest) (*KeystoreWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportWallet(context.Context, *ExportWalletRequest) (*ExportWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportPublicKey(context.Context, *ExportPublicKeyRequest) (*ExportPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPublicKey not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateHDWallet(context.Context, *CreateHDWalletRequest) (*HDWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHDWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) DeriveAddress(context.Context, *KeystoreWalletRequest) (*DeriveAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeriveAddress not implemented")
+}
+func (UnimplementedWalletServiceServer) DiscoverAddresses(context.Context, *KeystoreWalletRequest) (*DiscoverAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverAddresses not implemented")
+}
+func (UnimplementedWalletServiceServer) ListAddressTransactions(context.Context, *ListAddressTransactionsRequest) (*ListAddressTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateMultisigAddress(context.Context, *CreateMultisigAddressRequest) (*MultisigAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMultisigAddress not implemented")
+}
+func (UnimplementedWalletServiceServer) SignMultisigTransaction(context.Context, *SignMultisigTransactionRequest) (*SignMultisigTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMultisigTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) CombineMultisigTransaction(context.Context, *CombineMultisigTransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombineMultisigTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) CreatePartialTransaction(context.Context, *CreatePartialTransactionRequest) (*PartialTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePartialTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) DecodePartialTransaction(context.Context, *PartialTransactionRequest) (*PartialTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodePartialTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) SignPartialTransaction(context.Context, *SignPartialTransactionRequest) (*PartialTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignPartialTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) CombinePartialTransactions(context.Context, *CombinePartialTransactionsRequest) (*PartialTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombinePartialTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) FinalizePartialTransaction(context.Context, *PartialTransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizePartialTransaction not implemented")
+}
 func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 
 // UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -122,262 +640,1948 @@ func _WalletService_CreateTransaction_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _WalletService_PrepareTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).PrepareTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_PrepareTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).PrepareTransaction(ctx, req.(*PrepareTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SubmitSignedTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SubmitSignedTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_SubmitSignedTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SubmitSignedTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CancelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CancelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CancelTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CancelTransaction(ctx, req.(*CancelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateWallet(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_WalletBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletBalance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddress(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ValidateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ValidateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ValidateAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ValidateAddress(ctx, req.(*ValidateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateWatchGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateWatchGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateWatchGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateWatchGroup(ctx, req.(*WatchGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListWatchGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListWatchGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListWatchGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListWatchGroups(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_AddWatchAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AddWatchAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_AddWatchAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AddWatchAddresses(ctx, req.(*WatchGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RemoveWatchAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RemoveWatchAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_RemoveWatchAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RemoveWatchAddresses(ctx, req.(*WatchGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeleteWatchGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeleteWatchGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DeleteWatchGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeleteWatchGroup(ctx, req.(*WatchGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WatchGroupBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WatchGroupBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_WatchGroupBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WatchGroupBalance(ctx, req.(*WatchGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListWatchGroupTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchGroupTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListWatchGroupTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListWatchGroupTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListWatchGroupTransactions(ctx, req.(*WatchGroupTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SubscribeWatchGroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).SubscribeWatchGroup(m, &walletServiceSubscribeWatchGroupServer{stream})
+}
+
+type WalletService_SubscribeWatchGroupServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type walletServiceSubscribeWatchGroupServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceSubscribeWatchGroupServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_CreateKeystoreWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateKeystoreWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateKeystoreWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateKeystoreWallet(ctx, req.(*KeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListKeystoreWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListKeystoreWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListKeystoreWallets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListKeystoreWallets(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_UnlockKeystoreWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockKeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).UnlockKeystoreWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_UnlockKeystoreWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).UnlockKeystoreWallet(ctx, req.(*UnlockKeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_LockKeystoreWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).LockKeystoreWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_LockKeystoreWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).LockKeystoreWallet(ctx, req.(*KeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeleteKeystoreWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeleteKeystoreWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DeleteKeystoreWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeleteKeystoreWallet(ctx, req.(*KeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ImportWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportWallet(ctx, req.(*ImportWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExportWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ExportWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExportWallet(ctx, req.(*ExportWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExportPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ExportPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExportPublicKey(ctx, req.(*ExportPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateHDWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHDWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateHDWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateHDWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateHDWallet(ctx, req.(*CreateHDWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeriveAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeriveAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DeriveAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeriveAddress(ctx, req.(*KeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DiscoverAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DiscoverAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DiscoverAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DiscoverAddresses(ctx, req.(*KeystoreWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListAddressTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListAddressTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListAddressTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListAddressTransactions(ctx, req.(*ListAddressTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateMultisigAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultisigAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateMultisigAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, req.(*CreateMultisigAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignMultisigTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMultisigTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignMultisigTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_SignMultisigTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignMultisigTransaction(ctx, req.(*SignMultisigTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CombineMultisigTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombineMultisigTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CombineMultisigTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CombineMultisigTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CombineMultisigTransaction(ctx, req.(*CombineMultisigTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreatePartialTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePartialTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreatePartialTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreatePartialTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreatePartialTransaction(ctx, req.(*CreatePartialTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DecodePartialTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DecodePartialTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DecodePartialTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DecodePartialTransaction(ctx, req.(*PartialTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignPartialTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPartialTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignPartialTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_SignPartialTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignPartialTransaction(ctx, req.(*SignPartialTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CombinePartialTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombinePartialTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CombinePartialTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CombinePartialTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CombinePartialTransactions(ctx, req.(*CombinePartialTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_FinalizePartialTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FinalizePartialTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_FinalizePartialTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FinalizePartialTransaction(ctx, req.(*PartialTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTransaction",
+			Handler:    _WalletService_CreateTransaction_Handler,
+		},
+		{
+			MethodName: "PrepareTransaction",
+			Handler:    _WalletService_PrepareTransaction_Handler,
+		},
+		{
+			MethodName: "SubmitSignedTransaction",
+			Handler:    _WalletService_SubmitSignedTransaction_Handler,
+		},
+		{
+			MethodName: "CancelTransaction",
+			Handler:    _WalletService_CancelTransaction_Handler,
+		},
+		{
+			MethodName: "CreateWallet",
+			Handler:    _WalletService_CreateWallet_Handler,
+		},
+		{
+			MethodName: "WalletBalance",
+			Handler:    _WalletService_WalletBalance_Handler,
+		},
+		{
+			MethodName: "GetAddress",
+			Handler:    _WalletService_GetAddress_Handler,
+		},
+		{
+			MethodName: "ValidateAddress",
+			Handler:    _WalletService_ValidateAddress_Handler,
+		},
+		{
+			MethodName: "CreateWatchGroup",
+			Handler:    _WalletService_CreateWatchGroup_Handler,
+		},
+		{
+			MethodName: "ListWatchGroups",
+			Handler:    _WalletService_ListWatchGroups_Handler,
+		},
+		{
+			MethodName: "AddWatchAddresses",
+			Handler:    _WalletService_AddWatchAddresses_Handler,
+		},
+		{
+			MethodName: "RemoveWatchAddresses",
+			Handler:    _WalletService_RemoveWatchAddresses_Handler,
+		},
+		{
+			MethodName: "DeleteWatchGroup",
+			Handler:    _WalletService_DeleteWatchGroup_Handler,
+		},
+		{
+			MethodName: "WatchGroupBalance",
+			Handler:    _WalletService_WatchGroupBalance_Handler,
+		},
+		{
+			MethodName: "ListWatchGroupTransactions",
+			Handler:    _WalletService_ListWatchGroupTransactions_Handler,
+		},
+		{
+			MethodName: "CreateKeystoreWallet",
+			Handler:    _WalletService_CreateKeystoreWallet_Handler,
+		},
+		{
+			MethodName: "ListKeystoreWallets",
+			Handler:    _WalletService_ListKeystoreWallets_Handler,
+		},
+		{
+			MethodName: "UnlockKeystoreWallet",
+			Handler:    _WalletService_UnlockKeystoreWallet_Handler,
+		},
+		{
+			MethodName: "LockKeystoreWallet",
+			Handler:    _WalletService_LockKeystoreWallet_Handler,
+		},
+		{
+			MethodName: "DeleteKeystoreWallet",
+			Handler:    _WalletService_DeleteKeystoreWallet_Handler,
+		},
+		{
+			MethodName: "ImportWallet",
+			Handler:    _WalletService_ImportWallet_Handler,
+		},
+		{
+			MethodName: "ExportWallet",
+			Handler:    _WalletService_ExportWallet_Handler,
+		},
+		{
+			MethodName: "ExportPublicKey",
+			Handler:    _WalletService_ExportPublicKey_Handler,
+		},
+		{
+			MethodName: "CreateHDWallet",
+			Handler:    _WalletService_CreateHDWallet_Handler,
+		},
+		{
+			MethodName: "DeriveAddress",
+			Handler:    _WalletService_DeriveAddress_Handler,
+		},
+		{
+			MethodName: "DiscoverAddresses",
+			Handler:    _WalletService_DiscoverAddresses_Handler,
+		},
+		{
+			MethodName: "ListAddressTransactions",
+			Handler:    _WalletService_ListAddressTransactions_Handler,
+		},
+		{
+			MethodName: "CreateMultisigAddress",
+			Handler:    _WalletService_CreateMultisigAddress_Handler,
+		},
+		{
+			MethodName: "SignMultisigTransaction",
+			Handler:    _WalletService_SignMultisigTransaction_Handler,
+		},
+		{
+			MethodName: "CombineMultisigTransaction",
+			Handler:    _WalletService_CombineMultisigTransaction_Handler,
+		},
+		{
+			MethodName: "CreatePartialTransaction",
+			Handler:    _WalletService_CreatePartialTransaction_Handler,
+		},
+		{
+			MethodName: "DecodePartialTransaction",
+			Handler:    _WalletService_DecodePartialTransaction_Handler,
+		},
+		{
+			MethodName: "SignPartialTransaction",
+			Handler:    _WalletService_SignPartialTransaction_Handler,
+		},
+		{
+			MethodName: "CombinePartialTransactions",
+			Handler:    _WalletService_CombinePartialTransactions_Handler,
+		},
+		{
+			MethodName: "FinalizePartialTransaction",
+			Handler:    _WalletService_FinalizePartialTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeWatchGroup",
+			Handler:       _WalletService_SubscribeWatchGroup_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "service.proto",
+}
+
+const (
+	BlockChainService_ListTransactions_FullMethodName          = "/BlockChainService/ListTransactions"
+	BlockChainService_GetTransaction_FullMethodName            = "/BlockChainService/GetTransaction"
+	BlockChainService_GetBlockChain_FullMethodName             = "/BlockChainService/GetBlockChain"
+	BlockChainService_GetBlockByHeight_FullMethodName          = "/BlockChainService/GetBlockByHeight"
+	BlockChainService_GetBlockByHash_FullMethodName            = "/BlockChainService/GetBlockByHash"
+	BlockChainService_GetChainTip_FullMethodName               = "/BlockChainService/GetChainTip"
+	BlockChainService_ListBlocks_FullMethodName                = "/BlockChainService/ListBlocks"
+	BlockChainService_SubscribeBlocks_FullMethodName           = "/BlockChainService/SubscribeBlocks"
+	BlockChainService_SubscribeMempool_FullMethodName          = "/BlockChainService/SubscribeMempool"
+	BlockChainService_SubscribeAddress_FullMethodName          = "/BlockChainService/SubscribeAddress"
+	BlockChainService_RegisterWebhook_FullMethodName           = "/BlockChainService/RegisterWebhook"
+	BlockChainService_ListWebhooks_FullMethodName              = "/BlockChainService/ListWebhooks"
+	BlockChainService_DeleteWebhook_FullMethodName             = "/BlockChainService/DeleteWebhook"
+	BlockChainService_ListWebhookDeliveries_FullMethodName     = "/BlockChainService/ListWebhookDeliveries"
+	BlockChainService_StartMining_FullMethodName               = "/BlockChainService/StartMining"
+	BlockChainService_StopMining_FullMethodName                = "/BlockChainService/StopMining"
+	BlockChainService_SetMiningWorkers_FullMethodName          = "/BlockChainService/SetMiningWorkers"
+	BlockChainService_SetCoinbaseAddress_FullMethodName        = "/BlockChainService/SetCoinbaseAddress"
+	BlockChainService_GetMiningStatus_FullMethodName           = "/BlockChainService/GetMiningStatus"
+	BlockChainService_GetBlockTemplate_FullMethodName          = "/BlockChainService/GetBlockTemplate"
+	BlockChainService_SubmitBlock_FullMethodName               = "/BlockChainService/SubmitBlock"
+	BlockChainService_GenerateBlocks_FullMethodName            = "/BlockChainService/GenerateBlocks"
+	BlockChainService_GetSupplyInfo_FullMethodName             = "/BlockChainService/GetSupplyInfo"
+	BlockChainService_GetSyncInfo_FullMethodName               = "/BlockChainService/GetSyncInfo"
+	BlockChainService_WalletBalance_FullMethodName             = "/BlockChainService/WalletBalance"
+	BlockChainService_WalletBalances_FullMethodName            = "/BlockChainService/WalletBalances"
+	BlockChainService_ListAddressTransactions_FullMethodName   = "/BlockChainService/ListAddressTransactions"
+	BlockChainService_ListAddressesTransactions_FullMethodName = "/BlockChainService/ListAddressesTransactions"
+	BlockChainService_AccountNonce_FullMethodName              = "/BlockChainService/AccountNonce"
+	BlockChainService_CreateTransaction_FullMethodName         = "/BlockChainService/CreateTransaction"
+	BlockChainService_UpdateTransaction_FullMethodName         = "/BlockChainService/UpdateTransaction"
+	BlockChainService_DeleteTransaction_FullMethodName         = "/BlockChainService/DeleteTransaction"
+	BlockChainService_Consensus_FullMethodName                 = "/BlockChainService/Consensus"
+	BlockChainService_Handshake_FullMethodName                 = "/BlockChainService/Handshake"
+)
+
+// BlockChainServiceClient is the client API for BlockChainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlockChainServiceClient interface {
+	ListTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	GetBlockChain(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetBlockChainResponse, error)
+	GetBlockByHeight(ctx context.Context, in *BlockHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	GetBlockByHash(ctx context.Context, in *BlockHashRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	GetChainTip(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChainTipResponse, error)
+	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error)
+	// streaming calls are served over Server-Sent Events by the gateway under /v1/events
+	SubscribeBlocks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (BlockChainService_SubscribeBlocksClient, error)
+	SubscribeMempool(ctx context.Context, in *Empty, opts ...grpc.CallOption) (BlockChainService_SubscribeMempoolClient, error)
+	SubscribeAddress(ctx context.Context, in *SubscribeAddressRequest, opts ...grpc.CallOption) (BlockChainService_SubscribeAddressClient, error)
+	RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// mining control is only served over gRPC and requires the node's admin token
+	StartMining(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error)
+	StopMining(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error)
+	SetMiningWorkers(ctx context.Context, in *MiningWorkersRequest, opts ...grpc.CallOption) (*MiningStatus, error)
+	SetCoinbaseAddress(ctx context.Context, in *CoinbaseAddressRequest, opts ...grpc.CallOption) (*MiningStatus, error)
+	GetMiningStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error)
+	GetBlockTemplate(ctx context.Context, in *BlockTemplateRequest, opts ...grpc.CallOption) (*BlockTemplate, error)
+	SubmitBlock(ctx context.Context, in *SubmitBlockRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	GenerateBlocks(ctx context.Context, in *GenerateBlocksRequest, opts ...grpc.CallOption) (*GenerateBlocksResponse, error)
+	GetSupplyInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SupplyInfoResponse, error)
+	GetSyncInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncInfoResponse, error)
+	WalletBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	WalletBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error)
+	ListAddressTransactions(ctx context.Context, in *ListAddressTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error)
+	ListAddressesTransactions(ctx context.Context, in *AddressesTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error)
+	AccountNonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceResponse, error)
+	CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UpdateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteTransaction(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	Consensus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	Handshake(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HandshakeResponse, error)
+}
+
+type blockChainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockChainServiceClient(cc grpc.ClientConnInterface) BlockChainServiceClient {
+	return &blockChainServiceClient{cc}
+}
+
+func (c *blockChainServiceClient) ListTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetBlockChain(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetBlockChainResponse, error) {
+	out := new(GetBlockChainResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetBlockChain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetBlockByHeight(ctx context.Context, in *BlockHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetBlockByHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetBlockByHash(ctx context.Context, in *BlockHashRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetBlockByHash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetChainTip(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChainTipResponse, error) {
+	out := new(ChainTipResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetChainTip_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error) {
+	out := new(ListBlocksResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListBlocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) SubscribeBlocks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (BlockChainService_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockChainService_ServiceDesc.Streams[0], BlockChainService_SubscribeBlocks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockChainServiceSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockChainService_SubscribeBlocksClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type blockChainServiceSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockChainServiceSubscribeBlocksClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockChainServiceClient) SubscribeMempool(ctx context.Context, in *Empty, opts ...grpc.CallOption) (BlockChainService_SubscribeMempoolClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockChainService_ServiceDesc.Streams[1], BlockChainService_SubscribeMempool_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockChainServiceSubscribeMempoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockChainService_SubscribeMempoolClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type blockChainServiceSubscribeMempoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockChainServiceSubscribeMempoolClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockChainServiceClient) SubscribeAddress(ctx context.Context, in *SubscribeAddressRequest, opts ...grpc.CallOption) (BlockChainService_SubscribeAddressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockChainService_ServiceDesc.Streams[2], BlockChainService_SubscribeAddress_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockChainServiceSubscribeAddressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockChainService_SubscribeAddressClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type blockChainServiceSubscribeAddressClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockChainServiceSubscribeAddressClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockChainServiceClient) RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_RegisterWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) DeleteWebhook(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) ListWebhookDeliveries(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) StartMining(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error) {
+	out := new(MiningStatus)
+	err := c.cc.Invoke(ctx, BlockChainService_StartMining_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) StopMining(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error) {
+	out := new(MiningStatus)
+	err := c.cc.Invoke(ctx, BlockChainService_StopMining_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) SetMiningWorkers(ctx context.Context, in *MiningWorkersRequest, opts ...grpc.CallOption) (*MiningStatus, error) {
+	out := new(MiningStatus)
+	err := c.cc.Invoke(ctx, BlockChainService_SetMiningWorkers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) SetCoinbaseAddress(ctx context.Context, in *CoinbaseAddressRequest, opts ...grpc.CallOption) (*MiningStatus, error) {
+	out := new(MiningStatus)
+	err := c.cc.Invoke(ctx, BlockChainService_SetCoinbaseAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetMiningStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MiningStatus, error) {
+	out := new(MiningStatus)
+	err := c.cc.Invoke(ctx, BlockChainService_GetMiningStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetBlockTemplate(ctx context.Context, in *BlockTemplateRequest, opts ...grpc.CallOption) (*BlockTemplate, error) {
+	out := new(BlockTemplate)
+	err := c.cc.Invoke(ctx, BlockChainService_GetBlockTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) SubmitBlock(ctx context.Context, in *SubmitBlockRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_SubmitBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GenerateBlocks(ctx context.Context, in *GenerateBlocksRequest, opts ...grpc.CallOption) (*GenerateBlocksResponse, error) {
+	out := new(GenerateBlocksResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GenerateBlocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetSupplyInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SupplyInfoResponse, error) {
+	out := new(SupplyInfoResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetSupplyInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) GetSyncInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncInfoResponse, error) {
+	out := new(SyncInfoResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_GetSyncInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) WalletBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_WalletBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) WalletBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error) {
+	out := new(BalancesResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_WalletBalances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) ListAddressTransactions(ctx context.Context, in *ListAddressTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error) {
+	out := new(ListAddressTransactionsResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListAddressTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) ListAddressesTransactions(ctx context.Context, in *AddressesTransactionsRequest, opts ...grpc.CallOption) (*ListAddressTransactionsResponse, error) {
+	out := new(ListAddressTransactionsResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_ListAddressesTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) AccountNonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceResponse, error) {
+	out := new(NonceResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_AccountNonce_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_CreateTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) UpdateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_UpdateTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) DeleteTransaction(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_DeleteTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) Consensus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_Consensus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockChainServiceClient) Handshake(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HandshakeResponse, error) {
+	out := new(HandshakeResponse)
+	err := c.cc.Invoke(ctx, BlockChainService_Handshake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockChainServiceServer is the server API for BlockChainService service.
+// All implementations must embed UnimplementedBlockChainServiceServer
+// for forward compatibility
+type BlockChainServiceServer interface {
+	ListTransactions(context.Context, *Empty) (*ListTransactionsResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	GetBlockChain(context.Context, *Empty) (*GetBlockChainResponse, error)
+	GetBlockByHeight(context.Context, *BlockHeightRequest) (*BlockResponse, error)
+	GetBlockByHash(context.Context, *BlockHashRequest) (*BlockResponse, error)
+	GetChainTip(context.Context, *Empty) (*ChainTipResponse, error)
+	ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error)
+	// streaming calls are served over Server-Sent Events by the gateway under /v1/events
+	SubscribeBlocks(*Empty, BlockChainService_SubscribeBlocksServer) error
+	SubscribeMempool(*Empty, BlockChainService_SubscribeMempoolServer) error
+	SubscribeAddress(*SubscribeAddressRequest, BlockChainService_SubscribeAddressServer) error
+	RegisterWebhook(context.Context, *RegisterWebhookRequest) (*WebhookResponse, error)
+	ListWebhooks(context.Context, *Empty) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *WebhookIdRequest) (*StatusResponse, error)
+	ListWebhookDeliveries(context.Context, *WebhookIdRequest) (*ListWebhookDeliveriesResponse, error)
+	// mining control is only served over gRPC and requires the node's admin token
+	StartMining(context.Context, *Empty) (*MiningStatus, error)
+	StopMining(context.Context, *Empty) (*MiningStatus, error)
+	SetMiningWorkers(context.Context, *MiningWorkersRequest) (*MiningStatus, error)
+	SetCoinbaseAddress(context.Context, *CoinbaseAddressRequest) (*MiningStatus, error)
+	GetMiningStatus(context.Context, *Empty) (*MiningStatus, error)
+	GetBlockTemplate(context.Context, *BlockTemplateRequest) (*BlockTemplate, error)
+	SubmitBlock(context.Context, *SubmitBlockRequest) (*BlockResponse, error)
+	GenerateBlocks(context.Context, *GenerateBlocksRequest) (*GenerateBlocksResponse, error)
+	GetSupplyInfo(context.Context, *Empty) (*SupplyInfoResponse, error)
+	GetSyncInfo(context.Context, *Empty) (*SyncInfoResponse, error)
+	WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	WalletBalances(context.Context, *BalancesRequest) (*BalancesResponse, error)
+	ListAddressTransactions(context.Context, *ListAddressTransactionsRequest) (*ListAddressTransactionsResponse, error)
+	ListAddressesTransactions(context.Context, *AddressesTransactionsRequest) (*ListAddressTransactionsResponse, error)
+	AccountNonce(context.Context, *NonceRequest) (*NonceResponse, error)
+	CreateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error)
+	UpdateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error)
+	DeleteTransaction(context.Context, *Empty) (*StatusResponse, error)
+	Consensus(context.Context, *Empty) (*StatusResponse, error)
+	Handshake(context.Context, *Empty) (*HandshakeResponse, error)
+	mustEmbedUnimplementedBlockChainServiceServer()
+}
+
+// UnimplementedBlockChainServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBlockChainServiceServer struct {
+}
+
+func (UnimplementedBlockChainServiceServer) ListTransactions(context.Context, *Empty) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetBlockChain(context.Context, *Empty) (*GetBlockChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockChain not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetBlockByHeight(context.Context, *BlockHeightRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetBlockByHash(context.Context, *BlockHashRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetChainTip(context.Context, *Empty) (*ChainTipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainTip not implemented")
+}
+func (UnimplementedBlockChainServiceServer) ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocks not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SubscribeBlocks(*Empty, BlockChainService_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SubscribeMempool(*Empty, BlockChainService_SubscribeMempoolServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMempool not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SubscribeAddress(*SubscribeAddressRequest, BlockChainService_SubscribeAddressServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAddress not implemented")
+}
+func (UnimplementedBlockChainServiceServer) RegisterWebhook(context.Context, *RegisterWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedBlockChainServiceServer) ListWebhooks(context.Context, *Empty) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedBlockChainServiceServer) DeleteWebhook(context.Context, *WebhookIdRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedBlockChainServiceServer) ListWebhookDeliveries(context.Context, *WebhookIdRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedBlockChainServiceServer) StartMining(context.Context, *Empty) (*MiningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMining not implemented")
+}
+func (UnimplementedBlockChainServiceServer) StopMining(context.Context, *Empty) (*MiningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMining not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SetMiningWorkers(context.Context, *MiningWorkersRequest) (*MiningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMiningWorkers not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SetCoinbaseAddress(context.Context, *CoinbaseAddressRequest) (*MiningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCoinbaseAddress not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetMiningStatus(context.Context, *Empty) (*MiningStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMiningStatus not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetBlockTemplate(context.Context, *BlockTemplateRequest) (*BlockTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockTemplate not implemented")
+}
+func (UnimplementedBlockChainServiceServer) SubmitBlock(context.Context, *SubmitBlockRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitBlock not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GenerateBlocks(context.Context, *GenerateBlocksRequest) (*GenerateBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateBlocks not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetSupplyInfo(context.Context, *Empty) (*SupplyInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplyInfo not implemented")
+}
+func (UnimplementedBlockChainServiceServer) GetSyncInfo(context.Context, *Empty) (*SyncInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncInfo not implemented")
+}
+func (UnimplementedBlockChainServiceServer) WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletBalance not implemented")
+}
+func (UnimplementedBlockChainServiceServer) WalletBalances(context.Context, *BalancesRequest) (*BalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletBalances not implemented")
+}
+func (UnimplementedBlockChainServiceServer) ListAddressTransactions(context.Context, *ListAddressTransactionsRequest) (*ListAddressTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressTransactions not implemented")
+}
+func (UnimplementedBlockChainServiceServer) ListAddressesTransactions(context.Context, *AddressesTransactionsRequest) (*ListAddressTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressesTransactions not implemented")
+}
+func (UnimplementedBlockChainServiceServer) AccountNonce(context.Context, *NonceRequest) (*NonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNonce not implemented")
+}
+func (UnimplementedBlockChainServiceServer) CreateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+}
+func (UnimplementedBlockChainServiceServer) UpdateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTransaction not implemented")
+}
+func (UnimplementedBlockChainServiceServer) DeleteTransaction(context.Context, *Empty) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTransaction not implemented")
+}
+func (UnimplementedBlockChainServiceServer) Consensus(context.Context, *Empty) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Consensus not implemented")
+}
+func (UnimplementedBlockChainServiceServer) Handshake(context.Context, *Empty) (*HandshakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handshake not implemented")
+}
+func (UnimplementedBlockChainServiceServer) mustEmbedUnimplementedBlockChainServiceServer() {}
+
+// UnsafeBlockChainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockChainServiceServer will
+// result in compilation errors.
+type UnsafeBlockChainServiceServer interface {
+	mustEmbedUnimplementedBlockChainServiceServer()
+}
+
+func RegisterBlockChainServiceServer(s grpc.ServiceRegistrar, srv BlockChainServiceServer) {
+	s.RegisterService(&BlockChainService_ServiceDesc, srv)
+}
+
+func _BlockChainService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).ListTransactions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetBlockChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetBlockChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetBlockChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetBlockChain(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetBlockByHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetBlockByHeight(ctx, req.(*BlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetBlockByHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetBlockByHash(ctx, req.(*BlockHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetChainTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetChainTip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetChainTip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetChainTip(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_ListBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).ListBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_ListBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).ListBlocks(ctx, req.(*ListBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockChainServiceServer).SubscribeBlocks(m, &blockChainServiceSubscribeBlocksServer{stream})
+}
+
+type BlockChainService_SubscribeBlocksServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type blockChainServiceSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockChainServiceSubscribeBlocksServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockChainService_SubscribeMempool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockChainServiceServer).SubscribeMempool(m, &blockChainServiceSubscribeMempoolServer{stream})
+}
+
+type BlockChainService_SubscribeMempoolServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type blockChainServiceSubscribeMempoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockChainServiceSubscribeMempoolServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockChainService_SubscribeAddress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAddressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockChainServiceServer).SubscribeAddress(m, &blockChainServiceSubscribeAddressServer{stream})
+}
+
+type BlockChainService_SubscribeAddressServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type blockChainServiceSubscribeAddressServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockChainServiceSubscribeAddressServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockChainService_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_RegisterWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).RegisterWebhook(ctx, req.(*RegisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).ListWebhooks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).DeleteWebhook(ctx, req.(*WebhookIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).ListWebhookDeliveries(ctx, req.(*WebhookIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_StartMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).StartMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_StartMining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).StartMining(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_StopMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).StopMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_StopMining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).StopMining(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_SetMiningWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MiningWorkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).SetMiningWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_SetMiningWorkers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).SetMiningWorkers(ctx, req.(*MiningWorkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_SetCoinbaseAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinbaseAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).SetCoinbaseAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_SetCoinbaseAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).SetCoinbaseAddress(ctx, req.(*CoinbaseAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetMiningStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetMiningStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetMiningStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetMiningStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetBlockTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetBlockTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetBlockTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetBlockTemplate(ctx, req.(*BlockTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_SubmitBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).SubmitBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_SubmitBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).SubmitBlock(ctx, req.(*SubmitBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GenerateBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GenerateBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GenerateBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GenerateBlocks(ctx, req.(*GenerateBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetSupplyInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).GetSupplyInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_GetSupplyInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).GetSupplyInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockChainService_GetSyncInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateWallet(ctx, in)
+		return srv.(BlockChainServiceServer).GetSyncInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WalletService_CreateWallet_FullMethodName,
+		FullMethod: BlockChainService_GetSyncInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateWallet(ctx, req.(*Empty))
+		return srv.(BlockChainServiceServer).GetSyncInfo(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _BlockChainService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BalanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).WalletBalance(ctx, in)
+		return srv.(BlockChainServiceServer).WalletBalance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WalletService_WalletBalance_FullMethodName,
+		FullMethod: BlockChainService_WalletBalance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).WalletBalance(ctx, req.(*BalanceRequest))
+		return srv.(BlockChainServiceServer).WalletBalance(ctx, req.(*BalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var WalletService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "WalletService",
-	HandlerType: (*WalletServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateTransaction",
-			Handler:    _WalletService_CreateTransaction_Handler,
-		},
-		{
-			MethodName: "CreateWallet",
-			Handler:    _WalletService_CreateWallet_Handler,
-		},
-		{
-			MethodName: "WalletBalance",
-			Handler:    _WalletService_WalletBalance_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "service.proto",
-}
-
-const (
-	BlockChainService_ListTransactions_FullMethodName  = "/BlockChainService/ListTransactions"
-	BlockChainService_GetBlockChain_FullMethodName     = "/BlockChainService/GetBlockChain"
-	BlockChainService_WalletBalance_FullMethodName     = "/BlockChainService/WalletBalance"
-	BlockChainService_CreateTransaction_FullMethodName = "/BlockChainService/CreateTransaction"
-	BlockChainService_UpdateTransaction_FullMethodName = "/BlockChainService/UpdateTransaction"
-	BlockChainService_DeleteTransaction_FullMethodName = "/BlockChainService/DeleteTransaction"
-	BlockChainService_Consensus_FullMethodName         = "/BlockChainService/Consensus"
-)
-
-// BlockChainServiceClient is the client API for BlockChainService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type BlockChainServiceClient interface {
-	ListTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
-	GetBlockChain(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetBlockChainResponse, error)
-	WalletBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
-	CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
-	UpdateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
-	DeleteTransaction(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
-	Consensus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
-}
-
-type blockChainServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewBlockChainServiceClient(cc grpc.ClientConnInterface) BlockChainServiceClient {
-	return &blockChainServiceClient{cc}
-}
-
-func (c *blockChainServiceClient) ListTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
-	out := new(ListTransactionsResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_ListTransactions_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) GetBlockChain(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetBlockChainResponse, error) {
-	out := new(GetBlockChainResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_GetBlockChain_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) WalletBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
-	out := new(BalanceResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_WalletBalance_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_CreateTransaction_FullMethodName, in, out, opts...)
-	if err != nil {
+func _BlockChainService_WalletBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalancesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) UpdateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_UpdateTransaction_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).WalletBalances(ctx, in)
 	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) DeleteTransaction(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_DeleteTransaction_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_WalletBalances_FullMethodName,
 	}
-	return out, nil
-}
-
-func (c *blockChainServiceClient) Consensus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, BlockChainService_Consensus_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).WalletBalances(ctx, req.(*BalancesRequest))
 	}
-	return out, nil
-}
-
-// BlockChainServiceServer is the server API for BlockChainService service.
-// All implementations must embed UnimplementedBlockChainServiceServer
-// for forward compatibility
-type BlockChainServiceServer interface {
-	ListTransactions(context.Context, *Empty) (*ListTransactionsResponse, error)
-	GetBlockChain(context.Context, *Empty) (*GetBlockChainResponse, error)
-	WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
-	CreateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error)
-	UpdateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error)
-	DeleteTransaction(context.Context, *Empty) (*StatusResponse, error)
-	Consensus(context.Context, *Empty) (*StatusResponse, error)
-	mustEmbedUnimplementedBlockChainServiceServer()
-}
-
-// UnimplementedBlockChainServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedBlockChainServiceServer struct {
-}
-
-func (UnimplementedBlockChainServiceServer) ListTransactions(context.Context, *Empty) (*ListTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
-}
-func (UnimplementedBlockChainServiceServer) GetBlockChain(context.Context, *Empty) (*GetBlockChainResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBlockChain not implemented")
-}
-func (UnimplementedBlockChainServiceServer) WalletBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WalletBalance not implemented")
-}
-func (UnimplementedBlockChainServiceServer) CreateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
-}
-func (UnimplementedBlockChainServiceServer) UpdateTransaction(context.Context, *TransactionRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTransaction not implemented")
-}
-func (UnimplementedBlockChainServiceServer) DeleteTransaction(context.Context, *Empty) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTransaction not implemented")
-}
-func (UnimplementedBlockChainServiceServer) Consensus(context.Context, *Empty) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Consensus not implemented")
-}
-func (UnimplementedBlockChainServiceServer) mustEmbedUnimplementedBlockChainServiceServer() {}
-
-// UnsafeBlockChainServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BlockChainServiceServer will
-// result in compilation errors.
-type UnsafeBlockChainServiceServer interface {
-	mustEmbedUnimplementedBlockChainServiceServer()
-}
-
-func RegisterBlockChainServiceServer(s grpc.ServiceRegistrar, srv BlockChainServiceServer) {
-	s.RegisterService(&BlockChainService_ServiceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockChainService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _BlockChainService_ListAddressTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressTransactionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockChainServiceServer).ListTransactions(ctx, in)
+		return srv.(BlockChainServiceServer).ListAddressTransactions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockChainService_ListTransactions_FullMethodName,
+		FullMethod: BlockChainService_ListAddressTransactions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockChainServiceServer).ListTransactions(ctx, req.(*Empty))
+		return srv.(BlockChainServiceServer).ListAddressTransactions(ctx, req.(*ListAddressTransactionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockChainService_GetBlockChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _BlockChainService_ListAddressesTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressesTransactionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockChainServiceServer).GetBlockChain(ctx, in)
+		return srv.(BlockChainServiceServer).ListAddressesTransactions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockChainService_GetBlockChain_FullMethodName,
+		FullMethod: BlockChainService_ListAddressesTransactions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockChainServiceServer).GetBlockChain(ctx, req.(*Empty))
+		return srv.(BlockChainServiceServer).ListAddressesTransactions(ctx, req.(*AddressesTransactionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockChainService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BalanceRequest)
+func _BlockChainService_AccountNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockChainServiceServer).WalletBalance(ctx, in)
+		return srv.(BlockChainServiceServer).AccountNonce(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockChainService_WalletBalance_FullMethodName,
+		FullMethod: BlockChainService_AccountNonce_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockChainServiceServer).WalletBalance(ctx, req.(*BalanceRequest))
+		return srv.(BlockChainServiceServer).AccountNonce(ctx, req.(*NonceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -454,6 +2658,24 @@ func _BlockChainService_Consensus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockChainService_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockChainServiceServer).Handshake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockChainService_Handshake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockChainServiceServer).Handshake(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockChainService_ServiceDesc is the grpc.ServiceDesc for BlockChainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -465,14 +2687,106 @@ var BlockChainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransactions",
 			Handler:    _BlockChainService_ListTransactions_Handler,
 		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _BlockChainService_GetTransaction_Handler,
+		},
 		{
 			MethodName: "GetBlockChain",
 			Handler:    _BlockChainService_GetBlockChain_Handler,
 		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _BlockChainService_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetBlockByHash",
+			Handler:    _BlockChainService_GetBlockByHash_Handler,
+		},
+		{
+			MethodName: "GetChainTip",
+			Handler:    _BlockChainService_GetChainTip_Handler,
+		},
+		{
+			MethodName: "ListBlocks",
+			Handler:    _BlockChainService_ListBlocks_Handler,
+		},
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _BlockChainService_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _BlockChainService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _BlockChainService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _BlockChainService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "StartMining",
+			Handler:    _BlockChainService_StartMining_Handler,
+		},
+		{
+			MethodName: "StopMining",
+			Handler:    _BlockChainService_StopMining_Handler,
+		},
+		{
+			MethodName: "SetMiningWorkers",
+			Handler:    _BlockChainService_SetMiningWorkers_Handler,
+		},
+		{
+			MethodName: "SetCoinbaseAddress",
+			Handler:    _BlockChainService_SetCoinbaseAddress_Handler,
+		},
+		{
+			MethodName: "GetMiningStatus",
+			Handler:    _BlockChainService_GetMiningStatus_Handler,
+		},
+		{
+			MethodName: "GetBlockTemplate",
+			Handler:    _BlockChainService_GetBlockTemplate_Handler,
+		},
+		{
+			MethodName: "SubmitBlock",
+			Handler:    _BlockChainService_SubmitBlock_Handler,
+		},
+		{
+			MethodName: "GenerateBlocks",
+			Handler:    _BlockChainService_GenerateBlocks_Handler,
+		},
+		{
+			MethodName: "GetSupplyInfo",
+			Handler:    _BlockChainService_GetSupplyInfo_Handler,
+		},
+		{
+			MethodName: "GetSyncInfo",
+			Handler:    _BlockChainService_GetSyncInfo_Handler,
+		},
 		{
 			MethodName: "WalletBalance",
 			Handler:    _BlockChainService_WalletBalance_Handler,
 		},
+		{
+			MethodName: "WalletBalances",
+			Handler:    _BlockChainService_WalletBalances_Handler,
+		},
+		{
+			MethodName: "ListAddressTransactions",
+			Handler:    _BlockChainService_ListAddressTransactions_Handler,
+		},
+		{
+			MethodName: "ListAddressesTransactions",
+			Handler:    _BlockChainService_ListAddressesTransactions_Handler,
+		},
+		{
+			MethodName: "AccountNonce",
+			Handler:    _BlockChainService_AccountNonce_Handler,
+		},
 		{
 			MethodName: "CreateTransaction",
 			Handler:    _BlockChainService_CreateTransaction_Handler,
@@ -489,7 +2803,27 @@ var BlockChainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Consensus",
 			Handler:    _BlockChainService_Consensus_Handler,
 		},
+		{
+			MethodName: "Handshake",
+			Handler:    _BlockChainService_Handshake_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _BlockChainService_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMempool",
+			Handler:       _BlockChainService_SubscribeMempool_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeAddress",
+			Handler:       _BlockChainService_SubscribeAddress_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
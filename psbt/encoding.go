@@ -0,0 +1,190 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/helpers"
+)
+
+// A packet is encoded as base64 (standard alphabet, padded) of:
+//
+//	magic      "zpst"
+//	version    1 byte, VERSION
+//	sender     uvarint length, bytes
+//	recipient  uvarint length, bytes
+//	value      float32 bits, big-endian
+//	fee        float32 bits, big-endian
+//	nonce      uint64, big-endian
+//	required   1 byte, 0 for a single-key sender
+//	key count  1 byte
+//	keys       64 bytes each, X||Y big-endian
+//	signatures one per key: 0x00, or 0x01 followed by R||S as 64 bytes big-endian
+//
+// Keys are in address.SortPublicKeys order, so a packet has exactly one encoding.
+const (
+	MAGIC       = "zpst"
+	VERSION     = 1
+	MAX_ADDRESS = 128
+)
+
+var ErrFormat = errors.New("psbt: malformed packet")
+
+// Encode returns the base64 encoding of p.
+func (p *Packet) Encode() string {
+	var b bytes.Buffer
+	b.WriteString(MAGIC)
+	b.WriteByte(VERSION)
+	writeString(&b, p.SenderBlockchainAddress)
+	writeString(&b, p.RecipientBlockchainAddress)
+	b.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(p.Value)))
+	b.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(p.Fee)))
+	b.Write(binary.BigEndian.AppendUint64(nil, p.Nonce))
+	b.WriteByte(byte(p.RequiredSignatures))
+	b.WriteByte(byte(len(p.PublicKeys)))
+	for _, pub := range p.PublicKeys {
+		b.Write(fixed(pub.X, pub.Y))
+	}
+	for _, s := range p.Signatures {
+		if s == nil {
+			b.WriteByte(0)
+			continue
+		}
+		b.WriteByte(1)
+		b.Write(fixed(s.R, s.S))
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+// Decode parses an encoded packet and checks its keys and signatures.
+func Decode(s string) (*Packet, error) {
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrFormat
+	}
+	r := bytes.NewReader(raw)
+
+	magic := make([]byte, len(MAGIC)+1)
+	if _, err := r.Read(magic); err != nil || string(magic[:len(MAGIC)]) != MAGIC {
+		return nil, ErrFormat
+	}
+	if magic[len(MAGIC)] != VERSION {
+		return nil, fmt.Errorf("psbt: unsupported version %d", magic[len(MAGIC)])
+	}
+
+	p := new(Packet)
+	var header struct {
+		Value, Fee uint32
+		Nonce      uint64
+		Required   uint8
+		Keys       uint8
+	}
+	if p.SenderBlockchainAddress, err = readString(r); err != nil {
+		return nil, err
+	}
+	if p.RecipientBlockchainAddress, err = readString(r); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, ErrFormat
+	}
+	p.Value = math.Float32frombits(header.Value)
+	p.Fee = math.Float32frombits(header.Fee)
+	p.Nonce = header.Nonce
+	p.RequiredSignatures = int(header.Required)
+
+	keys := make([]*ecdsa.PublicKey, header.Keys)
+	for i := range keys {
+		x, y, err := readPair(r)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("psbt: public key %d is not on the curve", i+1)
+		}
+		keys[i] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	}
+
+	if p.IsMultisig() {
+		sorted := append([]*ecdsa.PublicKey(nil), keys...)
+		address.SortPublicKeys(sorted)
+		for i := range keys {
+			if !keys[i].Equal(sorted[i]) {
+				return nil, ErrFormat
+			}
+		}
+		a, err := address.FromMultisig(p.RequiredSignatures, keys)
+		if err != nil {
+			return nil, err
+		}
+		if a.String() != p.SenderBlockchainAddress {
+			return nil, fmt.Errorf("psbt: keys do not belong to multisig address %s", p.SenderBlockchainAddress)
+		}
+		p.PublicKeys = keys
+		p.Signatures = make([]*helpers.Signature, len(keys))
+	} else if len(keys) > 1 {
+		return nil, ErrFormat
+	}
+
+	for _, pub := range keys {
+		present, err := r.ReadByte()
+		if err != nil || present > 1 {
+			return nil, ErrFormat
+		}
+		if present == 0 {
+			if !p.IsMultisig() { // a single-key packet only lists its key once signed
+				return nil, ErrFormat
+			}
+			continue
+		}
+		rr, ss, err := readPair(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.AddSignature(pub, &helpers.Signature{R: rr, S: ss}); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrFormat
+	}
+	return p, nil
+}
+
+func fixed(a, b *big.Int) []byte {
+	buf := make([]byte, 64)
+	a.FillBytes(buf[:32])
+	b.FillBytes(buf[32:])
+	return buf
+}
+
+func readPair(r *bytes.Reader) (*big.Int, *big.Int, error) {
+	buf := make([]byte, 64)
+	if n, _ := r.Read(buf); n != len(buf) {
+		return nil, nil, ErrFormat
+	}
+	return new(big.Int).SetBytes(buf[:32]), new(big.Int).SetBytes(buf[32:]), nil
+}
+
+func writeString(b *bytes.Buffer, s string) {
+	b.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	b.WriteString(s)
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > MAX_ADDRESS || int(n) > r.Len() {
+		return "", ErrFormat
+	}
+	buf := make([]byte, n)
+	r.Read(buf)
+	return string(buf), nil
+}
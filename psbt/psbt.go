@@ -0,0 +1,193 @@
+// Package psbt implements a portable container for an unsigned or partially signed
+// transaction, in the spirit of Bitcoin's PSBT. A packet carries the transaction
+// fields, the keys allowed to sign it and the signatures collected so far, so signers
+// can add theirs independently, offline if need be, before the packet is finalized
+// into a transaction the blockchain service accepts.
+package psbt
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+var (
+	ErrNotSigner  = errors.New("psbt: key may not sign this transaction")
+	ErrSignature  = errors.New("psbt: invalid signature")
+	ErrIncomplete = errors.New("psbt: not enough signatures")
+	ErrMismatch   = errors.New("psbt: packets are for different transactions")
+)
+
+// Packet is a transaction waiting for signatures. For a multisig sender PublicKeys
+// holds all N keys in address.SortPublicKeys order; for a single-key sender it is
+// empty until the key signs. Signatures[i] is PublicKeys[i]'s signature, or nil.
+type Packet struct {
+	SenderBlockchainAddress    string
+	RecipientBlockchainAddress string
+	Value                      float32
+	Fee                        float32
+	Nonce                      uint64
+	RequiredSignatures         int // 0 for a single-key sender
+	PublicKeys                 []*ecdsa.PublicKey
+	Signatures                 []*helpers.Signature
+}
+
+// New creates an unsigned packet for a transaction from a single-key address.
+func New(md *transaction.MetaData) *Packet {
+	return &Packet{
+		SenderBlockchainAddress:    md.SenderBlockchainAddress,
+		RecipientBlockchainAddress: md.RecipientBlockchainAddress,
+		Value:                      md.Value,
+		Fee:                        md.Fee,
+		Nonce:                      md.Nonce,
+	}
+}
+
+// NewMultisig creates an unsigned packet for a transaction from the required-of-
+// publicKeys multisig address md.SenderBlockchainAddress.
+func NewMultisig(md *transaction.MetaData, required int, publicKeys []*ecdsa.PublicKey) (*Packet, error) {
+	a, err := address.FromMultisig(required, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	if a.String() != md.SenderBlockchainAddress {
+		return nil, fmt.Errorf("psbt: keys do not belong to multisig address %s", md.SenderBlockchainAddress)
+	}
+
+	p := New(md)
+	p.RequiredSignatures = required
+	p.PublicKeys = append([]*ecdsa.PublicKey(nil), publicKeys...)
+	address.SortPublicKeys(p.PublicKeys)
+	p.Signatures = make([]*helpers.Signature, len(p.PublicKeys))
+	return p, nil
+}
+
+func (p *Packet) IsMultisig() bool {
+	return p.RequiredSignatures > 0
+}
+
+// Payload returns the canonical JSON every signer signs, the same as for a transaction
+// signed in one go.
+func (p *Packet) Payload() ([]byte, error) {
+	return transaction.NewMetaData(nil, nil, p.SenderBlockchainAddress, p.RecipientBlockchainAddress, p.Value, p.Fee, p.Nonce).MarshalJSON()
+}
+
+// Sign adds key's signature.
+func (p *Packet) Sign(key *ecdsa.PrivateKey) error {
+	md := transaction.NewMetaData(key, &key.PublicKey, p.SenderBlockchainAddress, p.RecipientBlockchainAddress, p.Value, p.Fee, p.Nonce)
+	s := md.GenerateSignature()
+	if s == nil {
+		return errors.New("psbt: failed to sign transaction")
+	}
+	return p.AddSignature(&key.PublicKey, s)
+}
+
+// AddSignature adds a signature made elsewhere after checking it, replacing any
+// earlier signature by the same key.
+func (p *Packet) AddSignature(publicKey *ecdsa.PublicKey, s *helpers.Signature) error {
+	i := p.keyIndex(publicKey)
+	if i < 0 && !p.IsMultisig() && address.BelongsTo(p.SenderBlockchainAddress, publicKey) {
+		p.PublicKeys = []*ecdsa.PublicKey{publicKey}
+		p.Signatures = []*helpers.Signature{nil}
+		i = 0
+	}
+	if i < 0 {
+		return ErrNotSigner
+	}
+
+	payload, err := p.Payload()
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(payload)
+	if !ecdsa.Verify(publicKey, hash[:], s.R, s.S) {
+		return ErrSignature
+	}
+	p.Signatures[i] = s
+	return nil
+}
+
+// Combine merges the signatures of other, a copy of the same packet signed
+// independently, into p.
+func (p *Packet) Combine(other *Packet) error {
+	if p.SenderBlockchainAddress != other.SenderBlockchainAddress ||
+		p.RecipientBlockchainAddress != other.RecipientBlockchainAddress ||
+		p.Value != other.Value ||
+		p.Fee != other.Fee ||
+		p.Nonce != other.Nonce ||
+		p.RequiredSignatures != other.RequiredSignatures {
+		return ErrMismatch
+	}
+	for i, s := range other.Signatures {
+		if s == nil {
+			continue
+		}
+		if err := p.AddSignature(other.PublicKeys[i], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignatureCount returns how many keys have signed.
+func (p *Packet) SignatureCount() int {
+	n := 0
+	for _, s := range p.Signatures {
+		if s != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Complete reports whether the packet has enough signatures to be finalized.
+func (p *Packet) Complete() bool {
+	if p.IsMultisig() {
+		return p.SignatureCount() >= p.RequiredSignatures
+	}
+	return p.SignatureCount() == 1
+}
+
+// Finalize turns a complete packet into the request the blockchain service's
+// CreateTransaction takes.
+func (p *Packet) Finalize() (transaction.Request, error) {
+	if !p.Complete() {
+		return transaction.Request{}, ErrIncomplete
+	}
+
+	r := transaction.Request{
+		SenderBlockchainAddress:    p.SenderBlockchainAddress,
+		RecipientBlockchainAddress: p.RecipientBlockchainAddress,
+		Value:                      p.Value,
+		Fee:                        p.Fee,
+		Nonce:                      p.Nonce,
+	}
+	if !p.IsMultisig() {
+		r.SenderPublicKey = fmt.Sprintf("%064x%064x", p.PublicKeys[0].X.Bytes(), p.PublicKeys[0].Y.Bytes())
+		r.Signature = p.Signatures[0].String()
+		return r, nil
+	}
+
+	ms := &transaction.Multisig{
+		RequiredSignatures: p.RequiredSignatures,
+		PublicKeys:         p.PublicKeys,
+		Signatures:         p.Signatures,
+	}
+	r.RequiredSignatures = uint32(p.RequiredSignatures)
+	r.PublicKeys, r.Signatures = ms.Encode()
+	return r, nil
+}
+
+func (p *Packet) keyIndex(publicKey *ecdsa.PublicKey) int {
+	for i, pub := range p.PublicKeys {
+		if pub.Equal(publicKey) {
+			return i
+		}
+	}
+	return -1
+}
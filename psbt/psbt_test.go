@@ -0,0 +1,181 @@
+package psbt
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+// multisigPacket returns an unsigned 2-of-3 packet and the signers' wallets.
+func multisigPacket(t *testing.T) (*Packet, []*wallet.Wallet) {
+	t.Helper()
+	signers := []*wallet.Wallet{wallet.New(), wallet.New(), wallet.New()}
+	keys := []*ecdsa.PublicKey{signers[0].PublicKey, signers[1].PublicKey, signers[2].PublicKey}
+	a, err := address.FromMultisig(2, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := transaction.NewMetaData(nil, nil, a.String(), wallet.New().BlockchainAddress, 10, 0.5, 3)
+	p, err := NewMultisig(md, 2, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, signers
+}
+
+// verify checks that r, the finalized request, carries enough valid signatures.
+func verify(t *testing.T, r transaction.Request) {
+	t.Helper()
+	pub, sig, ms, err := r.Authorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := transaction.NewMetaData(nil, nil, r.SenderBlockchainAddress, r.RecipientBlockchainAddress, r.Value, r.Fee, r.Nonce).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms != nil {
+		if valid := ms.ValidSignatures(payload); valid < ms.RequiredSignatures {
+			t.Fatalf("finalized request has %d of %d valid signatures", valid, ms.RequiredSignatures)
+		}
+		return
+	}
+	hash := sha256.Sum256(payload)
+	if pub == nil || !ecdsa.Verify(pub, hash[:], sig.R, sig.S) {
+		t.Fatal("finalized request has no valid signature")
+	}
+}
+
+func TestMultisigWorkflow(t *testing.T) {
+	p, signers := multisigPacket(t)
+	if _, err := p.Finalize(); !errors.Is(err, ErrIncomplete) {
+		t.Fatalf("finalizing an unsigned packet: %v, want %v", err, ErrIncomplete)
+	}
+
+	// Two signers each sign their own copy of the encoded packet.
+	encoded := p.Encode()
+	copies := make([]*Packet, 2)
+	for i := range copies {
+		c, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Sign(signers[i*2].PrivateKey); err != nil {
+			t.Fatal(err)
+		}
+		if c.Complete() {
+			t.Fatal("a 2-of-3 packet is complete with one signature")
+		}
+		copies[i] = c
+	}
+	if err := p.Sign(wallet.New().PrivateKey); !errors.Is(err, ErrNotSigner) {
+		t.Fatalf("signing with an outside key: %v, want %v", err, ErrNotSigner)
+	}
+
+	if err := copies[0].Combine(copies[1]); err != nil {
+		t.Fatal(err)
+	}
+	if got := copies[0].SignatureCount(); got != 2 || !copies[0].Complete() {
+		t.Fatalf("combined packet has %d signatures, want 2", got)
+	}
+	r, err := copies[0].Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify(t, r)
+
+	other, _ := multisigPacket(t)
+	if err := copies[0].Combine(other); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("combining packets for different transactions: %v, want %v", err, ErrMismatch)
+	}
+}
+
+func TestSingleKey(t *testing.T) {
+	w := wallet.New()
+	p := New(transaction.NewMetaData(nil, nil, w.BlockchainAddress, wallet.New().BlockchainAddress, 1, 0.1, 0))
+	if _, err := Decode(p.Encode()); err != nil {
+		t.Fatalf("decoding an unsigned single-key packet: %v", err)
+	}
+	if err := p.Sign(wallet.New().PrivateKey); !errors.Is(err, ErrNotSigner) {
+		t.Fatalf("signing with another address's key: %v, want %v", err, ErrNotSigner)
+	}
+	if err := p.Sign(w.PrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := Decode(p.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := decoded.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify(t, r)
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	p, signers := multisigPacket(t)
+	if err := p.Sign(signers[1].PrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := p.Encode()
+	decoded, err := Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Encode() != encoded {
+		t.Fatal("re-encoding a decoded packet changed it")
+	}
+	if decoded.SenderBlockchainAddress != p.SenderBlockchainAddress ||
+		decoded.RecipientBlockchainAddress != p.RecipientBlockchainAddress ||
+		decoded.Value != p.Value || decoded.Fee != p.Fee || decoded.Nonce != p.Nonce ||
+		decoded.RequiredSignatures != p.RequiredSignatures ||
+		decoded.SignatureCount() != 1 || decoded.Signatures[p.keyIndex(signers[1].PublicKey)] == nil {
+		t.Fatalf("decoded packet %+v differs from %+v", decoded, p)
+	}
+}
+
+func TestDecodeCorrupt(t *testing.T) {
+	// Sign with the last key in packet order so the packet ends with its signature.
+	p, signers := multisigPacket(t)
+	for _, w := range signers {
+		if w.PublicKey.Equal(p.PublicKeys[len(p.PublicKeys)-1]) {
+			if err := p.Sign(w.PrivateKey); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(p.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := func(edit func(b []byte) []byte) string {
+		b := edit(append([]byte(nil), raw...))
+		return base64.StdEncoding.EncodeToString(b)
+	}
+	for name, s := range map[string]string{
+		"not base64":     "!" + p.Encode(),
+		"bad magic":      corrupt(func(b []byte) []byte { b[0] = 'x'; return b }),
+		"bad version":    corrupt(func(b []byte) []byte { b[len(MAGIC)] = VERSION + 1; return b }),
+		"truncated":      corrupt(func(b []byte) []byte { return b[:len(b)-1] }),
+		"trailing bytes": corrupt(func(b []byte) []byte { return append(b, 0) }),
+		"bad signature":  corrupt(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }),
+		"changed value": corrupt(func(b []byte) []byte {
+			b[len(MAGIC)+1+1+len(p.SenderBlockchainAddress)+1+len(p.RecipientBlockchainAddress)] ^= 1
+			return b
+		}),
+	} {
+		if _, err := Decode(s); err == nil {
+			t.Errorf("%s: decoded a corrupt packet", name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ws *WalletServer) ValidateAddress(ctx context.Context, req *protogen.ValidateAddressRequest) (*protogen.ValidateAddressResponse, error) {
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+
+	a, err := ws.walletService.ValidateAddress(req.GetBlockchainAddress())
+	resp := &protogen.ValidateAddressResponse{
+		Valid:    err == nil,
+		Network:  a.Network.Name,
+		Multisig: a.Multisig,
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+// checkAddresses rejects the first of addresses that is not a valid address of this
+// node's network; empty addresses are left to the missing field checks.
+func checkAddresses(addresses ...string) error {
+	for _, a := range addresses {
+		if a == "" {
+			continue
+		}
+		if err := address.Validate(a); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%s: %v", a, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminMethods change how the node mines and where its block rewards go. They are not
+// routed on the HTTP gateway, and over gRPC they need the admin token.
+var adminMethods = map[string]bool{
+	protogen.BlockChainService_StartMining_FullMethodName:        true,
+	protogen.BlockChainService_StopMining_FullMethodName:         true,
+	protogen.BlockChainService_SetMiningWorkers_FullMethodName:   true,
+	protogen.BlockChainService_SetCoinbaseAddress_FullMethodName: true,
+}
+
+// adminAuth rejects calls to adminMethods that don't carry "authorization: Bearer <token>"
+// metadata. Without a token admin calls are refused altogether, since the gRPC port is
+// also the one neighbors connect to.
+func adminAuth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			return nil, status.Errorf(codes.PermissionDenied, "admin RPCs are disabled, start the node with an admin token")
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		got := md.Get("authorization")
+		if len(got) != 1 || subtle.ConstantTimeCompare([]byte(got[0]), []byte("Bearer "+token)) != 1 {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid admin token")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGatewayRejectsMiningControl(t *testing.T) {
+	bcs, _ := newTestServer(t)
+	handler, err := bcs.gatewayHandler(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := bcs.blockChainService.GetMiningStatus()
+
+	coinbase := wallet.New().BlockchainAddress
+	calls := map[string]string{
+		"/v1/mining/start":    `{}`,
+		"/v1/mining/stop":     `{}`,
+		"/v1/mining/workers":  `{"workers": 3}`,
+		"/v1/mining/coinbase": `{"blockchain_address": "` + coinbase + `"}`,
+	}
+	for path, body := range calls {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		if rec.Code == http.StatusOK {
+			t.Errorf("POST %s = %d, want it rejected", path, rec.Code)
+		}
+	}
+	if after := bcs.blockChainService.GetMiningStatus(); after.CoinbaseAddress != before.CoinbaseAddress || after.Workers != before.Workers || after.Running != before.Running {
+		t.Fatalf("mining status changed through the gateway: %+v, was %+v", after, before)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/mining/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/mining/status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	admin := &grpc.UnaryServerInfo{FullMethod: protogen.BlockChainService_SetCoinbaseAddress_FullMethodName}
+	public := &grpc.UnaryServerInfo{FullMethod: protogen.BlockChainService_GetMiningStatus_FullMethodName}
+
+	tests := []struct {
+		name  string
+		token string
+		ctx   context.Context
+		info  *grpc.UnaryServerInfo
+		want  codes.Code
+	}{
+		{"no token configured", "", withToken(""), admin, codes.PermissionDenied},
+		{"missing token", "secret", context.Background(), admin, codes.Unauthenticated},
+		{"wrong token", "secret", withToken("guess"), admin, codes.Unauthenticated},
+		{"correct token", "secret", withToken("secret"), admin, codes.OK},
+		{"public method", "secret", context.Background(), public, codes.OK},
+	}
+	for _, tt := range tests {
+		called = false
+		_, err := adminAuth(tt.token)(tt.ctx, nil, tt.info, handler)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, got, tt.want)
+		}
+		if called != (tt.want == codes.OK) {
+			t.Errorf("%s: handler called = %v", tt.name, called)
+		}
+	}
+}
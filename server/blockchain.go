@@ -3,14 +3,21 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/zde37/Zero-Chain/blockchain"
 	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/service"
 	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/watchonly"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// MAX_BATCH_ADDRESSES caps the addresses of one batch lookup at the size of the
+// largest watch group.
+const MAX_BATCH_ADDRESSES = watchonly.MAX_GROUP_ADDRESSES
+
 func (bcs *BlockChainServer) ListTransactions(ctx context.Context, req *protogen.Empty) (*protogen.ListTransactionsResponse, error) {
 	transactions, length := bcs.blockChainService.ListTransactions()
 
@@ -20,6 +27,26 @@ func (bcs *BlockChainServer) ListTransactions(ctx context.Context, req *protogen
 	}, nil
 }
 
+func (bcs *BlockChainServer) GetTransaction(ctx context.Context, req *protogen.GetTransactionRequest) (*protogen.GetTransactionResponse, error) {
+	record, err := bcs.blockChainService.GetTransaction(req.GetHash())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &protogen.GetTransactionResponse{
+		Status:        record.Status,
+		Confirmations: int64(record.Confirmations),
+	}
+	if record.Transaction != nil {
+		resp.Transaction = bcs.convertTransaction(record.Transaction)
+	}
+	if record.Block != nil {
+		resp.BlockHeight = int64(record.Block.Index)
+		resp.BlockHash = fmt.Sprintf("%x", record.Block.Hash)
+	}
+	return resp, nil
+}
+
 func (bcs *BlockChainServer) GetBlockChain(ctx context.Context, req *protogen.Empty) (*protogen.GetBlockChainResponse, error) {
 	blockchain := bcs.blockChainService.GetBlockChain()
 
@@ -28,6 +55,91 @@ func (bcs *BlockChainServer) GetBlockChain(ctx context.Context, req *protogen.Em
 	}, nil
 }
 
+func (bcs *BlockChainServer) GetBlockByHeight(ctx context.Context, req *protogen.BlockHeightRequest) (*protogen.BlockResponse, error) {
+	b := bcs.blockChainService.GetBlockByHeight(req.GetHeight())
+	if b == nil {
+		return nil, status.Errorf(codes.NotFound, "no block at height %d", req.GetHeight())
+	}
+
+	return &protogen.BlockResponse{
+		Block: bcs.convertBlock(b),
+	}, nil
+}
+
+func (bcs *BlockChainServer) GetBlockByHash(ctx context.Context, req *protogen.BlockHashRequest) (*protogen.BlockResponse, error) {
+	b, err := bcs.blockChainService.GetBlockByHash(req.GetHash())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if b == nil {
+		return nil, status.Errorf(codes.NotFound, "no block with hash %s", req.GetHash())
+	}
+
+	return &protogen.BlockResponse{
+		Block: bcs.convertBlock(b),
+	}, nil
+}
+
+func (bcs *BlockChainServer) GetChainTip(ctx context.Context, req *protogen.Empty) (*protogen.ChainTipResponse, error) {
+	b := bcs.blockChainService.GetChainTip()
+
+	return &protogen.ChainTipResponse{
+		Height:    int64(b.Index),
+		Hash:      fmt.Sprintf("%x", b.Hash),
+		Timestamp: b.TimeStamp,
+	}, nil
+}
+
+func (bcs *BlockChainServer) ListBlocks(ctx context.Context, req *protogen.ListBlocksRequest) (*protogen.ListBlocksResponse, error) {
+	blocks, more, err := bcs.blockChainService.ListBlocks(req.GetFrom(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &protogen.ListBlocksResponse{
+		Blocks:    bcs.convertBlockChain(blocks),
+		TipHeight: int64(bcs.blockChainService.GetChainTip().Index),
+		HasMore:   more,
+	}
+	if more {
+		resp.NextFrom = req.GetFrom() + int64(len(blocks))
+	}
+	return resp, nil
+}
+
+func (bcs *BlockChainServer) GetSupplyInfo(ctx context.Context, req *protogen.Empty) (*protogen.SupplyInfoResponse, error) {
+	s := bcs.blockChainService.GetSupplyInfo()
+
+	return &protogen.SupplyInfoResponse{
+		Height:            int64(s.Height),
+		CirculatingSupply: s.CirculatingSupply,
+		MaxSupply:         s.MaxSupply,
+		BlockSubsidy:      s.BlockSubsidy,
+		HalvingInterval:   int64(s.HalvingInterval),
+		NextHalvingHeight: int64(s.NextHalvingHeight),
+		CoinbaseMaturity:  int64(s.CoinbaseMaturity),
+	}, nil
+}
+
+func (bcs *BlockChainServer) GetSyncInfo(ctx context.Context, req *protogen.Empty) (*protogen.SyncInfoResponse, error) {
+	s := bcs.blockChainService.GetSyncInfo()
+
+	return &protogen.SyncInfoResponse{
+		Height:               int64(s.Height),
+		LastCheckpointHeight: int64(s.LastCheckpointHeight),
+		AssumeValid:          s.AssumeValid,
+		AssumeValidHeight:    int64(s.AssumeValidHeight),
+		ChainsValidated:      int64(s.Stats.ChainsValidated),
+		ChainsRejected:       int64(s.Stats.ChainsRejected),
+		BlocksValidated:      int64(s.Stats.BlocksValidated),
+		ProofsSkipped:        int64(s.Stats.ProofsSkipped),
+		ValidationSeconds:    s.Stats.Duration.Seconds(),
+		BlocksPerSecond:      s.Stats.BlocksPerSecond(),
+		LastBlocks:           int64(s.Stats.LastBlocks),
+		LastBlocksPerSecond:  s.Stats.LastBlocksPerSecond(),
+	}, nil
+}
+
 func (bcs *BlockChainServer) WalletBalance(ctx context.Context, req *protogen.BalanceRequest) (*protogen.BalanceResponse, error) {
 	if req.GetBlockchainAddress() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
@@ -39,18 +151,99 @@ func (bcs *BlockChainServer) WalletBalance(ctx context.Context, req *protogen.Ba
 	}, nil
 }
 
+func (bcs *BlockChainServer) ListAddressTransactions(ctx context.Context, req *protogen.ListAddressTransactionsRequest) (*protogen.ListAddressTransactionsResponse, error) {
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+	history, nextCursor, err := bcs.blockChainService.ListAddressTransactions(req.GetBlockchainAddress(),
+		req.GetCursor(), int(req.GetLimit()), req.GetDirection())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return &protogen.ListAddressTransactionsResponse{
+		Transactions: bcs.convertAddressHistory(req.GetBlockchainAddress(), history),
+		NextCursor:   nextCursor,
+	}, nil
+}
+
+// WalletBalances looks up the balances of many addresses in one call, for watch groups.
+func (bcs *BlockChainServer) WalletBalances(ctx context.Context, req *protogen.BalancesRequest) (*protogen.BalancesResponse, error) {
+	if len(req.GetBlockchainAddresses()) > MAX_BATCH_ADDRESSES {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d addresses can be queried at once", MAX_BATCH_ADDRESSES)
+	}
+
+	balances := make([]float32, 0, len(req.GetBlockchainAddresses()))
+	for _, a := range req.GetBlockchainAddresses() {
+		balances = append(balances, bcs.blockChainService.GetWalletBalance(a))
+	}
+	return &protogen.BalancesResponse{
+		Balances: balances,
+	}, nil
+}
+
+// ListAddressesTransactions returns the newest limit transactions of each of many
+// addresses in one call, address by address.
+func (bcs *BlockChainServer) ListAddressesTransactions(ctx context.Context, req *protogen.AddressesTransactionsRequest) (*protogen.ListAddressTransactionsResponse, error) {
+	if len(req.GetBlockchainAddresses()) > MAX_BATCH_ADDRESSES {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d addresses can be queried at once", MAX_BATCH_ADDRESSES)
+	}
+
+	transactions := make([]*protogen.AddressTransaction, 0)
+	for _, a := range req.GetBlockchainAddresses() {
+		history, _, err := bcs.blockChainService.ListAddressTransactions(a, "", int(req.GetLimit()), service.HISTORY_DESCENDING)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		transactions = append(transactions, bcs.convertAddressHistory(a, history)...)
+	}
+	return &protogen.ListAddressTransactionsResponse{
+		Transactions: transactions,
+	}, nil
+}
+
+func (bcs *BlockChainServer) convertAddressHistory(blockchainAddress string, history []*blockchain.AddressTx) []*protogen.AddressTransaction {
+	transactions := make([]*protogen.AddressTransaction, 0, len(history))
+	for _, h := range history {
+		tx := &protogen.AddressTransaction{
+			Transaction:       bcs.convertTransaction(h.Transaction),
+			Type:              "outgoing",
+			Amount:            h.Amount,
+			Balance:           h.Balance,
+			Status:            blockchain.TX_STATUS_PENDING,
+			BlockchainAddress: blockchainAddress,
+		}
+		if h.Incoming {
+			tx.Type = "incoming"
+		}
+		if h.Block != nil {
+			tx.Status = blockchain.TX_STATUS_CONFIRMED
+			tx.BlockHeight = int64(h.Block.Index)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions
+}
+
+func (bcs *BlockChainServer) AccountNonce(ctx context.Context, req *protogen.NonceRequest) (*protogen.NonceResponse, error) {
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+
+	return &protogen.NonceResponse{
+		Nonce: bcs.blockChainService.GetAccountNonce(req.GetBlockchainAddress()),
+	}, nil
+}
+
 func (bcs *BlockChainServer) CreateTransaction(ctx context.Context, req *protogen.TransactionRequest) (*protogen.StatusResponse, error) {
 	if !bcs.validateTransaction(req) {
 		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
 	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
 
-	if err := bcs.blockChainService.CreateTransaction(ctx, transaction.Request{
-		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
-		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
-		SenderPublicKey:            req.GetSenderPublicKey(),
-		Value:                      req.GetValue(),
-		Signature:                  req.GetSignature(),
-	}); err != nil {
+	if err := bcs.blockChainService.CreateTransaction(ctx, transactionRequest(req)); err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
@@ -64,14 +257,11 @@ func (bcs *BlockChainServer) UpdateTransaction(ctx context.Context, req *protoge
 	if !bcs.validateTransaction(req) {
 		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
 	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
 
-	if err := bcs.blockChainService.UpdateTransaction(transaction.Request{
-		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
-		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
-		SenderPublicKey:            req.GetSenderPublicKey(),
-		Value:                      req.GetValue(),
-		Signature:                  req.GetSignature(),
-	}); err != nil {
+	if err := bcs.blockChainService.UpdateTransaction(transactionRequest(req)); err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
@@ -98,42 +288,96 @@ func (bcs *BlockChainServer) Consensus(ctx context.Context, req *protogen.Empty)
 	}, nil
 }
 
+// Handshake tells a neighbor our clock and height; it is how nodes sample each other's
+// clocks for the network-adjusted time blocks are checked against.
+func (bcs *BlockChainServer) Handshake(ctx context.Context, req *protogen.Empty) (*protogen.HandshakeResponse, error) {
+	return &protogen.HandshakeResponse{
+		TimestampMs: time.Now().UnixMilli(),
+		Height:      int64(bcs.blockChainService.GetChainTip().Index),
+	}, nil
+}
+
 func (bcs *BlockChainServer) convertBlockChain(bc []*blockchain.Block) []*protogen.Block {
 	blockchain := make([]*protogen.Block, 0)
 	for _, b := range bc {
-		blockchain = append(blockchain, &protogen.Block{
-			Nonce:        int64(b.Nonce),
-			Index:        int64(b.Index),
-			PreviousHash: fmt.Sprintf("%x", b.PreviousHash),
-			Timestamp:    b.TimeStamp,
-			Hash:         fmt.Sprintf("%x", b.Hash),
-			Transactions: bcs.convertTransactions(b.Transactions),
-		})
+		blockchain = append(blockchain, bcs.convertBlock(b))
 	}
 	return blockchain
 }
 
+func (bcs *BlockChainServer) convertBlock(b *blockchain.Block) *protogen.Block {
+	return &protogen.Block{
+		Nonce:        int64(b.Nonce),
+		Index:        int64(b.Index),
+		PreviousHash: fmt.Sprintf("%x", b.PreviousHash),
+		Timestamp:    b.TimeStamp,
+		Hash:         fmt.Sprintf("%x", b.Hash),
+		Transactions: bcs.convertTransactions(b.Transactions),
+	}
+}
+
 func (bcs *BlockChainServer) convertTransactions(tx []*transaction.Transaction) []*protogen.Transaction {
 	transactions := make([]*protogen.Transaction, 0)
 	for _, t := range tx {
-		transactions = append(transactions, &protogen.Transaction{
-			SenderBlockchainAddress:    t.SenderBlockChainAddress,
-			RecipientBlockchainAddress: t.RecipientBlockChainAddress,
-			Value:                      t.Value,
-			Hash:                       fmt.Sprintf("%x", t.Hash),
-			Timestamp:                  t.TimeStamp,
-		})
+		transactions = append(transactions, bcs.convertTransaction(t))
 	}
 	return transactions
 }
 
+func (bcs *BlockChainServer) convertTransaction(t *transaction.Transaction) *protogen.Transaction {
+	r := t.Request()
+	return &protogen.Transaction{
+		SenderBlockchainAddress:    t.SenderBlockChainAddress,
+		RecipientBlockchainAddress: t.RecipientBlockChainAddress,
+		Value:                      t.Value,
+		Fee:                        t.Fee,
+		Nonce:                      t.Nonce,
+		Hash:                       fmt.Sprintf("%x", t.Hash),
+		Timestamp:                  t.TimeStamp,
+		SenderPublicKey:            r.SenderPublicKey,
+		Signature:                  r.Signature,
+		RequiredSignatures:         r.RequiredSignatures,
+		PublicKeys:                 r.PublicKeys,
+		Signatures:                 r.Signatures,
+	}
+}
+
 func (bcs *BlockChainServer) validateTransaction(tr *protogen.TransactionRequest) bool {
-	if tr.GetSignature() == "" ||
-		tr.GetSenderPublicKey() == "" ||
-		tr.GetSenderBlockchainAddress() == "" ||
+	return validSignedTransaction(tr)
+}
+
+func validSignedTransaction(tr *protogen.TransactionRequest) bool {
+	if tr.GetSenderBlockchainAddress() == "" ||
 		tr.GetRecipientBlockchainAddress() == "" ||
-		tr.GetValue() == 0 {
+		tr.GetValue() < 0 ||
+		tr.GetFee() < 0 {
+		return false
+	}
+	if len(tr.GetPublicKeys()) > 0 {
+		if !validMultisig(tr.GetRequiredSignatures(), tr.GetPublicKeys(), tr.GetSignatures()) {
+			return false
+		}
+	} else if len(tr.GetSignature()) != 128 || len(tr.GetSenderPublicKey()) != 128 {
+		return false
+	}
+	// a zero-value transaction is only valid as a cancellation sent back to the sender
+	if tr.GetValue() == 0 && tr.GetSenderBlockchainAddress() != tr.GetRecipientBlockchainAddress() {
 		return false
 	}
 	return true
 }
+
+func transactionRequest(req *protogen.TransactionRequest) transaction.Request {
+	return transaction.Request{
+		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
+		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
+		SenderPublicKey:            req.GetSenderPublicKey(),
+		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.GetNonce(),
+		Signature:                  req.GetSignature(),
+		RequiredSignatures:         req.GetRequiredSignatures(),
+		PublicKeys:                 req.GetPublicKeys(),
+		Signatures:                 req.GetSignatures(),
+	}
+}
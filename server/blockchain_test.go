@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/service"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestValidSignedTransaction(t *testing.T) {
+	key := strings.Repeat("ab", 64)
+	valid := func() *protogen.TransactionRequest {
+		return &protogen.TransactionRequest{
+			SenderBlockchainAddress:    "sender",
+			RecipientBlockchainAddress: "recipient",
+			SenderPublicKey:            key,
+			Signature:                  key,
+			Value:                      1,
+			Fee:                        0.1,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*protogen.TransactionRequest)
+		want   bool
+	}{
+		{"valid", func(*protogen.TransactionRequest) {}, true},
+		{"negative value", func(tr *protogen.TransactionRequest) { tr.Value = -1 }, false},
+		{"negative fee", func(tr *protogen.TransactionRequest) { tr.Fee = -0.1 }, false},
+		{"zero value", func(tr *protogen.TransactionRequest) { tr.Value = 0 }, false},
+		{"cancellation", func(tr *protogen.TransactionRequest) {
+			tr.Value = 0
+			tr.RecipientBlockchainAddress = tr.SenderBlockchainAddress
+		}, true},
+		{"short signature", func(tr *protogen.TransactionRequest) { tr.Signature = key[:64] }, false},
+	}
+	for _, tt := range tests {
+		tr := valid()
+		tt.modify(tr)
+		if got := validSignedTransaction(tr); got != tt.want {
+			t.Errorf("%s: validSignedTransaction = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// newTestServer returns a blockchain server over a fresh chain that mines on demand,
+// whose genesis block gives each wallet 100 coins.
+func newTestServer(t *testing.T, funded ...*wallet.Wallet) (*BlockChainServer, *blockchain.BlockChain) {
+	t.Helper()
+	p := chainparams.Mainnet
+	p.Name = "test"
+	p.GenesisHash = ""
+	p.Checkpoints = nil
+	p.Difficulty = 1
+	p.MineOnDemand = true
+	for _, w := range funded {
+		p.Genesis.Allocations = append(p.Genesis.Allocations, chainparams.Allocation{Address: w.BlockchainAddress, Value: 100})
+		p.Emission.GenesisAllocation += 100
+	}
+
+	bc := blockchain.New(wallet.New().BlockchainAddress, 0, blockchain.MINING_MODE_CONTINUOUS, p)
+	service.DB["blockchain"] = bc
+	t.Cleanup(func() { delete(service.DB, "blockchain") })
+	return &BlockChainServer{blockChainService: service.NewBlockChainServiceImpl(0, t.TempDir(), blockchain.MINING_MODE_CONTINUOUS, p)}, bc
+}
+
+func TestBatchAddressLookups(t *testing.T) {
+	alice, bob, carol := wallet.New(), wallet.New(), wallet.New()
+	bcs, bc := newTestServer(t, alice, bob)
+	md := transaction.NewMetaData(alice.PrivateKey, alice.PublicKey, alice.BlockchainAddress, bob.BlockchainAddress, 10, 1, 0)
+	if !bc.AddTransaction(alice.BlockchainAddress, bob.BlockchainAddress, 10, 1, 0, alice.PublicKey, md.GenerateSignature()) {
+		t.Fatal("transaction rejected")
+	}
+	if _, ok := bc.GenerateBlocks(context.Background(), 1, carol.BlockchainAddress); !ok {
+		t.Fatal("failed to generate a block")
+	}
+	addresses := []string{alice.BlockchainAddress, bob.BlockchainAddress, carol.BlockchainAddress}
+
+	balances, err := bcs.WalletBalances(context.Background(), &protogen.BalancesRequest{BlockchainAddresses: addresses})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, a := range addresses {
+		if got, want := balances.GetBalances()[i], bc.CalculateWalletBalance(a); got != want {
+			t.Errorf("balance %d = %v, want %v", i, got, want)
+		}
+	}
+
+	history, err := bcs.ListAddressesTransactions(context.Background(), &protogen.AddressesTransactionsRequest{
+		BlockchainAddresses: addresses,
+		Limit:               10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	perAddress := map[string]int{}
+	for _, tx := range history.GetTransactions() {
+		perAddress[tx.GetBlockchainAddress()]++
+	}
+	for _, a := range addresses {
+		if want := len(bc.AddressHistory(a)); perAddress[a] != want {
+			t.Errorf("%d transactions for %s, want %d", perAddress[a], a, want)
+		}
+	}
+
+	tooMany := make([]string, MAX_BATCH_ADDRESSES+1)
+	if _, err := bcs.WalletBalances(context.Background(), &protogen.BalancesRequest{BlockchainAddresses: tooMany}); err == nil {
+		t.Fatal("accepted more addresses than a watch group holds")
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (bcs *BlockChainServer) SubscribeBlocks(req *protogen.Empty, stream protogen.BlockChainService_SubscribeBlocksServer) error {
+	return bcs.streamEvents(stream.Context(), blockEvents, stream.Send)
+}
+
+func (bcs *BlockChainServer) SubscribeMempool(req *protogen.Empty, stream protogen.BlockChainService_SubscribeMempoolServer) error {
+	return bcs.streamEvents(stream.Context(), mempoolEvents, stream.Send)
+}
+
+func (bcs *BlockChainServer) SubscribeAddress(req *protogen.SubscribeAddressRequest, stream protogen.BlockChainService_SubscribeAddressServer) error {
+	if req.GetBlockchainAddress() == "" {
+		return status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+	addresses := append([]string{req.GetBlockchainAddress()}, req.GetBlockchainAddresses()...)
+	return bcs.streamEvents(stream.Context(), addressEvents(addresses...), stream.Send)
+}
+
+// streamEvents forwards the chain events accepted by filter to send until ctx is done.
+func (bcs *BlockChainServer) streamEvents(ctx context.Context, filter func(blockchain.Event) bool,
+	send func(*protogen.Event) error) error {
+	events, unsubscribe := bcs.blockChainService.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-events:
+			if !filter(e) {
+				continue
+			}
+			if err := send(bcs.convertEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// eventSourceHandler serves a subscription to browsers as Server-Sent Events, since
+// the in-process gateway cannot proxy streaming calls.
+func (bcs *BlockChainServer) eventSourceHandler(filter func(r *http.Request) (func(blockchain.Event) bool, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, err := filter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		serveEventStream(w, r, func(ctx context.Context, send func(*protogen.Event) error) error {
+			return bcs.streamEvents(ctx, f, send)
+		})
+	}
+}
+
+// serveEventStream writes the events produced by stream as Server-Sent Events until
+// the client goes away or stream fails.
+func serveEventStream(w http.ResponseWriter, r *http.Request, stream func(ctx context.Context, send func(*protogen.Event) error) error) {
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err := stream(r.Context(), func(e *protogen.Event) error {
+		data, err := marshaler.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.GetType(), data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil {
+		log.Printf("server: event stream closed: %v", err)
+	}
+}
+
+func (bcs *BlockChainServer) convertEvent(e blockchain.Event) *protogen.Event {
+	event := &protogen.Event{Type: e.Type}
+	if e.Block != nil {
+		event.Block = bcs.convertBlock(e.Block)
+	}
+	if e.Transaction != nil {
+		event.Transaction = bcs.convertTransaction(e.Transaction)
+	}
+	return event
+}
+
+func blockEvents(e blockchain.Event) bool {
+	return e.Type == blockchain.EVENT_BLOCK_CONNECTED || e.Type == blockchain.EVENT_BLOCK_DISCONNECTED
+}
+
+func mempoolEvents(e blockchain.Event) bool {
+	return e.Type == blockchain.EVENT_TX_ACCEPTED || e.Type == blockchain.EVENT_TX_EVICTED
+}
+
+func addressEvents(blockchainAddresses ...string) func(blockchain.Event) bool {
+	return func(e blockchain.Event) bool {
+		for _, a := range blockchainAddresses {
+			if e.Involves(a) {
+				return true
+			}
+		}
+		return false
+	}
+}
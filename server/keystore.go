@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zde37/Zero-Chain/keystore"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ws *WalletServer) CreateKeystoreWallet(ctx context.Context, req *protogen.KeystoreWalletRequest) (*protogen.KeystoreWalletResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and passphrase are required")
+	}
+
+	entry, err := ws.walletService.CreateKeystoreWallet(req.GetName(), req.GetPassphrase())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.KeystoreWalletResponse{
+		Wallet: convertKeystoreEntry(entry),
+	}, nil
+}
+
+func (ws *WalletServer) ListKeystoreWallets(ctx context.Context, req *protogen.Empty) (*protogen.ListKeystoreWalletsResponse, error) {
+	entries, err := ws.walletService.ListKeystoreWallets()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	wallets := make([]*protogen.KeystoreWallet, 0, len(entries))
+	for _, e := range entries {
+		wallets = append(wallets, convertKeystoreEntry(e))
+	}
+	return &protogen.ListKeystoreWalletsResponse{
+		Wallets: wallets,
+	}, nil
+}
+
+func (ws *WalletServer) UnlockKeystoreWallet(ctx context.Context, req *protogen.UnlockKeystoreWalletRequest) (*protogen.KeystoreWalletResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and passphrase are required")
+	}
+	if req.GetTimeoutSeconds() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "timeout must not be negative")
+	}
+
+	entry, err := ws.walletService.UnlockKeystoreWallet(req.GetName(), req.GetPassphrase(),
+		time.Duration(req.GetTimeoutSeconds())*time.Second)
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.KeystoreWalletResponse{
+		Wallet: convertKeystoreEntry(entry),
+	}, nil
+}
+
+func (ws *WalletServer) LockKeystoreWallet(ctx context.Context, req *protogen.KeystoreWalletRequest) (*protogen.StatusResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	ws.walletService.LockKeystoreWallet(req.GetName())
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func (ws *WalletServer) DeleteKeystoreWallet(ctx context.Context, req *protogen.KeystoreWalletRequest) (*protogen.StatusResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and passphrase are required")
+	}
+	if err := ws.walletService.DeleteKeystoreWallet(req.GetName(), req.GetPassphrase()); err != nil {
+		return nil, keystoreError(err)
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func keystoreError(err error) error {
+	switch {
+	case errors.Is(err, keystore.ErrNotFound):
+		return status.Errorf(codes.NotFound, err.Error())
+	case errors.Is(err, keystore.ErrExists):
+		return status.Errorf(codes.AlreadyExists, err.Error())
+	case errors.Is(err, keystore.ErrWrongPassphrase):
+		return status.Errorf(codes.PermissionDenied, err.Error())
+	case errors.Is(err, keystore.ErrNotHD):
+		return status.Errorf(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, keystore.ErrTampered):
+		return status.Errorf(codes.DataLoss, err.Error())
+	default:
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+}
+
+func convertKeystoreEntry(e keystore.Entry) *protogen.KeystoreWallet {
+	w := &protogen.KeystoreWallet{
+		Name:              e.Name,
+		BlockchainAddress: e.Address,
+		PublicKey:         e.PublicKey,
+		CreatedAt:         e.CreatedAt.Format(time.RFC3339),
+		Unlocked:          !e.UnlockedUntil.IsZero(),
+		Type:              e.Type,
+		Account:           e.Account,
+		Path:              e.Path,
+		NextIndex:         e.NextIndex,
+	}
+	if w.Unlocked {
+		w.UnlockedUntil = e.UnlockedUntil.Format(time.RFC3339)
+	}
+	return w
+}
+
+func (ws *WalletServer) CreateHDWallet(ctx context.Context, req *protogen.CreateHDWalletRequest) (*protogen.HDWalletResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and passphrase are required")
+	}
+
+	entry, mnemonic, err := ws.walletService.CreateHDWallet(ctx, req.GetName(), req.GetPassphrase(),
+		req.GetMnemonic(), req.GetMnemonicPassphrase(), req.GetAccount())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.HDWalletResponse{
+		Wallet:   convertKeystoreEntry(entry),
+		Mnemonic: mnemonic,
+	}, nil
+}
+
+func (ws *WalletServer) DeriveAddress(ctx context.Context, req *protogen.KeystoreWalletRequest) (*protogen.DeriveAddressResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	address, path, err := ws.walletService.DeriveAddress(req.GetName())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.DeriveAddressResponse{
+		BlockchainAddress: address,
+		Path:              path,
+	}, nil
+}
+
+func (ws *WalletServer) DiscoverAddresses(ctx context.Context, req *protogen.KeystoreWalletRequest) (*protogen.DiscoverAddressesResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	entry, used, err := ws.walletService.DiscoverAddresses(ctx, req.GetName())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.DiscoverAddressesResponse{
+		Wallet:        convertKeystoreEntry(entry),
+		UsedAddresses: used,
+	}, nil
+}
+
+func (ws *WalletServer) ImportWallet(ctx context.Context, req *protogen.ImportWalletRequest) (*protogen.KeystoreWalletResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" || req.GetPrivateKey() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name, passphrase and private key are required")
+	}
+
+	entry, err := ws.walletService.ImportKeystoreWallet(req.GetName(), req.GetPassphrase(), req.GetPrivateKey(), req.GetFormat())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.KeystoreWalletResponse{
+		Wallet: convertKeystoreEntry(entry),
+	}, nil
+}
+
+func (ws *WalletServer) ExportWallet(ctx context.Context, req *protogen.ExportWalletRequest) (*protogen.ExportWalletResponse, error) {
+	if req.GetName() == "" || req.GetPassphrase() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and passphrase are required")
+	}
+
+	key, entry, err := ws.walletService.ExportKeystoreWallet(req.GetName(), req.GetPassphrase(), req.GetFormat())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.ExportWalletResponse{
+		Wallet:     convertKeystoreEntry(entry),
+		PrivateKey: key,
+	}, nil
+}
+
+func (ws *WalletServer) ExportPublicKey(ctx context.Context, req *protogen.ExportPublicKeyRequest) (*protogen.ExportPublicKeyResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	publicKey, accountKey, entry, err := ws.walletService.ExportPublicKey(req.GetName(), req.GetFormat())
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return &protogen.ExportPublicKeyResponse{
+		Wallet:     convertKeystoreEntry(entry),
+		PublicKey:  publicKey,
+		AccountKey: accountKey,
+	}, nil
+}
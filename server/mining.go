@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/miner"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (bcs *BlockChainServer) StartMining(ctx context.Context, req *protogen.Empty) (*protogen.MiningStatus, error) {
+	if err := bcs.blockChainService.StartMining(); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return bcs.GetMiningStatus(ctx, req)
+}
+
+func (bcs *BlockChainServer) StopMining(ctx context.Context, req *protogen.Empty) (*protogen.MiningStatus, error) {
+	if err := bcs.blockChainService.StopMining(); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return bcs.GetMiningStatus(ctx, req)
+}
+
+func (bcs *BlockChainServer) SetMiningWorkers(ctx context.Context, req *protogen.MiningWorkersRequest) (*protogen.MiningStatus, error) {
+	if req.GetWorkers() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "workers must not be negative")
+	}
+	bcs.blockChainService.SetMiningWorkers(int(req.GetWorkers()))
+	return bcs.GetMiningStatus(ctx, &protogen.Empty{})
+}
+
+func (bcs *BlockChainServer) SetCoinbaseAddress(ctx context.Context, req *protogen.CoinbaseAddressRequest) (*protogen.MiningStatus, error) {
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+	if err := checkAddresses(req.GetBlockchainAddress()); err != nil {
+		return nil, err
+	}
+	if err := bcs.blockChainService.SetCoinbaseAddress(req.GetBlockchainAddress()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return bcs.GetMiningStatus(ctx, &protogen.Empty{})
+}
+
+func (bcs *BlockChainServer) GetMiningStatus(ctx context.Context, req *protogen.Empty) (*protogen.MiningStatus, error) {
+	s := bcs.blockChainService.GetMiningStatus()
+	return &protogen.MiningStatus{
+		Running:         s.Running,
+		Mode:            s.Mode,
+		Workers:         int32(s.Workers),
+		HashRate:        s.HashRate,
+		CoinbaseAddress: s.CoinbaseAddress,
+		TemplateHeight:  int64(s.TemplateHeight),
+		BlocksFound:     int64(s.BlocksFound),
+	}, nil
+}
+
+func (bcs *BlockChainServer) GetBlockTemplate(ctx context.Context, req *protogen.BlockTemplateRequest) (*protogen.BlockTemplate, error) {
+	if err := checkAddresses(req.GetCoinbaseAddress()); err != nil {
+		return nil, err
+	}
+	t, err := bcs.blockChainService.GetBlockTemplate(req.GetCoinbaseAddress())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &protogen.BlockTemplate{
+		Height:        int64(t.Height),
+		PreviousHash:  fmt.Sprintf("%x", t.PreviousHash),
+		Difficulty:    int32(t.Difficulty),
+		Target:        fmt.Sprintf("%x", miner.Target(t.Difficulty)),
+		Transactions:  bcs.convertTransactions(t.Transactions),
+		Coinbase:      bcs.convertTransaction(t.Coinbase),
+		CoinbaseValue: t.Coinbase.Value,
+		HeaderPrefix:  string(t.HeaderPrefix),
+		HeaderSuffix:  string(t.HeaderSuffix),
+		Timestamp:     t.Timestamp,
+	}, nil
+}
+
+func (bcs *BlockChainServer) GenerateBlocks(ctx context.Context, req *protogen.GenerateBlocksRequest) (*protogen.GenerateBlocksResponse, error) {
+	if req.GetBlocks() < 1 || req.GetBlocks() > blockchain.MAX_GENERATE_BLOCKS {
+		return nil, status.Errorf(codes.InvalidArgument, "blocks must be between 1 and %d", blockchain.MAX_GENERATE_BLOCKS)
+	}
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+	if err := checkAddresses(req.GetBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	blocks, err := bcs.blockChainService.GenerateBlocks(ctx, int(req.GetBlocks()), req.GetBlockchainAddress())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	hashes := make([]string, len(blocks))
+	for i, b := range blocks {
+		hashes[i] = fmt.Sprintf("%x", b.Hash)
+	}
+	return &protogen.GenerateBlocksResponse{
+		Hashes: hashes,
+		Height: int64(blocks[len(blocks)-1].Index),
+	}, nil
+}
+
+func (bcs *BlockChainServer) SubmitBlock(ctx context.Context, req *protogen.SubmitBlockRequest) (*protogen.BlockResponse, error) {
+	if req.GetPreviousHash() == "" || req.GetCoinbaseAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetCoinbaseAddress()); err != nil {
+		return nil, err
+	}
+
+	b, err := bcs.blockChainService.SubmitBlock(req.GetPreviousHash(), req.GetTimestamp(), req.GetCoinbaseAddress(), req.GetTransactionHashes(), req.GetNonce())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return &protogen.BlockResponse{
+		Block: bcs.convertBlock(b),
+	}, nil
+}
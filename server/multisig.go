@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ws *WalletServer) CreateMultisigAddress(ctx context.Context, req *protogen.CreateMultisigAddressRequest) (*protogen.MultisigAddressResponse, error) {
+	if req.GetRequiredSignatures() == 0 || len(req.GetPublicKeys()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+
+	blockchainAddress, publicKeys, err := ws.walletService.CreateMultisigAddress(int(req.GetRequiredSignatures()), req.GetPublicKeys())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return &protogen.MultisigAddressResponse{
+		BlockchainAddress:  blockchainAddress,
+		RequiredSignatures: req.GetRequiredSignatures(),
+		PublicKeys:         publicKeys,
+	}, nil
+}
+
+func (ws *WalletServer) SignMultisigTransaction(ctx context.Context, req *protogen.SignMultisigTransactionRequest) (*protogen.SignMultisigTransactionResponse, error) {
+	if req.GetSenderBlockchainAddress() == "" ||
+		req.GetRecipientBlockchainAddress() == "" ||
+		req.GetValue() == 0 ||
+		req.GetFee() < 0 ||
+		(req.GetWalletName() == "" && req.GetSignerPrivateKey() == "") {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress(), req.GetSignerBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	signature, md, err := ws.walletService.SignMultisigTransaction(ctx, wallet.TransactionRequest{
+		WalletName:                 req.GetWalletName(),
+		SenderPrivateKey:           req.GetSignerPrivateKey(),
+		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
+		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
+		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.Nonce,
+	}, req.GetSignerBlockchainAddress())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	payload, err := md.MarshalJSON()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build signing payload: %v", err)
+	}
+
+	return &protogen.SignMultisigTransactionResponse{
+		Signature: &protogen.PartialSignature{
+			PublicKey: signature.PublicKey,
+			Signature: signature.Signature,
+		},
+		Nonce:   md.Nonce,
+		Payload: string(payload),
+	}, nil
+}
+
+func (ws *WalletServer) CombineMultisigTransaction(ctx context.Context, req *protogen.CombineMultisigTransactionRequest) (*protogen.StatusResponse, error) {
+	if req.GetSenderBlockchainAddress() == "" ||
+		req.GetRecipientBlockchainAddress() == "" ||
+		req.GetValue() == 0 ||
+		req.GetFee() < 0 ||
+		!validMultisig(req.GetRequiredSignatures(), req.GetPublicKeys(), make([]string, len(req.GetPublicKeys()))) {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	signatures := make([]wallet.PartialSignature, 0, len(req.GetSignatures()))
+	for _, ps := range req.GetSignatures() {
+		if len(ps.GetPublicKey()) != 128 || len(ps.GetSignature()) != 128 {
+			return nil, status.Errorf(codes.InvalidArgument, "partial signatures need a 128 character public key and signature")
+		}
+		signatures = append(signatures, wallet.PartialSignature{
+			PublicKey: ps.GetPublicKey(),
+			Signature: ps.GetSignature(),
+		})
+	}
+
+	if err := ws.walletService.CombineMultisigTransaction(ctx, transaction.Request{
+		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
+		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
+		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.GetNonce(),
+		RequiredSignatures:         req.GetRequiredSignatures(),
+		PublicKeys:                 req.GetPublicKeys(),
+	}, signatures); err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+// validMultisig checks the shape of a multisig authorization: a threshold within the
+// key count and one 128 character key per, possibly empty, signature.
+func validMultisig(required uint32, publicKeys, signatures []string) bool {
+	if required == 0 ||
+		int(required) > len(publicKeys) ||
+		len(publicKeys) > address.MAX_MULTISIG_KEYS ||
+		len(signatures) != len(publicKeys) {
+		return false
+	}
+	for i := range publicKeys {
+		if len(publicKeys[i]) != 128 || (signatures[i] != "" && len(signatures[i]) != 128) {
+			return false
+		}
+	}
+	return true
+}
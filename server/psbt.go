@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/psbt"
+	"github.com/zde37/Zero-Chain/wallet"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ws *WalletServer) CreatePartialTransaction(ctx context.Context, req *protogen.CreatePartialTransactionRequest) (*protogen.PartialTransactionResponse, error) {
+	if req.GetSenderBlockchainAddress() == "" ||
+		req.GetRecipientBlockchainAddress() == "" ||
+		req.GetValue() == 0 ||
+		req.GetFee() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if len(req.GetPublicKeys()) > 0 && !validMultisig(req.GetRequiredSignatures(), req.GetPublicKeys(), make([]string, len(req.GetPublicKeys()))) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid multisig threshold or public keys")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	p, err := ws.walletService.CreatePartialTransaction(ctx, wallet.TransactionRequest{
+		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
+		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
+		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.Nonce,
+	}, int(req.GetRequiredSignatures()), req.GetPublicKeys())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return convertPacket(p)
+}
+
+func (ws *WalletServer) DecodePartialTransaction(ctx context.Context, req *protogen.PartialTransactionRequest) (*protogen.PartialTransactionResponse, error) {
+	p, err := decodePacket(req.GetPsbt())
+	if err != nil {
+		return nil, err
+	}
+	return convertPacket(p)
+}
+
+func (ws *WalletServer) SignPartialTransaction(ctx context.Context, req *protogen.SignPartialTransactionRequest) (*protogen.PartialTransactionResponse, error) {
+	if req.GetWalletName() == "" && req.GetSignerPrivateKey() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	p, err := decodePacket(req.GetPsbt())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ws.walletService.SignPartialTransaction(p, req.GetWalletName(), req.GetSignerBlockchainAddress(), req.GetSignerPrivateKey()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return convertPacket(p)
+}
+
+func (ws *WalletServer) CombinePartialTransactions(ctx context.Context, req *protogen.CombinePartialTransactionsRequest) (*protogen.PartialTransactionResponse, error) {
+	if len(req.GetPsbts()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+
+	var combined *psbt.Packet
+	for _, s := range req.GetPsbts() {
+		p, err := decodePacket(s)
+		if err != nil {
+			return nil, err
+		}
+		if combined == nil {
+			combined = p
+			continue
+		}
+		if err := combined.Combine(p); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+	return convertPacket(combined)
+}
+
+func (ws *WalletServer) FinalizePartialTransaction(ctx context.Context, req *protogen.PartialTransactionRequest) (*protogen.StatusResponse, error) {
+	p, err := decodePacket(req.GetPsbt())
+	if err != nil {
+		return nil, err
+	}
+	if !p.Complete() {
+		return nil, status.Errorf(codes.FailedPrecondition, psbt.ErrIncomplete.Error())
+	}
+
+	if err := ws.walletService.FinalizePartialTransaction(ctx, p); err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func decodePacket(s string) (*psbt.Packet, error) {
+	if s == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "psbt is required")
+	}
+	p, err := psbt.Decode(s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := checkAddresses(p.SenderBlockchainAddress, p.RecipientBlockchainAddress); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func convertPacket(p *psbt.Packet) (*protogen.PartialTransactionResponse, error) {
+	payload, err := p.Payload()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build signing payload: %v", err)
+	}
+
+	signatures := make([]*protogen.PartialSignature, len(p.PublicKeys))
+	for i, pub := range p.PublicKeys {
+		signatures[i] = &protogen.PartialSignature{
+			PublicKey: fmt.Sprintf("%064x%064x", pub.X.Bytes(), pub.Y.Bytes()),
+		}
+		if p.Signatures[i] != nil {
+			signatures[i].Signature = p.Signatures[i].String()
+		}
+	}
+	return &protogen.PartialTransactionResponse{
+		Psbt: p.Encode(),
+		Transaction: &protogen.PartialTransaction{
+			SenderBlockchainAddress:    p.SenderBlockchainAddress,
+			RecipientBlockchainAddress: p.RecipientBlockchainAddress,
+			Value:                      p.Value,
+			Fee:                        p.Fee,
+			Nonce:                      p.Nonce,
+			RequiredSignatures:         uint32(p.RequiredSignatures),
+			Signatures:                 signatures,
+			Complete:                   p.Complete(),
+			Payload:                    string(payload),
+		},
+	}, nil
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"html/template"
 	"log"
 	"net"
@@ -9,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/zde37/Zero-Chain/blockchain"
 	"github.com/zde37/Zero-Chain/config"
 	"github.com/zde37/Zero-Chain/protobuf/protogen"
 	"github.com/zde37/Zero-Chain/service"
@@ -46,7 +48,7 @@ func NewBlockChainServer(blockChainService service.BlockChainService, config con
 }
 
 func (bcs *BlockChainServer) RunGrpcServer() {
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(adminAuth(bcs.config.AdminToken)))
 	bcs.grpcServer = grpcServer
 
 	protogen.RegisterBlockChainServiceServer(grpcServer, bcs)
@@ -68,17 +70,34 @@ func (bcs *BlockChainServer) RunGrpcServer() {
 }
 
 func (bcs *BlockChainServer) RunGatewayServer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler, err := bcs.gatewayHandler(ctx)
+	if err != nil {
+		log.Printf("server: failed to register blockchain http handlers: %v", err)
+		return
+	}
+	httpServer := &http.Server{
+		Handler: handler,
+		Addr:    bcs.config.BlockChainGatewayServerAddr,
+	}
+
+	log.Printf("server: blockchain gateway server started on: %s", httpServer.Addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Printf("server: failed start blockchain gateway server: %v", err)
+		return
+	}
+}
+
+// gatewayHandler routes the blockchain service's HTTP API, event streams and pages.
+func (bcs *BlockChainServer) gatewayHandler(ctx context.Context) (http.Handler, error) {
 	jsonOption := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
 		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
 	})
 	grpcMux := runtime.NewServeMux(jsonOption)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	if err := protogen.RegisterBlockChainServiceHandlerServer(ctx, grpcMux, bcs); err != nil {
-		log.Printf("server: failed to register blockchain http handlers: %v", err)
-		return
+		return nil, err
 	}
 
 	mux := http.NewServeMux()
@@ -87,6 +106,19 @@ func (bcs *BlockChainServer) RunGatewayServer() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Hello World"))
 	}))
+	mux.Handle("/v1/events/blocks", bcs.eventSourceHandler(func(r *http.Request) (func(blockchain.Event) bool, error) {
+		return blockEvents, nil
+	}))
+	mux.Handle("/v1/events/mempool", bcs.eventSourceHandler(func(r *http.Request) (func(blockchain.Event) bool, error) {
+		return mempoolEvents, nil
+	}))
+	mux.Handle("/v1/events/address", bcs.eventSourceHandler(func(r *http.Request) (func(blockchain.Event) bool, error) {
+		addresses := r.URL.Query()["blockchain_address"]
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("blockchain address is required")
+		}
+		return addressEvents(addresses...), nil
+	}))
 	mux.Handle("/explorer", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { 
 		var (
 			err  error
@@ -117,16 +149,7 @@ func (bcs *BlockChainServer) RunGatewayServer() {
 		}
 		tpl.Execute(w, "")
 	}))
-	httpServer := &http.Server{
-		Handler: mux,
-		Addr:    bcs.config.BlockChainGatewayServerAddr,
-	}
-
-	log.Printf("server: blockchain gateway server started on: %s", httpServer.Addr)
-	if err := httpServer.ListenAndServe(); err != nil {
-		log.Printf("server: failed start blockchain gateway server: %v", err)
-		return
-	}
+	return mux, nil
 }
 
 func (s *BlockChainServer) StopGrpcServer() {
@@ -180,6 +203,7 @@ func (s *WalletServer) RunGatewayServer() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Hello World"))
 	}))
+	mux.Handle("/v1/watch/events", http.HandlerFunc(s.watchGroupEventsHandler))
 	mux.Handle("/index", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { 
 		var (
 			err  error
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context" 
+	"crypto/sha256"
+	"fmt"
 
 	"github.com/zde37/Zero-Chain/protobuf/protogen"
 	"github.com/zde37/Zero-Chain/wallet"
@@ -13,13 +15,19 @@ func (ws *WalletServer) CreateTransaction(ctx context.Context, req *protogen.Wal
 	if !ws.validateTransactionRequest(req) {
 		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
 	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
  
 	if err := ws.walletService.CreateTransaction(ctx, wallet.TransactionRequest{
+		WalletName:                 req.GetWalletName(),
 		SenderPrivateKey:           req.GetSenderPrivateKey(),
 		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
 		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
 		SenderPublicKey:            req.GetSenderPublicKey(),
 		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.Nonce,
 	}); err != nil { 
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
@@ -29,6 +37,99 @@ func (ws *WalletServer) CreateTransaction(ctx context.Context, req *protogen.Wal
 	}, nil
 }
 
+func (ws *WalletServer) PrepareTransaction(ctx context.Context, req *protogen.PrepareTransactionRequest) (*protogen.PrepareTransactionResponse, error) {
+	if req.GetSenderBlockchainAddress() == "" ||
+		req.GetRecipientBlockchainAddress() == "" ||
+		req.GetValue() <= 0 ||
+		req.GetFee() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	md, err := ws.walletService.PrepareTransaction(ctx, wallet.TransactionRequest{
+		SenderBlockchainAddress:    req.GetSenderBlockchainAddress(),
+		RecipientBlockchainAddress: req.GetRecipientBlockchainAddress(),
+		Value:                      req.GetValue(),
+		Fee:                        req.GetFee(),
+		Nonce:                      req.Nonce,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	payload, err := md.MarshalJSON()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build signing payload: %v", err)
+	}
+
+	return &protogen.PrepareTransactionResponse{
+		SenderBlockchainAddress:    md.SenderBlockchainAddress,
+		RecipientBlockchainAddress: md.RecipientBlockchainAddress,
+		Value:                      md.Value,
+		Fee:                        md.Fee,
+		Nonce:                      md.Nonce,
+		Payload:                    string(payload),
+		PayloadHash:                fmt.Sprintf("%x", sha256.Sum256(payload)),
+	}, nil
+}
+
+func (ws *WalletServer) SubmitSignedTransaction(ctx context.Context, req *protogen.TransactionRequest) (*protogen.StatusResponse, error) {
+	if !validSignedTransaction(req) {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress(), req.GetRecipientBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	if err := ws.walletService.SubmitSignedTransaction(ctx, transactionRequest(req)); err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func (ws *WalletServer) GetAddress(ctx context.Context, req *protogen.AddressRequest) (*protogen.AddressResponse, error) {
+	if len(req.GetPublicKey()) != 128 {
+		return nil, status.Errorf(codes.InvalidArgument, "public key must be 128 hex characters")
+	}
+
+	address, err := ws.walletService.GetAddress(req.GetPublicKey())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return &protogen.AddressResponse{
+		BlockchainAddress: address,
+	}, nil
+}
+
+func (ws *WalletServer) CancelTransaction(ctx context.Context, req *protogen.CancelTransactionRequest) (*protogen.StatusResponse, error) {
+	if !validSigner(req.GetWalletName(), req.GetSenderPrivateKey(), req.GetSenderPublicKey(), req.GetSenderBlockchainAddress()) ||
+		req.GetFee() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "failed due to missing fields")
+	}
+	if err := checkAddresses(req.GetSenderBlockchainAddress()); err != nil {
+		return nil, err
+	}
+
+	if err := ws.walletService.CancelTransaction(ctx, wallet.CancelRequest{
+		WalletName:              req.GetWalletName(),
+		SenderPrivateKey:        req.GetSenderPrivateKey(),
+		SenderBlockchainAddress: req.GetSenderBlockchainAddress(),
+		SenderPublicKey:         req.GetSenderPublicKey(),
+		Nonce:                   req.GetNonce(),
+		Fee:                     req.GetFee(),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
 func (ws *WalletServer) CreateWallet(ctx context.Context, req *protogen.Empty) (*protogen.CreateWalletResponse, error) {
 	wallet, err := ws.walletService.CreateWallet()
 	if err != nil {
@@ -57,13 +158,32 @@ func (ws *WalletServer) WalletBalance(ctx context.Context, req *protogen.Balance
 	}, nil
 }
 
+func (ws *WalletServer) ListAddressTransactions(ctx context.Context, req *protogen.ListAddressTransactionsRequest) (*protogen.ListAddressTransactionsResponse, error) {
+	if req.GetBlockchainAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "blockchain address is required")
+	}
+
+	resp, err := ws.walletService.ListAddressTransactions(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
 func (ws *WalletServer) validateTransactionRequest(req *protogen.WalletTransactionRequest) bool {
-	if req.GetSenderPrivateKey() == "" ||
-		req.GetSenderPublicKey() == "" ||
-		req.GetSenderBlockchainAddress() == "" ||
+	if !validSigner(req.GetWalletName(), req.GetSenderPrivateKey(), req.GetSenderPublicKey(), req.GetSenderBlockchainAddress()) ||
 		req.GetRecipientBlockchainAddress() == "" ||
-		req.GetValue() == 0 {
+		req.GetValue() <= 0 ||
+		req.GetFee() < 0 {
 		return false
 	}
 	return true
 }
+
+// validSigner accepts either a keystore wallet name or a full sender key pair and address.
+func validSigner(walletName, privateKey, publicKey, sender string) bool {
+	if walletName != "" {
+		return true
+	}
+	return privateKey != "" && len(publicKey) == 128 && sender != ""
+}
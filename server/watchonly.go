@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/watchonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ws *WalletServer) CreateWatchGroup(ctx context.Context, req *protogen.WatchGroupRequest) (*protogen.WatchGroupResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	g, err := ws.walletService.CreateWatchGroup(req.GetName(), req.GetBlockchainAddresses())
+	if err != nil {
+		return nil, watchGroupError(err, codes.InvalidArgument)
+	}
+	return &protogen.WatchGroupResponse{
+		Group: convertWatchGroup(g),
+	}, nil
+}
+
+func (ws *WalletServer) ListWatchGroups(ctx context.Context, req *protogen.Empty) (*protogen.ListWatchGroupsResponse, error) {
+	groups := ws.walletService.ListWatchGroups()
+
+	resp := &protogen.ListWatchGroupsResponse{
+		Groups: make([]*protogen.WatchGroup, 0, len(groups)),
+	}
+	for _, g := range groups {
+		resp.Groups = append(resp.Groups, convertWatchGroup(g))
+	}
+	return resp, nil
+}
+
+func (ws *WalletServer) AddWatchAddresses(ctx context.Context, req *protogen.WatchGroupRequest) (*protogen.WatchGroupResponse, error) {
+	if req.GetName() == "" || len(req.GetBlockchainAddresses()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name and blockchain addresses are required")
+	}
+
+	g, err := ws.walletService.AddWatchAddresses(req.GetName(), req.GetBlockchainAddresses())
+	if err != nil {
+		return nil, watchGroupError(err, codes.InvalidArgument)
+	}
+	return &protogen.WatchGroupResponse{
+		Group: convertWatchGroup(g),
+	}, nil
+}
+
+func (ws *WalletServer) RemoveWatchAddresses(ctx context.Context, req *protogen.WatchGroupRequest) (*protogen.WatchGroupResponse, error) {
+	if req.GetName() == "" || len(req.GetBlockchainAddresses()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name and blockchain addresses are required")
+	}
+
+	g, err := ws.walletService.RemoveWatchAddresses(req.GetName(), req.GetBlockchainAddresses())
+	if err != nil {
+		return nil, watchGroupError(err, codes.InvalidArgument)
+	}
+	return &protogen.WatchGroupResponse{
+		Group: convertWatchGroup(g),
+	}, nil
+}
+
+func (ws *WalletServer) DeleteWatchGroup(ctx context.Context, req *protogen.WatchGroupRequest) (*protogen.StatusResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if err := ws.walletService.DeleteWatchGroup(req.GetName()); err != nil {
+		return nil, watchGroupError(err, codes.InvalidArgument)
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func (ws *WalletServer) WatchGroupBalance(ctx context.Context, req *protogen.WatchGroupRequest) (*protogen.WatchGroupBalanceResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	g, total, balances, err := ws.walletService.GetWatchGroupBalance(ctx, req.GetName())
+	if err != nil {
+		return nil, watchGroupError(err, codes.Internal)
+	}
+
+	resp := &protogen.WatchGroupBalanceResponse{
+		Name:     g.Name,
+		Balance:  total,
+		Balances: make([]*protogen.AddressBalance, 0, len(balances)),
+	}
+	for i, a := range g.Addresses {
+		resp.Balances = append(resp.Balances, &protogen.AddressBalance{
+			BlockchainAddress: a,
+			Balance:           balances[i],
+		})
+	}
+	return resp, nil
+}
+
+func (ws *WalletServer) ListWatchGroupTransactions(ctx context.Context, req *protogen.WatchGroupTransactionsRequest) (*protogen.WatchGroupTransactionsResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	transactions, err := ws.walletService.ListWatchGroupTransactions(ctx, req.GetName(), int(req.GetLimit()))
+	if err != nil {
+		return nil, watchGroupError(err, codes.Internal)
+	}
+	return &protogen.WatchGroupTransactionsResponse{
+		Transactions: transactions,
+	}, nil
+}
+
+func (ws *WalletServer) SubscribeWatchGroup(req *protogen.WatchGroupRequest, stream protogen.WalletService_SubscribeWatchGroupServer) error {
+	if req.GetName() == "" {
+		return status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	return ws.streamWatchGroup(stream.Context(), req.GetName(), stream.Send)
+}
+
+// streamWatchGroup relays the chain events of a watch group's addresses to send,
+// following the group as addresses are added or removed.
+func (ws *WalletServer) streamWatchGroup(ctx context.Context, name string, send func(*protogen.Event) error) error {
+	if err := ws.walletService.SubscribeWatchGroup(ctx, name, send); err != nil {
+		return watchGroupError(err, codes.Unavailable)
+	}
+	return nil
+}
+
+// watchGroupEventsHandler serves SubscribeWatchGroup to browsers as Server-Sent Events.
+func (ws *WalletServer) watchGroupEventsHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	serveEventStream(w, r, func(ctx context.Context, send func(*protogen.Event) error) error {
+		return ws.streamWatchGroup(ctx, name, send)
+	})
+}
+
+// watchGroupError maps watch group errors to status codes, using fallback for the rest.
+func watchGroupError(err error, fallback codes.Code) error {
+	switch {
+	case errors.Is(err, watchonly.ErrNotFound):
+		return status.Errorf(codes.NotFound, err.Error())
+	case errors.Is(err, watchonly.ErrExists):
+		return status.Errorf(codes.AlreadyExists, err.Error())
+	default:
+		return status.Errorf(fallback, err.Error())
+	}
+}
+
+func convertWatchGroup(g *watchonly.Group) *protogen.WatchGroup {
+	return &protogen.WatchGroup{
+		Name:                g.Name,
+		BlockchainAddresses: g.Addresses,
+		CreatedAt:           g.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (bcs *BlockChainServer) RegisterWebhook(ctx context.Context, req *protogen.RegisterWebhookRequest) (*protogen.WebhookResponse, error) {
+	if req.GetUrl() == "" || req.GetSecret() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "url and secret are required")
+	}
+
+	h, err := bcs.blockChainService.RegisterWebhook(webhook.Hook{
+		URL:           req.GetUrl(),
+		Secret:        req.GetSecret(),
+		Addresses:     req.GetAddresses(),
+		Events:        req.GetEvents(),
+		Confirmations: int(req.GetConfirmations()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return &protogen.WebhookResponse{
+		Webhook: convertWebhook(h),
+	}, nil
+}
+
+func (bcs *BlockChainServer) ListWebhooks(ctx context.Context, req *protogen.Empty) (*protogen.ListWebhooksResponse, error) {
+	hooks, err := bcs.blockChainService.ListWebhooks()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	webhooks := make([]*protogen.Webhook, 0, len(hooks))
+	for _, h := range hooks {
+		webhooks = append(webhooks, convertWebhook(h))
+	}
+	return &protogen.ListWebhooksResponse{
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (bcs *BlockChainServer) DeleteWebhook(ctx context.Context, req *protogen.WebhookIdRequest) (*protogen.StatusResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook id is required")
+	}
+	if err := bcs.blockChainService.DeleteWebhook(req.GetId()); err != nil {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	}
+
+	return &protogen.StatusResponse{
+		Status: "Success",
+	}, nil
+}
+
+func (bcs *BlockChainServer) ListWebhookDeliveries(ctx context.Context, req *protogen.WebhookIdRequest) (*protogen.ListWebhookDeliveriesResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook id is required")
+	}
+	deliveries, err := bcs.blockChainService.ListWebhookDeliveries(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	resp := &protogen.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*protogen.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, &protogen.WebhookDelivery{
+			Id:          d.ID,
+			Event:       d.Event,
+			TxHash:      d.TxHash,
+			Status:      d.Status,
+			Attempts:    int32(d.Attempts),
+			NextAttempt: d.NextAttempt.Format(time.RFC3339),
+			LastError:   d.LastError,
+			CreatedAt:   d.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// convertWebhook leaves the secret out: it is write-only once registered.
+func convertWebhook(h *webhook.Hook) *protogen.Webhook {
+	return &protogen.Webhook{
+		Id:            h.ID,
+		Url:           h.URL,
+		Addresses:     h.Addresses,
+		Events:        h.Events,
+		Confirmations: int32(h.Confirmations),
+		CreatedAt:     h.CreatedAt.Format(time.RFC3339),
+	}
+}
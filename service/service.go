@@ -2,25 +2,85 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/zde37/Zero-Chain/address"
 	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/keystore"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/psbt"
 	"github.com/zde37/Zero-Chain/transaction"
 	"github.com/zde37/Zero-Chain/wallet"
+	"github.com/zde37/Zero-Chain/watchonly"
+	"github.com/zde37/Zero-Chain/webhook"
 )
 
 type WalletService interface {
 	CreateTransaction(ctx context.Context, tr wallet.TransactionRequest) error
+	PrepareTransaction(ctx context.Context, tr wallet.TransactionRequest) (*transaction.MetaData, error)
+	SubmitSignedTransaction(ctx context.Context, t transaction.Request) error
+	CreateMultisigAddress(required int, publicKeys []string) (string, []string, error)
+	SignMultisigTransaction(ctx context.Context, tr wallet.TransactionRequest, signer string) (wallet.PartialSignature, *transaction.MetaData, error)
+	CombineMultisigTransaction(ctx context.Context, t transaction.Request, signatures []wallet.PartialSignature) error
+	CreatePartialTransaction(ctx context.Context, tr wallet.TransactionRequest, required int, publicKeys []string) (*psbt.Packet, error)
+	SignPartialTransaction(p *psbt.Packet, walletName, signer, privateKey string) error
+	FinalizePartialTransaction(ctx context.Context, p *psbt.Packet) error
+	GetAddress(publicKey string) (string, error)
+	ValidateAddress(blockchainAddress string) (address.Address, error)
+	CancelTransaction(ctx context.Context, cr wallet.CancelRequest) error
 	CreateWallet() (*wallet.Wallet, error)
 	GetWalletBalance(ctx context.Context, blockchainAddress string) (float32, error)
+	ListAddressTransactions(ctx context.Context, req *protogen.ListAddressTransactionsRequest) (*protogen.ListAddressTransactionsResponse, error)
+	CreateWatchGroup(name string, addresses []string) (*watchonly.Group, error)
+	AddWatchAddresses(name string, addresses []string) (*watchonly.Group, error)
+	RemoveWatchAddresses(name string, addresses []string) (*watchonly.Group, error)
+	ListWatchGroups() []*watchonly.Group
+	DeleteWatchGroup(name string) error
+	GetWatchGroupBalance(ctx context.Context, name string) (*watchonly.Group, float32, []float32, error)
+	ListWatchGroupTransactions(ctx context.Context, name string, limit int) ([]*protogen.AddressTransaction, error)
+	SubscribeWatchGroup(ctx context.Context, name string, send func(*protogen.Event) error) error
+	CreateKeystoreWallet(name, passphrase string) (keystore.Entry, error)
+	ListKeystoreWallets() ([]keystore.Entry, error)
+	UnlockKeystoreWallet(name, passphrase string, timeout time.Duration) (keystore.Entry, error)
+	LockKeystoreWallet(name string)
+	DeleteKeystoreWallet(name, passphrase string) error
+	ImportKeystoreWallet(name, passphrase, privateKey, format string) (keystore.Entry, error)
+	ExportKeystoreWallet(name, passphrase, format string) (string, keystore.Entry, error)
+	ExportPublicKey(name, format string) (string, string, keystore.Entry, error)
+	CreateHDWallet(ctx context.Context, name, passphrase, mnemonic, mnemonicPassphrase string, account uint32) (keystore.Entry, string, error)
+	DeriveAddress(name string) (string, string, error)
+	DiscoverAddresses(ctx context.Context, name string) (keystore.Entry, []string, error)
 }
 
 type BlockChainService interface {
 	CreateTransaction(ctx context.Context, t transaction.Request) error
 	UpdateTransaction(t transaction.Request) error
 	ListTransactions() ([]*transaction.Transaction, int)
+	GetTransaction(hash string) (blockchain.TxRecord, error)
 	DeleteTransactions() error
 	Consensus() error
 	Run()  
 	GetBlockChain() []*blockchain.Block
+	GetBlockByHeight(height int64) *blockchain.Block
+	GetBlockByHash(hash string) (*blockchain.Block, error)
+	GetChainTip() *blockchain.Block
+	ListBlocks(from int64, limit int) ([]*blockchain.Block, bool, error)
+	Subscribe() (<-chan blockchain.Event, func())
+	RegisterWebhook(h webhook.Hook) (*webhook.Hook, error)
+	ListWebhooks() ([]*webhook.Hook, error)
+	DeleteWebhook(id string) error
+	ListWebhookDeliveries(id string) ([]webhook.Delivery, error)
 	GetWalletBalance(blockchainAddress string) float32
+	GetAccountNonce(blockchainAddress string) uint64
+	ListAddressTransactions(blockchainAddress, cursor string, limit int, direction string) ([]*blockchain.AddressTx, string, error)
+	StartMining() error
+	StopMining() error
+	SetMiningWorkers(workers int)
+	SetCoinbaseAddress(blockchainAddress string) error
+	GetMiningStatus() blockchain.MiningStatus
+	GetSupplyInfo() blockchain.SupplyInfo
+	GetSyncInfo() blockchain.SyncInfo
+	GetBlockTemplate(coinbaseAddress string) (*blockchain.BlockTemplate, error)
+	SubmitBlock(previousHash string, timestamp int64, coinbaseAddress string, transactionHashes []string, nonce int64) (*blockchain.Block, error)
+	GenerateBlocks(ctx context.Context, blocks int, coinbaseAddress string) ([]*blockchain.Block, error)
 }
@@ -2,39 +2,77 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
 	"fmt" 
+	"log"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/zde37/Zero-Chain/address"
 	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/hd"
 	"github.com/zde37/Zero-Chain/helpers"
+	"github.com/zde37/Zero-Chain/keystore"
 	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/psbt"
 	"github.com/zde37/Zero-Chain/transaction"
 	"github.com/zde37/Zero-Chain/wallet"
+	"github.com/zde37/Zero-Chain/watchonly"
+	"github.com/zde37/Zero-Chain/webhook"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+const (
+	DEFAULT_PAGE_LIMIT = 20
+	MAX_PAGE_LIMIT     = 100
+	HISTORY_ASCENDING  = "asc"
+	HISTORY_DESCENDING = "desc"
+)
+
 var (
 	DB           map[string]*blockchain.BlockChain = make(map[string]*blockchain.BlockChain) // in-memory database
 	minersWallet map[uint16]*wallet.Wallet         = make(map[uint16]*wallet.Wallet)
 )
 
 type WalletServiceImpl struct {
-	port    uint16
-	gateway string
-	conn    *grpc.ClientConn
-	client  protogen.BlockChainServiceClient
+	port        uint16
+	gateway     string
+	conn        *grpc.ClientConn
+	client      protogen.BlockChainServiceClient
+	keystore    *keystore.KeyStore
+	watchGroups *watchonly.Store
 }
 
 type BlockChainServiceImpl struct {
-	port uint16
+	port         uint16
+	dataDir      string
+	miningMode   string
+	params       chainparams.Params
+	webhooks     *webhook.Dispatcher
+	webhooksErr  error
+	webhooksOnce sync.Once
 }
 
-func NewWalletServiceImpl(port uint16, gateway string) (WalletService, error) {
+func NewWalletServiceImpl(port uint16, gateway, dataDir string) (WalletService, error) {
 	w := &WalletServiceImpl{port: port, gateway: gateway}
 
 	var err error
+	w.keystore, err = keystore.New(filepath.Join(dataDir, fmt.Sprintf("keystore-%d", port)))
+	if err != nil {
+		return nil, err
+	}
+	w.watchGroups, err = watchonly.New(filepath.Join(dataDir, fmt.Sprintf("watch-%d.json", port)))
+	if err != nil {
+		return nil, err
+	}
 	w.conn, err = grpc.NewClient(
 		w.gateway,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -48,34 +86,139 @@ func NewWalletServiceImpl(port uint16, gateway string) (WalletService, error) {
 	return w, nil
 }
 
-func NewBlockChainServiceImpl(port uint16) BlockChainService {
-	return &BlockChainServiceImpl{port: port}
+func NewBlockChainServiceImpl(port uint16, dataDir, miningMode string, params chainparams.Params) BlockChainService {
+	return &BlockChainServiceImpl{port: port, dataDir: dataDir, miningMode: miningMode, params: params}
 }
 
-func (w *WalletServiceImpl) CreateTransaction(ctx context.Context, tr wallet.TransactionRequest) error {
-	publicKey := helpers.PublicKeyFromString(tr.SenderPublicKey)
-	privateKey := helpers.PrivateKeyFromString(tr.SenderPrivateKey, publicKey)
+// PrepareTransaction validates a transfer and fills in the sender's next nonce. The
+// returned metadata carries no keys; its MarshalJSON output is the payload the sender signs.
+func (w *WalletServiceImpl) PrepareTransaction(ctx context.Context, tr wallet.TransactionRequest) (*transaction.MetaData, error) {
+	if err := address.Validate(tr.RecipientBlockchainAddress); err != nil {
+		return nil, fmt.Errorf("ERR: invalid recipient address: %w", err)
+	}
+	if err := address.Validate(tr.SenderBlockchainAddress); err != nil {
+		return nil, fmt.Errorf("ERR: invalid sender address: %w", err)
+	}
 	if tr.SenderBlockchainAddress == tr.RecipientBlockchainAddress {
-		return fmt.Errorf("ERR: c'mon man, you can't send z-coin to yourself")
+		return nil, fmt.Errorf("ERR: c'mon man, you can't send z-coin to yourself")
 	}
 	senderBalance, err := w.GetWalletBalance(ctx, tr.SenderBlockchainAddress)
 	if err != nil {
-		return fmt.Errorf("ERR: failed to fetch wallet balance: %v", err)
+		return nil, fmt.Errorf("ERR: failed to fetch wallet balance: %v", err)
+	}
+	if senderBalance < tr.Value+tr.Fee {
+		return nil, fmt.Errorf("ERR: insufficient funds for this transaction")
+	}
+
+	var nonce uint64
+	if tr.Nonce != nil {
+		nonce = *tr.Nonce
+	} else {
+		resp, err := w.client.AccountNonce(ctx, &protogen.NonceRequest{
+			BlockchainAddress: tr.SenderBlockchainAddress,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ERR: failed to fetch account nonce: %v", err)
+		}
+		nonce = resp.GetNonce()
+	}
+
+	return transaction.NewMetaData(nil, nil, tr.SenderBlockchainAddress, tr.RecipientBlockchainAddress, tr.Value, tr.Fee, nonce), nil
+}
+
+func (w *WalletServiceImpl) CreateTransaction(ctx context.Context, tr wallet.TransactionRequest) error {
+	key, err := w.signingKey(tr.WalletName, tr.SenderPrivateKey, tr.SenderPublicKey, &tr.SenderBlockchainAddress)
+	if err != nil {
+		return err
 	}
-	if senderBalance < tr.Value {
-		return fmt.Errorf("ERR: insufficient funds for this transaction")
+	md, err := w.PrepareTransaction(ctx, tr)
+	if err != nil {
+		return err
+	}
+	return w.signAndSubmit(ctx, key, md)
+}
+
+// CancelTransaction evicts a pending transaction by replacing it with a zero-value
+// transfer back to the sender; only the (higher) fee is spent.
+func (w *WalletServiceImpl) CancelTransaction(ctx context.Context, cr wallet.CancelRequest) error {
+	key, err := w.signingKey(cr.WalletName, cr.SenderPrivateKey, cr.SenderPublicKey, &cr.SenderBlockchainAddress)
+	if err != nil {
+		return err
+	}
+	md := transaction.NewMetaData(nil, nil, cr.SenderBlockchainAddress, cr.SenderBlockchainAddress, 0, cr.Fee, cr.Nonce)
+	return w.signAndSubmit(ctx, key, md)
+}
+
+// signingKey returns the unlocked keystore wallet's key when walletName is set, and the
+// supplied key pair otherwise. For keystore wallets an empty sender is filled in with
+// the wallet's address and one the wallet cannot sign for is rejected.
+func (w *WalletServiceImpl) signingKey(walletName, privateKey, publicKey string, sender *string) (*ecdsa.PrivateKey, error) {
+	if walletName == "" {
+		key, err := wallet.ParsePrivateKey(privateKey, wallet.FORMAT_DETECT)
+		if err != nil {
+			return nil, fmt.Errorf("ERR: %w", err)
+		}
+		if pub, _ := wallet.EncodePublicKey(&key.PublicKey, wallet.FORMAT_HEX); !strings.EqualFold(pub, publicKey) {
+			return nil, fmt.Errorf("ERR: public key does not match private key")
+		}
+		return key, nil
+	}
+
+	key, _, err := w.keystore.Key(walletName, *sender)
+	if err != nil {
+		return nil, fmt.Errorf("ERR: %w", err)
+	}
+	if *sender == "" {
+		*sender = wallet.Address(&key.PublicKey)
+	}
+	return key, nil
+}
+
+func (w *WalletServiceImpl) signAndSubmit(ctx context.Context, key *ecdsa.PrivateKey, md *transaction.MetaData) error {
+	md.SenderPrivateKey = key
+	md.SenderPublicKey = &key.PublicKey
+	signature := md.GenerateSignature()
+	if signature == nil {
+		return fmt.Errorf("ERR: failed to sign transaction")
 	}
 
-	transaction := transaction.NewMetaData(privateKey, publicKey, tr.SenderBlockchainAddress, tr.RecipientBlockchainAddress, tr.Value)
-	signature := transaction.GenerateSignature()
-	signatureStr := signature.String()
+	return w.SubmitSignedTransaction(ctx, transaction.Request{
+		SenderBlockchainAddress:    md.SenderBlockchainAddress,
+		RecipientBlockchainAddress: md.RecipientBlockchainAddress,
+		SenderPublicKey:            fmt.Sprintf("%064x%064x", key.X.Bytes(), key.Y.Bytes()),
+		Value:                      md.Value,
+		Fee:                        md.Fee,
+		Nonce:                      md.Nonce,
+		Signature:                  signature.String(),
+	})
+}
+
+// SubmitSignedTransaction relays a transaction signed by the client. The signature is
+// checked here first so a bad one is reported without a round trip to the node.
+func (w *WalletServiceImpl) SubmitSignedTransaction(ctx context.Context, t transaction.Request) error {
+	if t.IsMultisig() {
+		if err := verifyMultisig(t); err != nil {
+			return err
+		}
+	} else {
+		publicKey := helpers.PublicKeyFromString(t.SenderPublicKey)
+		md := transaction.NewMetaData(nil, publicKey, t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce)
+		if !md.VerifySignature(helpers.SignatureFromString(t.Signature)) {
+			return fmt.Errorf("ERR: invalid transaction signature")
+		}
+	}
 
 	resp, err := w.client.CreateTransaction(ctx, &protogen.TransactionRequest{
-		SenderBlockchainAddress:    tr.SenderBlockchainAddress,
-		RecipientBlockchainAddress: tr.RecipientBlockchainAddress,
-		SenderPublicKey:            tr.SenderPublicKey,
-		Value:                      tr.Value,
-		Signature:                  signatureStr,
+		SenderBlockchainAddress:    t.SenderBlockchainAddress,
+		RecipientBlockchainAddress: t.RecipientBlockchainAddress,
+		SenderPublicKey:            t.SenderPublicKey,
+		Value:                      t.Value,
+		Fee:                        t.Fee,
+		Nonce:                      t.Nonce,
+		Signature:                  t.Signature,
+		RequiredSignatures:         t.RequiredSignatures,
+		PublicKeys:                 t.PublicKeys,
+		Signatures:                 t.Signatures,
 	})
 	if err != nil || resp.GetStatus() != "Success" {
 		return fmt.Errorf("ERR: failed to create transaction: %v", err)
@@ -83,6 +226,239 @@ func (w *WalletServiceImpl) CreateTransaction(ctx context.Context, tr wallet.Tra
 	return nil
 }
 
+// verifyMultisig checks that a multisig transaction's keys belong to its sender and that
+// enough of them signed.
+func verifyMultisig(t transaction.Request) error {
+	ms := t.Multisig()
+	a, err := ms.Address()
+	if err != nil {
+		return fmt.Errorf("ERR: %w", err)
+	}
+	if a.String() != t.SenderBlockchainAddress {
+		return fmt.Errorf("ERR: keys do not belong to multisig address %s", t.SenderBlockchainAddress)
+	}
+
+	md := transaction.NewMetaData(nil, nil, t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce)
+	payload, err := md.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("ERR: failed to build signing payload: %v", err)
+	}
+	if valid := ms.ValidSignatures(payload); valid < ms.RequiredSignatures {
+		return fmt.Errorf("ERR: %d of %d required signatures are valid", valid, ms.RequiredSignatures)
+	}
+	return nil
+}
+
+// CreateMultisigAddress derives the address spendable by any required of publicKeys and
+// returns it with the keys in the order their signatures are given in.
+func (w *WalletServiceImpl) CreateMultisigAddress(required int, publicKeys []string) (string, []string, error) {
+	keys, err := parsePublicKeys(publicKeys)
+	if err != nil {
+		return "", nil, err
+	}
+	a, err := address.FromMultisig(required, keys)
+	if err != nil {
+		return "", nil, fmt.Errorf("ERR: %w", err)
+	}
+
+	address.SortPublicKeys(keys)
+	sorted := make([]string, len(keys))
+	for i, pub := range keys {
+		sorted[i], _ = wallet.EncodePublicKey(pub, wallet.FORMAT_HEX)
+	}
+	return a.String(), sorted, nil
+}
+
+// SignMultisigTransaction produces one cosigner's signature of a transaction sent from
+// the multisig address tr.SenderBlockchainAddress, with the key of signer in the
+// unlocked keystore wallet tr.WalletName or with tr.SenderPrivateKey. The prepared
+// transaction is returned so cosigners can agree on its nonce.
+func (w *WalletServiceImpl) SignMultisigTransaction(ctx context.Context, tr wallet.TransactionRequest, signer string) (wallet.PartialSignature, *transaction.MetaData, error) {
+	if a, err := address.Parse(tr.SenderBlockchainAddress); err == nil && !a.Multisig {
+		return wallet.PartialSignature{}, nil, fmt.Errorf("ERR: %s is not a multisig address", tr.SenderBlockchainAddress)
+	}
+
+	key, err := w.cosignerKey(tr.WalletName, signer, tr.SenderPrivateKey)
+	if err != nil {
+		return wallet.PartialSignature{}, nil, err
+	}
+
+	md, err := w.PrepareTransaction(ctx, tr)
+	if err != nil {
+		return wallet.PartialSignature{}, nil, err
+	}
+	md.SenderPrivateKey = key
+	md.SenderPublicKey = &key.PublicKey
+	signature := md.GenerateSignature()
+	if signature == nil {
+		return wallet.PartialSignature{}, nil, fmt.Errorf("ERR: failed to sign transaction")
+	}
+
+	publicKey, _ := wallet.EncodePublicKey(&key.PublicKey, wallet.FORMAT_HEX)
+	return wallet.PartialSignature{PublicKey: publicKey, Signature: signature.String()}, md, nil
+}
+
+// cosignerKey returns the key of signer in the unlocked keystore wallet walletName (the
+// wallet's own address when signer is empty), or privateKey when no wallet is named.
+func (w *WalletServiceImpl) cosignerKey(walletName, signer, privateKey string) (*ecdsa.PrivateKey, error) {
+	var key *ecdsa.PrivateKey
+	var err error
+	if walletName == "" {
+		key, err = wallet.ParsePrivateKey(privateKey, wallet.FORMAT_DETECT)
+	} else {
+		key, _, err = w.keystore.Key(walletName, signer)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ERR: %w", err)
+	}
+	return key, nil
+}
+
+func parsePublicKeys(publicKeys []string) ([]*ecdsa.PublicKey, error) {
+	keys := make([]*ecdsa.PublicKey, len(publicKeys))
+	for i, s := range publicKeys {
+		pub, err := wallet.ParsePublicKeyHex(s)
+		if err != nil {
+			return nil, fmt.Errorf("ERR: public key %d: %w", i+1, err)
+		}
+		keys[i] = pub
+	}
+	return keys, nil
+}
+
+// CombineMultisigTransaction puts the cosigners' signatures, given in any order, in the
+// order of t.PublicKeys and submits the transaction once enough of them are valid.
+func (w *WalletServiceImpl) CombineMultisigTransaction(ctx context.Context, t transaction.Request, signatures []wallet.PartialSignature) error {
+	t.Signatures = make([]string, len(t.PublicKeys))
+	for _, ps := range signatures {
+		i := slices.IndexFunc(t.PublicKeys, func(pub string) bool {
+			return strings.EqualFold(pub, ps.PublicKey)
+		})
+		if i < 0 {
+			return fmt.Errorf("ERR: %s is not a key of multisig address %s", ps.PublicKey, t.SenderBlockchainAddress)
+		}
+		t.Signatures[i] = ps.Signature
+	}
+	return w.SubmitSignedTransaction(ctx, t)
+}
+
+// CreatePartialTransaction prepares a transaction like PrepareTransaction and wraps it in
+// an unsigned packet. required and publicKeys describe a multisig sender and are left
+// empty for a single-key one.
+func (w *WalletServiceImpl) CreatePartialTransaction(ctx context.Context, tr wallet.TransactionRequest, required int, publicKeys []string) (*psbt.Packet, error) {
+	md, err := w.PrepareTransaction(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+	if len(publicKeys) == 0 {
+		return psbt.New(md), nil
+	}
+
+	keys, err := parsePublicKeys(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	p, err := psbt.NewMultisig(md, required, keys)
+	if err != nil {
+		return nil, fmt.Errorf("ERR: %w", err)
+	}
+	return p, nil
+}
+
+// SignPartialTransaction adds the signature of signer in the unlocked keystore wallet
+// walletName, or of privateKey, to p. A wallet signs for a single-key sender with the
+// sender's key unless signer says otherwise.
+func (w *WalletServiceImpl) SignPartialTransaction(p *psbt.Packet, walletName, signer, privateKey string) error {
+	if signer == "" && !p.IsMultisig() {
+		signer = p.SenderBlockchainAddress
+	}
+	key, err := w.cosignerKey(walletName, signer, privateKey)
+	if err != nil {
+		return err
+	}
+	if err := p.Sign(key); err != nil {
+		return fmt.Errorf("ERR: %w", err)
+	}
+	return nil
+}
+
+// FinalizePartialTransaction submits a fully signed packet to the blockchain service.
+func (w *WalletServiceImpl) FinalizePartialTransaction(ctx context.Context, p *psbt.Packet) error {
+	t, err := p.Finalize()
+	if err != nil {
+		return fmt.Errorf("ERR: %w", err)
+	}
+	return w.SubmitSignedTransaction(ctx, t)
+}
+
+// GetAddress derives the blockchain address of a client-generated public key.
+func (w *WalletServiceImpl) GetAddress(publicKey string) (string, error) {
+	pub, err := wallet.ParsePublicKeyHex(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("ERR: %v", err)
+	}
+	return wallet.Address(pub), nil
+}
+
+// ImportKeystoreWallet stores an existing private key, given in any wallet.FORMAT_*,
+// as a keystore wallet.
+func (w *WalletServiceImpl) ImportKeystoreWallet(name, passphrase, privateKey, format string) (keystore.Entry, error) {
+	key, err := wallet.ParsePrivateKey(privateKey, format)
+	if err != nil {
+		return keystore.Entry{}, err
+	}
+	return w.keystore.Import(name, passphrase, key)
+}
+
+// ExportKeystoreWallet returns the private key of a keystore wallet encoded in format.
+func (w *WalletServiceImpl) ExportKeystoreWallet(name, passphrase, format string) (string, keystore.Entry, error) {
+	key, entry, err := w.keystore.Export(name, passphrase)
+	if err != nil {
+		return "", keystore.Entry{}, err
+	}
+	encoded, err := wallet.EncodePrivateKey(key, format)
+	return encoded, entry, err
+}
+
+// ExportPublicKey returns what a watch-only wallet needs: the public key encoded in
+// format and, for HD wallets, the public account key for deriving receive addresses.
+func (w *WalletServiceImpl) ExportPublicKey(name, format string) (string, string, keystore.Entry, error) {
+	entry, err := w.keystore.Get(name)
+	if err != nil {
+		return "", "", keystore.Entry{}, err
+	}
+	pub, err := wallet.ParsePublicKeyHex(entry.PublicKey)
+	if err != nil {
+		return "", "", keystore.Entry{}, err
+	}
+	encoded, err := wallet.EncodePublicKey(pub, format)
+	if err != nil {
+		return "", "", keystore.Entry{}, err
+	}
+
+	var accountKey string
+	if entry.Type == keystore.TYPE_HD {
+		account, err := w.keystore.AccountKey(name)
+		if err != nil {
+			return "", "", keystore.Entry{}, err
+		}
+		accountKey = account.PublicString()
+	}
+	return encoded, accountKey, entry, nil
+}
+
+// ValidateAddress parses an address and checks that it belongs to this node's network.
+func (w *WalletServiceImpl) ValidateAddress(blockchainAddress string) (address.Address, error) {
+	a, err := address.Parse(blockchainAddress)
+	if err != nil {
+		return address.Address{}, err
+	}
+	if a.Network != address.ActiveNetwork() {
+		return a, address.ErrNetwork
+	}
+	return a, nil
+}
+
 func (w *WalletServiceImpl) CreateWallet() (*wallet.Wallet, error) {
 	val := strings.Split(w.gateway, ":")
 
@@ -103,6 +479,253 @@ func (w *WalletServiceImpl) GetWalletBalance(ctx context.Context, blockchainAddr
 	return resp.GetBalance(), nil
 }
 
+func (w *WalletServiceImpl) ListAddressTransactions(ctx context.Context, req *protogen.ListAddressTransactionsRequest) (*protogen.ListAddressTransactionsResponse, error) {
+	resp, err := w.client.ListAddressTransactions(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("ERR: failed to list address transactions: %v", err)
+	}
+	return resp, nil
+}
+
+func (w *WalletServiceImpl) CreateWatchGroup(name string, addresses []string) (*watchonly.Group, error) {
+	return w.watchGroups.Create(name, addresses)
+}
+
+func (w *WalletServiceImpl) AddWatchAddresses(name string, addresses []string) (*watchonly.Group, error) {
+	return w.watchGroups.AddAddresses(name, addresses)
+}
+
+func (w *WalletServiceImpl) RemoveWatchAddresses(name string, addresses []string) (*watchonly.Group, error) {
+	return w.watchGroups.RemoveAddresses(name, addresses)
+}
+
+func (w *WalletServiceImpl) ListWatchGroups() []*watchonly.Group {
+	return w.watchGroups.List()
+}
+
+func (w *WalletServiceImpl) DeleteWatchGroup(name string) error {
+	return w.watchGroups.Delete(name)
+}
+
+// GetWatchGroupBalance returns a watch group with its total balance and the balance of
+// each of its addresses, in the group's order.
+func (w *WalletServiceImpl) GetWatchGroupBalance(ctx context.Context, name string) (*watchonly.Group, float32, []float32, error) {
+	g, err := w.watchGroups.Get(name)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	resp, err := w.client.WalletBalances(ctx, &protogen.BalancesRequest{
+		BlockchainAddresses: g.Addresses,
+	})
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("ERR: failed to get wallet balances: %v", err)
+	}
+	balances := resp.GetBalances()
+	if len(balances) != len(g.Addresses) {
+		return nil, 0, nil, fmt.Errorf("ERR: got %d balances for %d addresses", len(balances), len(g.Addresses))
+	}
+
+	var total float32
+	for _, b := range balances {
+		total += b
+	}
+	return g, total, balances, nil
+}
+
+// ListWatchGroupTransactions merges the newest limit transactions of every address in
+// a watch group, pending ones first and then by descending block height. A transfer
+// between two watched addresses shows up once for each side.
+func (w *WalletServiceImpl) ListWatchGroupTransactions(ctx context.Context, name string, limit int) ([]*protogen.AddressTransaction, error) {
+	g, err := w.watchGroups.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	limit = pageLimit(limit)
+
+	resp, err := w.client.ListAddressesTransactions(ctx, &protogen.AddressesTransactionsRequest{
+		BlockchainAddresses: g.Addresses,
+		Limit:               int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ERR: failed to list address transactions: %v", err)
+	}
+	transactions := resp.GetTransactions()
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		pi := transactions[i].GetStatus() == blockchain.TX_STATUS_PENDING
+		pj := transactions[j].GetStatus() == blockchain.TX_STATUS_PENDING
+		if pi != pj {
+			return pi
+		}
+		return transactions[i].GetBlockHeight() > transactions[j].GetBlockHeight()
+	})
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+	return transactions, nil
+}
+
+// SubscribeWatchGroup passes chain events touching any address of a watch group to send
+// until ctx is done. When addresses are added to or removed from the group the
+// subscription is replaced by one for the new set; the stream ends with
+// watchonly.ErrNotFound once the group is deleted.
+func (w *WalletServiceImpl) SubscribeWatchGroup(ctx context.Context, name string, send func(*protogen.Event) error) error {
+	g, changes, stop, err := w.watchGroups.Watch(name)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	events := make(chan *protogen.Event)
+	failed := make(chan error, 1)
+	cancel := func() {}
+	defer func() { cancel() }()
+
+	subscribe := func(addresses []string) error {
+		if len(addresses) == 0 {
+			cancel()
+			cancel = func() {}
+			return nil
+		}
+		streamCtx, streamCancel := context.WithCancel(ctx)
+		stream, err := w.client.SubscribeAddress(streamCtx, &protogen.SubscribeAddressRequest{
+			BlockchainAddress:   addresses[0],
+			BlockchainAddresses: addresses[1:],
+		})
+		if err != nil {
+			streamCancel()
+			return fmt.Errorf("ERR: failed to subscribe to address events: %v", err)
+		}
+		// the old subscription is only dropped once the new one is open
+		cancel()
+		cancel = streamCancel
+
+		go func() {
+			for {
+				e, err := stream.Recv()
+				if err != nil {
+					if streamCtx.Err() == nil {
+						select {
+						case failed <- err:
+						default:
+						}
+					}
+					return
+				}
+				select {
+				case events <- e:
+				case <-streamCtx.Done():
+					return
+				}
+			}
+		}()
+		return nil
+	}
+
+	if err := subscribe(g.Addresses); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case addresses, ok := <-changes:
+			if !ok {
+				return watchonly.ErrNotFound
+			}
+			if err := subscribe(addresses); err != nil {
+				return err
+			}
+		case e := <-events:
+			if err := send(e); err != nil {
+				return err
+			}
+		case err := <-failed:
+			return fmt.Errorf("ERR: address event stream failed: %v", err)
+		}
+	}
+}
+
+func (w *WalletServiceImpl) CreateKeystoreWallet(name, passphrase string) (keystore.Entry, error) {
+	return w.keystore.Create(name, passphrase)
+}
+
+func (w *WalletServiceImpl) ListKeystoreWallets() ([]keystore.Entry, error) {
+	return w.keystore.List()
+}
+
+func (w *WalletServiceImpl) UnlockKeystoreWallet(name, passphrase string, timeout time.Duration) (keystore.Entry, error) {
+	return w.keystore.Unlock(name, passphrase, timeout)
+}
+
+func (w *WalletServiceImpl) LockKeystoreWallet(name string) {
+	w.keystore.Lock(name)
+}
+
+func (w *WalletServiceImpl) DeleteKeystoreWallet(name, passphrase string) error {
+	return w.keystore.Delete(name, passphrase)
+}
+
+// CreateHDWallet stores an HD wallet deriving its keys under account. Without a mnemonic
+// a new one is generated and returned; with one the wallet is recovered and its used
+// addresses are discovered.
+func (w *WalletServiceImpl) CreateHDWallet(ctx context.Context, name, passphrase, mnemonic, mnemonicPassphrase string, account uint32) (keystore.Entry, string, error) {
+	generated := mnemonic == ""
+	if generated {
+		var err error
+		if mnemonic, err = hd.NewMnemonic(); err != nil {
+			return keystore.Entry{}, "", err
+		}
+	}
+
+	entry, err := w.keystore.CreateHD(name, passphrase, mnemonic, mnemonicPassphrase, account)
+	if err != nil {
+		return keystore.Entry{}, "", err
+	}
+	if generated {
+		return entry, mnemonic, nil
+	}
+	entry, _, err = w.DiscoverAddresses(ctx, name)
+	return entry, "", err
+}
+
+// DeriveAddress hands out the next receive address of an HD wallet.
+func (w *WalletServiceImpl) DeriveAddress(name string) (string, string, error) {
+	return w.keystore.NextAddress(name)
+}
+
+// DiscoverAddresses scans the chain for used receive addresses of an HD wallet, stopping
+// after hd.GAP_LIMIT unused ones, and moves the wallet's next address past them.
+func (w *WalletServiceImpl) DiscoverAddresses(ctx context.Context, name string) (keystore.Entry, []string, error) {
+	account, err := w.keystore.AccountKey(name)
+	if err != nil {
+		return keystore.Entry{}, nil, err
+	}
+
+	used := make([]string, 0)
+	next, err := hd.Discover(account, func(address string) (bool, error) {
+		resp, err := w.client.ListAddressTransactions(ctx, &protogen.ListAddressTransactionsRequest{
+			BlockchainAddress: address,
+			Limit:             1,
+		})
+		if err != nil {
+			return false, fmt.Errorf("ERR: failed to list address transactions: %v", err)
+		}
+		if len(resp.GetTransactions()) > 0 {
+			used = append(used, address)
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return keystore.Entry{}, nil, err
+	}
+
+	entry, err := w.keystore.SetNextIndex(name, next)
+	return entry, used, err
+}
+
 func getWallet(port uint16) *wallet.Wallet {
 	w, ok := minersWallet[port]
 	if !ok {
@@ -116,21 +739,42 @@ func (b *BlockChainServiceImpl) getBlockchain() *blockchain.BlockChain {
 	bc, ok := DB["blockchain"] // check if blockchain already exists
 	if !ok {
 		minersWallet := getWallet(b.port)
-		bc = blockchain.New(minersWallet.BlockchainAddress, b.port)
+		bc = blockchain.New(minersWallet.BlockchainAddress, b.port, b.miningMode, b.params)
 		DB["blockchain"] = bc
 	}
 	return bc
 }
 
+// getWebhooks opens the webhook dispatcher the first time it is needed; concurrent
+// requests share the one dispatcher, or the error it failed with.
+func (b *BlockChainServiceImpl) getWebhooks() (*webhook.Dispatcher, error) {
+	b.webhooksOnce.Do(func() {
+		path := filepath.Join(b.dataDir, fmt.Sprintf("webhooks-%d.json", b.port)) // one file per node sharing the data directory
+		b.webhooks, b.webhooksErr = webhook.New(b.getBlockchain(), path)
+	})
+	return b.webhooks, b.webhooksErr
+}
+
 func (b *BlockChainServiceImpl) Run() {
+	webhooks, err := b.getWebhooks()
+	if err != nil {
+		log.Printf("run: webhooks disabled: %v", err)
+	} else {
+		webhooks.Run()
+	}
 	b.getBlockchain().Run()
 }
 
 func (b *BlockChainServiceImpl) CreateTransaction(ctx context.Context, t transaction.Request) error {
-	publicKey := helpers.PublicKeyFromString(t.SenderPublicKey)
-	signature := helpers.SignatureFromString(t.Signature)
 	bc := b.getBlockchain()
-	isCreated := bc.CreateTransaction(ctx, t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, publicKey, signature)
+	var isCreated bool
+	if t.IsMultisig() {
+		isCreated = bc.CreateMultisigTransaction(ctx, t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce, t.Multisig())
+	} else {
+		publicKey := helpers.PublicKeyFromString(t.SenderPublicKey)
+		signature := helpers.SignatureFromString(t.Signature)
+		isCreated = bc.CreateTransaction(ctx, t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce, publicKey, signature)
+	}
  
 	if !isCreated {
 		return fmt.Errorf("ERR: failed to create transaction")
@@ -139,10 +783,15 @@ func (b *BlockChainServiceImpl) CreateTransaction(ctx context.Context, t transac
 }
 
 func (b *BlockChainServiceImpl) UpdateTransaction(t transaction.Request) error {
-	publicKey := helpers.PublicKeyFromString(t.SenderPublicKey)
-	signature := helpers.SignatureFromString(t.Signature)
 	bc := b.getBlockchain()
-	isUpdated := bc.AddTransaction(t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, publicKey, signature)
+	var isUpdated bool
+	if t.IsMultisig() {
+		isUpdated = bc.AddMultisigTransaction(t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce, t.Multisig())
+	} else {
+		publicKey := helpers.PublicKeyFromString(t.SenderPublicKey)
+		signature := helpers.SignatureFromString(t.Signature)
+		isUpdated = bc.AddTransaction(t.SenderBlockchainAddress, t.RecipientBlockchainAddress, t.Value, t.Fee, t.Nonce, publicKey, signature)
+	}
  	if !isUpdated {
 		return fmt.Errorf("ERR: failed to update transaction")
 	}
@@ -150,8 +799,16 @@ func (b *BlockChainServiceImpl) UpdateTransaction(t transaction.Request) error {
 }
 
 func (b *BlockChainServiceImpl) ListTransactions() ([]*transaction.Transaction, int) {
-	bc := b.getBlockchain()
-	return bc.MemPool, len(bc.MemPool)
+	transactions := b.getBlockchain().PendingTransactions()
+	return transactions, len(transactions)
+}
+
+func (b *BlockChainServiceImpl) GetTransaction(hash string) (blockchain.TxRecord, error) {
+	h, err := decodeHash(hash)
+	if err != nil {
+		return blockchain.TxRecord{}, fmt.Errorf("ERR: invalid transaction hash: %v", err)
+	}
+	return b.getBlockchain().GetTransaction(h), nil
 }
 
 func (b *BlockChainServiceImpl) DeleteTransactions() error {
@@ -170,10 +827,249 @@ func (b *BlockChainServiceImpl) Consensus() error {
 }
 
 func (b *BlockChainServiceImpl) GetBlockChain() []*blockchain.Block {
-	return b.getBlockchain().Chain
+	return b.getBlockchain().ChainCopy()
 }
 
 func (b *BlockChainServiceImpl) GetWalletBalance(blockchainAddress string) float32 {
-	bc := b.getBlockchain()
-	return bc.CalculateWalletBalance(blockchainAddress)
+	return b.getBlockchain().WalletBalance(blockchainAddress)
+}
+
+func (b *BlockChainServiceImpl) GetAccountNonce(blockchainAddress string) uint64 {
+	return b.getBlockchain().AccountNonce(blockchainAddress)
+}
+
+// ListAddressTransactions pages through an address' history. The cursor is the history
+// position of the next entry to return, its block height (or "pending") and transaction
+// hash, so pages neither skip nor repeat entries when transactions are added, replaced or
+// evicted in between; an empty next cursor marks the last page.
+func (b *BlockChainServiceImpl) ListAddressTransactions(blockchainAddress, cursor string, limit int,
+	direction string) ([]*blockchain.AddressTx, string, error) {
+	limit = pageLimit(limit)
+	if direction == "" {
+		direction = HISTORY_DESCENDING
+	}
+	if direction != HISTORY_ASCENDING && direction != HISTORY_DESCENDING {
+		return nil, "", fmt.Errorf("ERR: direction must be %q or %q", HISTORY_ASCENDING, HISTORY_DESCENDING)
+	}
+
+	history := b.getBlockchain().AddressHistory(blockchainAddress)
+	start := 0
+	if direction == HISTORY_DESCENDING {
+		start = len(history) - 1
+	}
+	if cursor != "" {
+		position, err := parseHistoryCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		// the first entry at or past the cursor in the paging direction
+		start, _ = slices.BinarySearchFunc(history, position, func(e *blockchain.AddressTx, p blockchain.HistoryPosition) int {
+			return e.Position().Compare(p)
+		})
+		if direction == HISTORY_DESCENDING && (start == len(history) || history[start].Position().Compare(position) > 0) {
+			start--
+		}
+	}
+
+	page := make([]*blockchain.AddressTx, 0, limit)
+	next := start
+	for next >= 0 && next < len(history) && len(page) < limit {
+		page = append(page, history[next])
+		if direction == HISTORY_ASCENDING {
+			next++
+		} else {
+			next--
+		}
+	}
+
+	if next < 0 || next >= len(history) {
+		return page, "", nil
+	}
+	return page, historyCursor(history[next].Position()), nil
+}
+
+func historyCursor(p blockchain.HistoryPosition) string {
+	height := "pending"
+	if p.Height != blockchain.PENDING_HEIGHT {
+		height = strconv.Itoa(p.Height)
+	}
+	return fmt.Sprintf("%s:%x", height, p.Hash)
+}
+
+func parseHistoryCursor(cursor string) (blockchain.HistoryPosition, error) {
+	var p blockchain.HistoryPosition
+	height, hash, ok := strings.Cut(cursor, ":")
+	if !ok {
+		return p, fmt.Errorf("ERR: invalid cursor")
+	}
+	var err error
+	if p.Hash, err = decodeHash(hash); err != nil {
+		return p, fmt.Errorf("ERR: invalid cursor")
+	}
+	if height == "pending" {
+		p.Height = blockchain.PENDING_HEIGHT
+		return p, nil
+	}
+	h, err := strconv.Atoi(height)
+	if err != nil || h < 0 {
+		return p, fmt.Errorf("ERR: invalid cursor")
+	}
+	p.Height = h
+	return p, nil
+}
+
+func (b *BlockChainServiceImpl) GetBlockByHeight(height int64) *blockchain.Block {
+	return b.getBlockchain().BlockByHeight(int(height))
+}
+
+func (b *BlockChainServiceImpl) GetBlockByHash(hash string) (*blockchain.Block, error) {
+	h, err := decodeHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("ERR: invalid block hash: %v", err)
+	}
+	return b.getBlockchain().BlockByHash(h), nil
+}
+
+func (b *BlockChainServiceImpl) GetChainTip() *blockchain.Block {
+	return b.getBlockchain().Tip()
+}
+
+// ListBlocks returns up to limit blocks starting at height from, oldest first, and
+// whether more blocks follow; the next page starts at from plus the blocks returned.
+func (b *BlockChainServiceImpl) ListBlocks(from int64, limit int) ([]*blockchain.Block, bool, error) {
+	if from < 0 {
+		return nil, false, fmt.Errorf("ERR: from must not be negative")
+	}
+	blocks, more := b.getBlockchain().Blocks(int(from), pageLimit(limit))
+	return blocks, more, nil
+}
+
+func (b *BlockChainServiceImpl) Subscribe() (<-chan blockchain.Event, func()) {
+	return b.getBlockchain().Subscribe()
+}
+
+func (b *BlockChainServiceImpl) RegisterWebhook(h webhook.Hook) (*webhook.Hook, error) {
+	webhooks, err := b.getWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.Register(h)
+}
+
+func (b *BlockChainServiceImpl) ListWebhooks() ([]*webhook.Hook, error) {
+	webhooks, err := b.getWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.Hooks(), nil
+}
+
+func (b *BlockChainServiceImpl) DeleteWebhook(id string) error {
+	webhooks, err := b.getWebhooks()
+	if err != nil {
+		return err
+	}
+	return webhooks.Delete(id)
+}
+
+func (b *BlockChainServiceImpl) ListWebhookDeliveries(id string) ([]webhook.Delivery, error) {
+	webhooks, err := b.getWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.Deliveries(id), nil
+}
+
+func (b *BlockChainServiceImpl) StartMining() error {
+	if !b.getBlockchain().StartMining() {
+		return fmt.Errorf("ERR: mining is already running")
+	}
+	return nil
+}
+
+func (b *BlockChainServiceImpl) StopMining() error {
+	if !b.getBlockchain().StopMining() {
+		return fmt.Errorf("ERR: mining is not running")
+	}
+	return nil
+}
+
+func (b *BlockChainServiceImpl) SetMiningWorkers(workers int) {
+	b.getBlockchain().SetMiningWorkers(workers)
+}
+
+func (b *BlockChainServiceImpl) SetCoinbaseAddress(blockchainAddress string) error {
+	if !b.getBlockchain().SetCoinbaseAddress(blockchainAddress) {
+		return fmt.Errorf("ERR: invalid coinbase address")
+	}
+	return nil
+}
+
+func (b *BlockChainServiceImpl) GetMiningStatus() blockchain.MiningStatus {
+	return b.getBlockchain().MiningStatus()
+}
+
+func (b *BlockChainServiceImpl) GetSupplyInfo() blockchain.SupplyInfo {
+	return b.getBlockchain().SupplyInfo()
+}
+
+func (b *BlockChainServiceImpl) GetSyncInfo() blockchain.SyncInfo {
+	return b.getBlockchain().SyncInfo()
+}
+
+func (b *BlockChainServiceImpl) GetBlockTemplate(coinbaseAddress string) (*blockchain.BlockTemplate, error) {
+	t, ok := b.getBlockchain().BlockTemplate(coinbaseAddress)
+	if !ok {
+		return nil, fmt.Errorf("ERR: failed to create block template")
+	}
+	return t, nil
+}
+
+// SubmitBlock connects a block found by a miner outside the node for a template from
+// GetBlockTemplate.
+func (b *BlockChainServiceImpl) SubmitBlock(previousHash string, timestamp int64, coinbaseAddress string, transactionHashes []string, nonce int64) (*blockchain.Block, error) {
+	previous, err := decodeHash(previousHash)
+	if err != nil {
+		return nil, fmt.Errorf("ERR: invalid previous hash: %v", err)
+	}
+	hashes := make([][32]byte, len(transactionHashes))
+	for i, h := range transactionHashes {
+		if hashes[i], err = decodeHash(h); err != nil {
+			return nil, fmt.Errorf("ERR: invalid transaction hash %s: %v", h, err)
+		}
+	}
+
+	block, ok := b.getBlockchain().SubmitBlock(previous, timestamp, coinbaseAddress, hashes, int(nonce))
+	if !ok {
+		return nil, fmt.Errorf("ERR: block rejected")
+	}
+	return block, nil
+}
+
+// GenerateBlocks mines blocks on demand on networks meant for tests, such as regtest.
+func (b *BlockChainServiceImpl) GenerateBlocks(ctx context.Context, blocks int, coinbaseAddress string) ([]*blockchain.Block, error) {
+	generated, ok := b.getBlockchain().GenerateBlocks(ctx, blocks, coinbaseAddress)
+	if !ok {
+		return generated, fmt.Errorf("ERR: generated %d of %d blocks", len(generated), blocks)
+	}
+	return generated, nil
+}
+
+func decodeHash(s string) ([32]byte, error) {
+	var h [32]byte
+	if len(s) != hex.EncodedLen(len(h)) {
+		return h, fmt.Errorf("expected %d hex digits", hex.EncodedLen(len(h)))
+	}
+	_, err := hex.Decode(h[:], []byte(s))
+	return h, err
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_PAGE_LIMIT
+	}
+	if limit > MAX_PAGE_LIMIT {
+		return MAX_PAGE_LIMIT
+	}
+	return limit
 }
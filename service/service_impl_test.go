@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/protobuf/protogen"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+	"github.com/zde37/Zero-Chain/watchonly"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// newTestService returns a blockchain service on a fresh chain that mines on demand at
+// the lowest difficulty, whose genesis block gives each wallet 100 coins.
+func newTestService(t *testing.T, funded ...*wallet.Wallet) (*BlockChainServiceImpl, *blockchain.BlockChain) {
+	t.Helper()
+	p := chainparams.Mainnet
+	p.Name = "test"
+	p.GenesisHash = ""
+	p.Checkpoints = nil
+	p.Difficulty = 1
+	p.MineOnDemand = true
+	for _, w := range funded {
+		p.Genesis.Allocations = append(p.Genesis.Allocations, chainparams.Allocation{Address: w.BlockchainAddress, Value: 100})
+		p.Emission.GenesisAllocation += 100
+	}
+
+	bc := blockchain.New(wallet.New().BlockchainAddress, 0, blockchain.MINING_MODE_CONTINUOUS, p)
+	DB["blockchain"] = bc
+	t.Cleanup(func() { delete(DB, "blockchain") })
+	return &BlockChainServiceImpl{dataDir: t.TempDir(), params: p}, bc
+}
+
+func send(t *testing.T, bc *blockchain.BlockChain, w *wallet.Wallet, recipient string, value, fee float32, nonce uint64) {
+	t.Helper()
+	md := transaction.NewMetaData(w.PrivateKey, w.PublicKey, w.BlockchainAddress, recipient, value, fee, nonce)
+	if !bc.AddTransaction(w.BlockchainAddress, recipient, value, fee, nonce, w.PublicKey, md.GenerateSignature()) {
+		t.Fatalf("transaction with nonce %d rejected", nonce)
+	}
+}
+
+func mine(t *testing.T, bc *blockchain.BlockChain, n int) {
+	t.Helper()
+	if blocks, ok := bc.GenerateBlocks(context.Background(), n, bc.BlockChainAddress); !ok {
+		t.Fatalf("generated %d of %d blocks", len(blocks), n)
+	}
+}
+
+func TestListAddressTransactions(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	s, bc := newTestService(t, alice)
+	for nonce := range uint64(3) {
+		send(t, bc, alice, bob.BlockchainAddress, 1, 0.1, nonce)
+	}
+	mine(t, bc, 1)
+	for nonce := range uint64(2) {
+		send(t, bc, alice, bob.BlockchainAddress, 1, 0.1, 3+nonce)
+	}
+
+	for _, direction := range []string{HISTORY_ASCENDING, HISTORY_DESCENDING} {
+		all, next, err := s.ListAddressTransactions(alice.BlockchainAddress, "", MAX_PAGE_LIMIT, direction)
+		if err != nil || next != "" || len(all) != 6 {
+			t.Fatalf("%s: got %d entries, next %q, error %v; want all 6", direction, len(all), next, err)
+		}
+
+		var paged []*blockchain.AddressTx
+		cursor := ""
+		for {
+			page, next, err := s.ListAddressTransactions(alice.BlockchainAddress, cursor, 4, direction)
+			if err != nil {
+				t.Fatalf("%s: %v", direction, err)
+			}
+			paged = append(paged, page...)
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		for i := range all {
+			if paged[i].Transaction != all[i].Transaction {
+				t.Fatalf("%s: entry %d differs between pages of 4 and a single page", direction, i)
+			}
+		}
+	}
+	if _, _, err := s.ListAddressTransactions(alice.BlockchainAddress, "3", 1, ""); err == nil {
+		t.Fatal("accepted a positional cursor")
+	}
+}
+
+// TestListAddressTransactionsReplaced pages through a history whose pending transactions
+// change between pages: the entries that stay are each returned exactly once.
+func TestListAddressTransactionsReplaced(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	s, bc := newTestService(t, alice)
+	for nonce := range uint64(4) {
+		send(t, bc, alice, bob.BlockchainAddress, 1, 0.1, nonce)
+	}
+
+	page, cursor, err := s.ListAddressTransactions(alice.BlockchainAddress, "", 2, HISTORY_ASCENDING)
+	if err != nil || len(page) != 2 || cursor == "" {
+		t.Fatalf("first page: %d entries, cursor %q, error %v", len(page), cursor, err)
+	}
+	replaced := page[1].Transaction // pending, already returned
+	send(t, bc, alice, bob.BlockchainAddress, 2, 0.5, replaced.Nonce)
+
+	seen := map[[32]byte]int{}
+	for _, e := range page {
+		seen[e.Transaction.Hash]++
+	}
+	for cursor != "" {
+		page, cursor, err = s.ListAddressTransactions(alice.BlockchainAddress, cursor, 2, HISTORY_ASCENDING)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range page {
+			seen[e.Transaction.Hash]++
+		}
+	}
+
+	for _, e := range bc.AddressHistory(alice.BlockchainAddress) {
+		if e.Transaction.Nonce == replaced.Nonce && e.Block == nil {
+			continue // the replacement was added after paging started
+		}
+		if seen[e.Transaction.Hash] != 1 {
+			t.Errorf("transaction with nonce %d returned %d times", e.Transaction.Nonce, seen[e.Transaction.Hash])
+		}
+	}
+	if seen[replaced.Hash] != 1 {
+		t.Errorf("replaced transaction returned %d times", seen[replaced.Hash])
+	}
+}
+
+func TestListBlocks(t *testing.T) {
+	s, bc := newTestService(t)
+	mine(t, bc, 2) // heights 0 to 2
+
+	blocks, more, err := s.ListBlocks(0, 2)
+	if err != nil || len(blocks) != 2 || !more {
+		t.Fatalf("first page: %d blocks, more %v, error %v; want 2 and more", len(blocks), more, err)
+	}
+	blocks, more, err = s.ListBlocks(2, 2)
+	if err != nil || len(blocks) != 1 || more {
+		t.Fatalf("last page: %d blocks, more %v, error %v; want 1 and no more", len(blocks), more, err)
+	}
+	if _, _, err := s.ListBlocks(-1, 2); err == nil {
+		t.Fatal("accepted a negative height")
+	}
+}
+
+func TestLookupsByHash(t *testing.T) {
+	s, bc := newTestService(t)
+	mine(t, bc, 1)
+	tip := fmt.Sprintf("%x", bc.Tip().Hash)
+
+	if b, err := s.GetBlockByHash(tip); err != nil || b == nil || b.Index != 1 {
+		t.Fatalf("block by hash: %v, %v", b, err)
+	}
+	for _, hash := range []string{"", tip[:62], strings.Repeat("zz", 32)} {
+		if _, err := s.GetBlockByHash(hash); err == nil || !strings.Contains(err.Error(), "invalid block hash") {
+			t.Errorf("block hash %q: %v", hash, err)
+		}
+		if _, err := s.GetTransaction(hash); err == nil || !strings.Contains(err.Error(), "invalid transaction hash") {
+			t.Errorf("transaction hash %q: %v", hash, err)
+		}
+	}
+}
+
+// TestChainReadsReturnCopies changes the slices the service returns and expects the chain
+// to be unaffected.
+func TestChainReadsReturnCopies(t *testing.T) {
+	alice, bob := wallet.New(), wallet.New()
+	s, bc := newTestService(t, alice)
+	mine(t, bc, 1)
+	send(t, bc, alice, bob.BlockchainAddress, 10, 0.5, 0)
+
+	transactions, n := s.ListTransactions()
+	if n != 1 || s.GetAccountNonce(alice.BlockchainAddress) != 1 || s.GetWalletBalance(alice.BlockchainAddress) != 100 {
+		t.Fatalf("%d pending transactions, nonce %d and balance %v; want 1, 1 and 100", n,
+			s.GetAccountNonce(alice.BlockchainAddress), s.GetWalletBalance(alice.BlockchainAddress))
+	}
+	transactions[0] = nil
+	blocks := s.GetBlockChain()
+	blocks[1] = nil
+	page, _, err := s.ListBlocks(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page[0] = nil
+	if bc.CopyMemPool()[0] == nil || bc.BlockByHeight(1) == nil {
+		t.Fatal("changing a returned slice changed the chain")
+	}
+}
+
+// fakeChainClient answers the blockchain service calls of the watch groups and counts
+// them; calls it doesn't implement panic on the nil embedded client.
+type fakeChainClient struct {
+	protogen.BlockChainServiceClient
+	mut           sync.Mutex
+	balanceCalls  int
+	historyCalls  int
+	subscriptions chan *fakeSubscription
+}
+
+type fakeSubscription struct {
+	addresses []string
+	ctx       context.Context
+	events    chan *protogen.Event
+}
+
+func (c *fakeChainClient) WalletBalances(ctx context.Context, req *protogen.BalancesRequest, opts ...grpc.CallOption) (*protogen.BalancesResponse, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.balanceCalls++
+	resp := &protogen.BalancesResponse{}
+	for i := range req.GetBlockchainAddresses() {
+		resp.Balances = append(resp.Balances, float32(i+1))
+	}
+	return resp, nil
+}
+
+func (c *fakeChainClient) ListAddressesTransactions(ctx context.Context, req *protogen.AddressesTransactionsRequest, opts ...grpc.CallOption) (*protogen.ListAddressTransactionsResponse, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.historyCalls++
+	resp := &protogen.ListAddressTransactionsResponse{}
+	for i, a := range req.GetBlockchainAddresses() {
+		resp.Transactions = append(resp.Transactions,
+			&protogen.AddressTransaction{BlockchainAddress: a, Status: blockchain.TX_STATUS_CONFIRMED, BlockHeight: int64(i + 1)},
+			&protogen.AddressTransaction{BlockchainAddress: a, Status: blockchain.TX_STATUS_PENDING})
+	}
+	return resp, nil
+}
+
+func (c *fakeChainClient) SubscribeAddress(ctx context.Context, req *protogen.SubscribeAddressRequest, opts ...grpc.CallOption) (protogen.BlockChainService_SubscribeAddressClient, error) {
+	sub := &fakeSubscription{
+		addresses: append([]string{req.GetBlockchainAddress()}, req.GetBlockchainAddresses()...),
+		ctx:       ctx,
+		events:    make(chan *protogen.Event),
+	}
+	c.subscriptions <- sub
+	return sub, nil
+}
+
+func (s *fakeSubscription) Recv() (*protogen.Event, error) {
+	select {
+	case e := <-s.events:
+		return e, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *fakeSubscription) Header() (metadata.MD, error) { return nil, nil }
+func (s *fakeSubscription) Trailer() metadata.MD         { return nil }
+func (s *fakeSubscription) CloseSend() error             { return nil }
+func (s *fakeSubscription) Context() context.Context     { return s.ctx }
+func (s *fakeSubscription) SendMsg(any) error            { return nil }
+func (s *fakeSubscription) RecvMsg(any) error            { return nil }
+
+func newTestWalletService(t *testing.T) (*WalletServiceImpl, *fakeChainClient) {
+	t.Helper()
+	groups, err := watchonly.New(filepath.Join(t.TempDir(), "watch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeChainClient{subscriptions: make(chan *fakeSubscription, 4)}
+	return &WalletServiceImpl{client: client, watchGroups: groups}, client
+}
+
+func TestWatchGroupBatchedLookups(t *testing.T) {
+	w, client := newTestWalletService(t)
+	addresses := []string{wallet.New().BlockchainAddress, wallet.New().BlockchainAddress, wallet.New().BlockchainAddress}
+	if _, err := w.CreateWatchGroup("cold", addresses); err != nil {
+		t.Fatal(err)
+	}
+
+	_, total, balances, err := w.GetWatchGroupBalance(context.Background(), "cold")
+	if err != nil || total != 6 || !slices.Equal(balances, []float32{1, 2, 3}) {
+		t.Fatalf("balance = %v %v, %v", total, balances, err)
+	}
+	transactions, err := w.ListWatchGroupTransactions(context.Background(), "cold", 4)
+	if err != nil || len(transactions) != 4 {
+		t.Fatalf("got %d transactions, %v; want 4", len(transactions), err)
+	}
+	for i, tx := range transactions {
+		if pending := tx.GetStatus() == blockchain.TX_STATUS_PENDING; pending != (i < 3) {
+			t.Fatalf("transaction %d is %s; want pending ones first", i, tx.GetStatus())
+		}
+	}
+	if transactions[3].GetBlockHeight() != 3 {
+		t.Fatalf("first confirmed transaction at height %d, want the newest", transactions[3].GetBlockHeight())
+	}
+	if client.balanceCalls != 1 || client.historyCalls != 1 {
+		t.Fatalf("made %d balance and %d history calls for 3 addresses, want 1 each", client.balanceCalls, client.historyCalls)
+	}
+}
+
+// TestSubscribeWatchGroupRefresh adds an address to a group while it is subscribed: the
+// subscription is replaced by one that includes it.
+func TestSubscribeWatchGroupRefresh(t *testing.T) {
+	w, client := newTestWalletService(t)
+	a, b := wallet.New().BlockchainAddress, wallet.New().BlockchainAddress
+	if _, err := w.CreateWatchGroup("cold", []string{a}); err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan *protogen.Event)
+	done := make(chan error)
+	go func() {
+		done <- w.SubscribeWatchGroup(context.Background(), "cold", func(e *protogen.Event) error {
+			received <- e
+			return nil
+		})
+	}()
+
+	first := <-client.subscriptions
+	if !slices.Equal(first.addresses, []string{a}) {
+		t.Fatalf("subscribed to %v, want %v", first.addresses, []string{a})
+	}
+	if _, err := w.AddWatchAddresses("cold", []string{b}); err != nil {
+		t.Fatal(err)
+	}
+	second := <-client.subscriptions
+	if !slices.Equal(second.addresses, []string{a, b}) {
+		t.Fatalf("resubscribed to %v, want %v", second.addresses, []string{a, b})
+	}
+	<-first.ctx.Done()
+
+	second.events <- &protogen.Event{Type: blockchain.EVENT_TX_ACCEPTED}
+	if e := <-received; e.GetType() != blockchain.EVENT_TX_ACCEPTED {
+		t.Fatalf("received %s event", e.GetType())
+	}
+
+	if err := w.DeleteWatchGroup("cold"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; !errors.Is(err, watchonly.ErrNotFound) {
+		t.Fatalf("subscription ended with %v after the group was deleted, want %v", err, watchonly.ErrNotFound)
+	}
+}
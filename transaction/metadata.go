@@ -16,16 +16,20 @@ type MetaData struct {
 	SenderBlockchainAddress    string
 	RecipientBlockchainAddress string
 	Value                      float32
+	Fee                        float32
+	Nonce                      uint64
 }
 
 func NewMetaData(senderPrivateKey *ecdsa.PrivateKey, senderPublicKey *ecdsa.PublicKey, senderBlockchainAddress string,
-	recipientBlockchainAddress string, value float32) *MetaData {
+	recipientBlockchainAddress string, value, fee float32, nonce uint64) *MetaData {
 	return &MetaData{
 		SenderPrivateKey:           senderPrivateKey,
 		SenderPublicKey:            senderPublicKey,
 		SenderBlockchainAddress:    senderBlockchainAddress,
 		RecipientBlockchainAddress: recipientBlockchainAddress,
 		Value:                      value,
+		Fee:                        fee,
+		Nonce:                      nonce,
 	}
 }
 
@@ -47,6 +51,17 @@ func (md *MetaData) GenerateSignature() *helpers.Signature {
 	}
 }
 
+// VerifySignature checks s against the signing payload with the sender's public key.
+func (md *MetaData) VerifySignature(s *helpers.Signature) bool {
+	m, err := md.MarshalJSON()
+	if err != nil {
+		log.Printf("wallet: failed to marshal transaction: %v", err)
+		return false
+	}
+	hash := sha256.Sum256([]byte(m))
+	return ecdsa.Verify(md.SenderPublicKey, hash[:], s.R, s.S)
+}
+
 func (md *MetaData) Validate() bool {
 	if md.SenderPrivateKey == nil ||
 		md.SenderPublicKey == nil ||
@@ -64,9 +79,13 @@ func (md *MetaData) MarshalJSON() ([]byte, error) {
 		Sender    string  `json:"sender_blockchain_address"`
 		Recipient string  `json:"recipient_blockchain_address"`
 		Value     float32 `json:"value"`
+		Fee       float32 `json:"fee"`
+		Nonce     uint64  `json:"nonce"`
 	}{
 		Sender:    md.SenderBlockchainAddress,
 		Recipient: md.RecipientBlockchainAddress,
 		Value:     md.Value,
+		Fee:       md.Fee,
+		Nonce:     md.Nonce,
 	})
 }
@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/helpers"
+)
+
+// Multisig authorizes a transaction sent from an M-of-N multisig address: Signatures[i]
+// is PublicKeys[i]'s signature of the signing payload, or nil if that key did not sign.
+type Multisig struct {
+	RequiredSignatures int
+	PublicKeys         []*ecdsa.PublicKey
+	Signatures         []*helpers.Signature
+}
+
+// Multisig decodes the multisig fields of a request whose lengths have been checked.
+func (r Request) Multisig() *Multisig {
+	m := &Multisig{
+		RequiredSignatures: int(r.RequiredSignatures),
+		PublicKeys:         make([]*ecdsa.PublicKey, len(r.PublicKeys)),
+		Signatures:         make([]*helpers.Signature, len(r.PublicKeys)),
+	}
+	for i, pub := range r.PublicKeys {
+		m.PublicKeys[i] = helpers.PublicKeyFromString(pub)
+		if i < len(r.Signatures) && r.Signatures[i] != "" {
+			m.Signatures[i] = helpers.SignatureFromString(r.Signatures[i])
+		}
+	}
+	return m
+}
+
+// Address derives the multisig address the threshold and keys belong to.
+func (m *Multisig) Address() (address.Address, error) {
+	return address.FromMultisig(m.RequiredSignatures, m.PublicKeys)
+}
+
+// ValidSignatures counts the keys whose signature of payload verifies.
+func (m *Multisig) ValidSignatures(payload []byte) int {
+	hash := sha256.Sum256(payload)
+	valid := 0
+	for i, pub := range m.PublicKeys {
+		s := m.Signatures[i]
+		if s != nil && ecdsa.Verify(pub, hash[:], s.R, s.S) {
+			valid++
+		}
+	}
+	return valid
+}
+
+// Encode returns the hex public keys and signatures of a Request; keys that did not
+// sign get an empty signature.
+func (m *Multisig) Encode() (publicKeys, signatures []string) {
+	publicKeys = make([]string, len(m.PublicKeys))
+	signatures = make([]string, len(m.PublicKeys))
+	for i, pub := range m.PublicKeys {
+		publicKeys[i] = fmt.Sprintf("%064x%064x", pub.X.Bytes(), pub.Y.Bytes())
+		if m.Signatures[i] != nil {
+			signatures[i] = m.Signatures[i].String()
+		}
+	}
+	return publicKeys, signatures
+}
@@ -1,9 +1,66 @@
 package transaction
 
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/helpers"
+)
+
 type Request struct {
 	SenderBlockchainAddress    string
 	RecipientBlockchainAddress string
 	SenderPublicKey            string
 	Value                      float32
+	Fee                        float32
+	Nonce                      uint64
 	Signature                  string
+
+	// set instead of SenderPublicKey and Signature for transactions sent from a
+	// multisig address; Signatures[i] is by PublicKeys[i] and empty if it did not sign
+	RequiredSignatures uint32
+	PublicKeys         []string
+	Signatures         []string
+}
+
+func (r Request) IsMultisig() bool {
+	return len(r.PublicKeys) > 0
+}
+
+// Authorization decodes the sender's public key and signature, or the multisig keys
+// and signatures, checking their encoding but not the signatures themselves. A request
+// without either, such as a block reward, decodes to nils.
+func (r Request) Authorization() (*ecdsa.PublicKey, *helpers.Signature, *Multisig, error) {
+	if r.IsMultisig() {
+		if r.RequiredSignatures == 0 ||
+			int(r.RequiredSignatures) > len(r.PublicKeys) ||
+			len(r.PublicKeys) > address.MAX_MULTISIG_KEYS ||
+			len(r.Signatures) != len(r.PublicKeys) {
+			return nil, nil, nil, fmt.Errorf("transaction: invalid %d-of-%d multisig with %d signatures",
+				r.RequiredSignatures, len(r.PublicKeys), len(r.Signatures))
+		}
+		for i := range r.PublicKeys {
+			if !validHexPair(r.PublicKeys[i]) || (r.Signatures[i] != "" && !validHexPair(r.Signatures[i])) {
+				return nil, nil, nil, fmt.Errorf("transaction: malformed multisig key or signature %d", i)
+			}
+		}
+		return nil, nil, r.Multisig(), nil
+	}
+
+	if r.SenderPublicKey == "" && r.Signature == "" {
+		return nil, nil, nil, nil
+	}
+	if !validHexPair(r.SenderPublicKey) || !validHexPair(r.Signature) {
+		return nil, nil, nil, fmt.Errorf("transaction: malformed public key or signature")
+	}
+	return helpers.PublicKeyFromString(r.SenderPublicKey), helpers.SignatureFromString(r.Signature), nil, nil
+}
+
+// validHexPair reports whether s is two 32-byte numbers in 128 hex characters, the
+// encoding of public keys and signatures.
+func validHexPair(s string) bool {
+	_, err := hex.DecodeString(s)
+	return len(s) == 128 && err == nil
 }
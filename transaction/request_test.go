@@ -0,0 +1,75 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/helpers"
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestAuthorizationRoundTrip(t *testing.T) {
+	k := newKey(t)
+	tx := New("sender", "recipient", 1, 0.1, 0)
+	tx.SenderPublicKey = &k.PublicKey
+	tx.Signature = NewMetaData(k, &k.PublicKey, "sender", "recipient", 1, 0.1, 0).GenerateSignature()
+
+	pub, sig, ms, err := tx.Request().Authorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms != nil || !pub.Equal(tx.SenderPublicKey) || sig.String() != tx.Signature.String() {
+		t.Fatal("single-key authorization changed in its request form")
+	}
+
+	keys := []*ecdsa.PrivateKey{newKey(t), newKey(t)}
+	tx = New("sender", "recipient", 1, 0.1, 0)
+	tx.Multisig = &Multisig{
+		RequiredSignatures: 1,
+		PublicKeys:         []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey},
+		Signatures:         []*helpers.Signature{nil, NewMetaData(keys[1], &keys[1].PublicKey, "sender", "recipient", 1, 0.1, 0).GenerateSignature()},
+	}
+	pub, sig, ms, err = tx.Request().Authorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub != nil || sig != nil || ms == nil {
+		t.Fatal("multisig authorization decoded as a single key")
+	}
+	payload, _ := tx.MarshalJSON()
+	if ms.RequiredSignatures != 1 || ms.Signatures[0] != nil || ms.ValidSignatures(payload) != 1 {
+		t.Fatal("multisig authorization changed in its request form")
+	}
+
+	if pub, sig, ms, err := New("sender", "recipient", 1, 0, 0).Request().Authorization(); err != nil || pub != nil || sig != nil || ms != nil {
+		t.Fatalf("unsigned request decoded to %v %v %v %v, want nils", pub, sig, ms, err)
+	}
+}
+
+func TestAuthorizationMalformed(t *testing.T) {
+	key := strings.Repeat("ab", 64)
+	for name, r := range map[string]Request{
+		"short key":          {SenderPublicKey: "ab", Signature: key},
+		"missing signature":  {SenderPublicKey: key},
+		"non-hex signature":  {SenderPublicKey: key, Signature: strings.Repeat("zz", 64)},
+		"zero threshold":     {PublicKeys: []string{key}, Signatures: []string{key}},
+		"threshold too high": {RequiredSignatures: 2, PublicKeys: []string{key}, Signatures: []string{key}},
+		"missing signatures": {RequiredSignatures: 1, PublicKeys: []string{key, key}, Signatures: []string{key}},
+		"short multisig key": {RequiredSignatures: 1, PublicKeys: []string{"ab"}, Signatures: []string{""}},
+	} {
+		if _, _, _, err := r.Authorization(); err == nil {
+			t.Errorf("%s: decoded a malformed authorization", name)
+		}
+	}
+}
@@ -1,25 +1,41 @@
 package transaction
 
 import (
+	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
+
+	"github.com/zde37/Zero-Chain/helpers"
 )
 
 type Transaction struct {
 	SenderBlockChainAddress    string
 	RecipientBlockChainAddress string
 	Value                      float32
+	Fee                        float32
+	Nonce                      uint64
 	Hash                       [32]byte
 	TimeStamp                  string
+
+	// The authorization travels with the transaction into blocks so every node can
+	// check it, but is not part of the signing payload or the hash. Block rewards have
+	// none; transactions from multisig addresses carry Multisig instead of
+	// SenderPublicKey and Signature.
+	SenderPublicKey *ecdsa.PublicKey
+	Signature       *helpers.Signature
+	Multisig        *Multisig
 }
 
-func New(senderBlockChainAddress string, recipientBlockChainAddress string, value float32) *Transaction {
+func New(senderBlockChainAddress string, recipientBlockChainAddress string, value, fee float32, nonce uint64) *Transaction {
 	t := new(Transaction)
 	t.SenderBlockChainAddress = senderBlockChainAddress
 	t.RecipientBlockChainAddress = recipientBlockChainAddress
 	t.Value = value
+	t.Fee = fee
+	t.Nonce = nonce
 	t.TimeStamp = time.Now().String()
 	t.Hash = t.TxHash()
 
@@ -40,9 +56,40 @@ func (t *Transaction) MarshalJSON() ([]byte, error) {
 		Sender    string  `json:"sender_blockchain_address"`
 		Recipient string  `json:"recipient_blockchain_address"`
 		Value     float32 `json:"value"`
+		Fee       float32 `json:"fee"`
+		Nonce     uint64  `json:"nonce"`
 	}{
 		Sender:    t.SenderBlockChainAddress,
 		Recipient: t.RecipientBlockChainAddress,
 		Value:     t.Value,
+		Fee:       t.Fee,
+		Nonce:     t.Nonce,
 	})
 }
+
+// Request encodes the transaction with its authorization as it is sent between nodes.
+func (t *Transaction) Request() Request {
+	r := Request{
+		SenderBlockchainAddress:    t.SenderBlockChainAddress,
+		RecipientBlockchainAddress: t.RecipientBlockChainAddress,
+		Value:                      t.Value,
+		Fee:                        t.Fee,
+		Nonce:                      t.Nonce,
+	}
+	if t.SenderPublicKey != nil {
+		r.SenderPublicKey = fmt.Sprintf("%064x%064x", t.SenderPublicKey.X.Bytes(), t.SenderPublicKey.Y.Bytes())
+	}
+	if t.Signature != nil {
+		r.Signature = t.Signature.String()
+	}
+	if t.Multisig != nil {
+		r.RequiredSignatures = uint32(t.Multisig.RequiredSignatures)
+		r.PublicKeys, r.Signatures = t.Multisig.Encode()
+	}
+	return r
+}
+
+// Cost is the total amount debited from the sender: the value plus the fee.
+func (t *Transaction) Cost() float32 {
+	return t.Value + t.Fee
+}
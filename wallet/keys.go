@@ -0,0 +1,188 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/zde37/Zero-Chain/address"
+)
+
+// Private key formats accepted by ParsePrivateKey and produced by EncodePrivateKey.
+const (
+	FORMAT_HEX    = "hex"   // 64 hex characters of the private scalar, or fewer with the leading zeros dropped
+	FORMAT_SEC1   = "sec1"  // PEM "EC PRIVATE KEY" (RFC 5915)
+	FORMAT_PKCS8  = "pkcs8" // PEM "PRIVATE KEY"
+	FORMAT_WIF    = "wif"   // base58check of the scalar with the network's key version byte
+	FORMAT_PEM    = "pem"   // public keys only: PEM "PUBLIC KEY" (PKIX)
+	FORMAT_DETECT = ""      // guess the format from the input
+)
+
+var ErrInvalidKey = errors.New("wallet: invalid private key")
+
+// ParsePrivateKey decodes a P-256 private key in the given format, or in whichever
+// format it looks like when format is FORMAT_DETECT.
+func ParsePrivateKey(s, format string) (*ecdsa.PrivateKey, error) {
+	s = strings.TrimSpace(s)
+	if format == FORMAT_DETECT {
+		format = detectFormat(s)
+	}
+
+	switch format {
+	case FORMAT_HEX:
+		return ParsePrivateKeyHex(s)
+	case FORMAT_SEC1, FORMAT_PKCS8, FORMAT_PEM:
+		return parsePrivateKeyPEM(s)
+	case FORMAT_WIF:
+		return parsePrivateKeyWIF(s)
+	default:
+		return nil, fmt.Errorf("wallet: unknown key format %q", format)
+	}
+}
+
+// ParsePrivateKeyHex decodes a hex private scalar and derives its public key. Scalars
+// shorter than 64 characters are left-padded with zeros. It replaces
+// helpers.PrivateKeyFromString, which trusted the caller's public key and only logged
+// decoding errors.
+func ParsePrivateKeyHex(s string) (*ecdsa.PrivateKey, error) {
+	if s == "" || len(s) > 64 {
+		return nil, ErrInvalidKey
+	}
+	b, err := hex.DecodeString(strings.Repeat("0", 64-len(s)) + s)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return PrivateKeyFromBytes(b)
+}
+
+// ParsePublicKeyHex decodes a public key given as hex X followed by hex Y.
+func ParsePublicKeyHex(s string) (*ecdsa.PublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 64 {
+		return nil, fmt.Errorf("wallet: public key must be 128 hex characters")
+	}
+	curve := elliptic.P256()
+	x, y := new(big.Int).SetBytes(b[:32]), new(big.Int).SetBytes(b[32:])
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("wallet: public key is not on the P-256 curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// EncodePrivateKey encodes key in one of the private key formats; WIF keys carry the
+// active network's key version.
+func EncodePrivateKey(key *ecdsa.PrivateKey, format string) (string, error) {
+	switch format {
+	case FORMAT_HEX, FORMAT_DETECT:
+		return hex.EncodeToString(key.D.FillBytes(make([]byte, 32))), nil
+	case FORMAT_SEC1:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return "", fmt.Errorf("wallet: failed to encode key: %v", err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+	case FORMAT_PKCS8:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return "", fmt.Errorf("wallet: failed to encode key: %v", err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+	case FORMAT_WIF:
+		return base58.CheckEncode(key.D.FillBytes(make([]byte, 32)), address.ActiveNetwork().KeyVersion), nil
+	default:
+		return "", fmt.Errorf("wallet: unknown key format %q", format)
+	}
+}
+
+// EncodePublicKey encodes a public key as hex X followed by hex Y, or as PEM.
+func EncodePublicKey(publicKey *ecdsa.PublicKey, format string) (string, error) {
+	switch format {
+	case FORMAT_HEX, FORMAT_DETECT:
+		return fmt.Sprintf("%064x%064x", publicKey.X.Bytes(), publicKey.Y.Bytes()), nil
+	case FORMAT_PEM:
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			return "", fmt.Errorf("wallet: failed to encode public key: %v", err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+	default:
+		return "", fmt.Errorf("wallet: unknown public key format %q", format)
+	}
+}
+
+// detectFormat guesses the format of a private key. Hex keys may have lost their
+// leading zeros, so any even-length hex string of up to 64 characters is taken as
+// hex. A base58 WIF key of around 50 characters is all but never also valid hex.
+func detectFormat(s string) string {
+	switch {
+	case strings.HasPrefix(s, "-----BEGIN"):
+		return FORMAT_PEM
+	case len(s) > 0 && len(s) <= 64 && len(s)%2 == 0:
+		if _, err := hex.DecodeString(s); err == nil {
+			return FORMAT_HEX
+		}
+	}
+	return FORMAT_WIF
+}
+
+func parsePrivateKeyPEM(s string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("wallet: no PEM block found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("wallet: unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("wallet: failed to parse %s: %v", block.Type, err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("wallet: only P-256 keys are supported")
+	}
+	return ecKey, nil
+}
+
+func parsePrivateKeyWIF(s string) (*ecdsa.PrivateKey, error) {
+	b, version, err := base58.CheckDecode(s)
+	if err != nil || len(b) != 32 {
+		return nil, ErrInvalidKey
+	}
+	network, err := address.NetworkByKeyVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if network != address.ActiveNetwork() {
+		return nil, fmt.Errorf("wallet: key belongs to %s, not %s", network.Name, address.ActiveNetwork().Name)
+	}
+	return PrivateKeyFromBytes(b)
+}
+
+// PrivateKeyFromBytes builds a P-256 key from its big-endian private scalar.
+func PrivateKeyFromBytes(b []byte) (*ecdsa.PrivateKey, error) {
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(b)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	key := &ecdsa.PrivateKey{D: d}
+	key.PublicKey.Curve = curve
+	key.PublicKey.X, key.PublicKey.Y = curve.ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+	return key, nil
+}
@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/address"
+)
+
+// shortKey returns a key whose private scalar has at least one leading zero byte.
+func shortKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	d := new(big.Int).SetBytes([]byte{0x7f, 0x01, 0x02, 0x03})
+	d.Lsh(d, 8*24) // 28 significant bytes
+	key, err := PrivateKeyFromBytes(d.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestPrivateKeyFormats(t *testing.T) {
+	defer address.SetNetwork(address.ActiveNetwork())
+	address.SetNetwork(address.Mainnet)
+
+	for _, key := range []*ecdsa.PrivateKey{New().PrivateKey, shortKey(t)} {
+		for _, format := range []string{FORMAT_HEX, FORMAT_SEC1, FORMAT_PKCS8, FORMAT_WIF} {
+			s, err := EncodePrivateKey(key, format)
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			if got := detectFormat(strings.TrimSpace(s)); format == FORMAT_HEX && got != FORMAT_HEX ||
+				format == FORMAT_WIF && got != FORMAT_WIF {
+				t.Errorf("%s key detected as %s", format, got)
+			}
+			for _, f := range []string{format, FORMAT_DETECT} {
+				parsed, err := ParsePrivateKey(s, f)
+				if err != nil || parsed.D.Cmp(key.D) != 0 || parsed.X.Cmp(key.X) != 0 {
+					t.Errorf("%s key parsed as %q: %v", format, f, err)
+				}
+			}
+		}
+	}
+}
+
+// TestShortHexKeys parses hex keys whose leading zeros were dropped by the tool that
+// exported them.
+func TestShortHexKeys(t *testing.T) {
+	key := shortKey(t)
+	short := key.D.Text(16) // 56 characters
+	if len(short) != 56 {
+		t.Fatalf("test key has %d hex characters", len(short))
+	}
+	for _, s := range []string{short, "01", "0" + short[:len(short)-1]} {
+		if detectFormat(s) != FORMAT_HEX {
+			t.Errorf("%q not detected as hex", s)
+		}
+	}
+
+	parsed, err := ParsePrivateKey(short, FORMAT_DETECT)
+	if err != nil || parsed.D.Cmp(key.D) != 0 {
+		t.Fatalf("short hex key: %v", err)
+	}
+	if parsed, err := ParsePrivateKey("1", FORMAT_HEX); err != nil || parsed.D.Int64() != 1 {
+		t.Fatalf("odd-length hex key: %v", err)
+	}
+	n := elliptic.P256().Params().N.Text(16)
+	for _, s := range []string{"", "00", "zz", n, strings.Repeat("1", 66)} {
+		if _, err := ParsePrivateKey(s, FORMAT_HEX); err == nil {
+			t.Errorf("accepted hex key %q", s)
+		}
+	}
+}
+
+func TestWIFNetwork(t *testing.T) {
+	defer address.SetNetwork(address.ActiveNetwork())
+	address.SetNetwork(address.Testnet)
+	s, err := EncodePrivateKey(New().PrivateKey, FORMAT_WIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address.SetNetwork(address.Mainnet)
+	if _, err := ParsePrivateKey(s, FORMAT_WIF); err == nil {
+		t.Fatal("accepted a testnet key on mainnet")
+	}
+}
+
+func TestParsePublicKeyHex(t *testing.T) {
+	w := New()
+	s, err := EncodePublicKey(w.PublicKey, FORMAT_HEX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParsePublicKeyHex(s)
+	if err != nil || !pub.Equal(w.PublicKey) {
+		t.Fatalf("ParsePublicKeyHex: %v", err)
+	}
+	if _, err := ParsePublicKeyHex(strings.Repeat("0", 128)); err == nil {
+		t.Fatal("accepted a point that is not on the curve")
+	}
+}
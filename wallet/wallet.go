@@ -4,12 +4,10 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"crypto/sha256"
 	"fmt"
 	"log"
 
-	"github.com/btcsuite/btcd/btcutil/base58"
-	"golang.org/x/crypto/ripemd160"
+	"github.com/zde37/Zero-Chain/address"
 )
 
 type Wallet struct {
@@ -19,11 +17,32 @@ type Wallet struct {
 }
 
 type TransactionRequest struct {
+	WalletName                 string // sign with this unlocked keystore wallet instead of SenderPrivateKey
 	SenderPrivateKey           string
 	SenderBlockchainAddress    string
 	RecipientBlockchainAddress string
 	SenderPublicKey            string
 	Value                      float32
+	Fee                        float32
+	Nonce                      *uint64 // nil means the sender's next account nonce
+}
+
+// CancelRequest replaces the sender's pending transaction carrying Nonce with a
+// zero-value transfer to the sender itself, paying Fee.
+type CancelRequest struct {
+	WalletName              string
+	SenderPrivateKey        string
+	SenderBlockchainAddress string
+	SenderPublicKey         string
+	Nonce                   uint64
+	Fee                     float32
+}
+
+// PartialSignature is one cosigner's signature of a transaction sent from a multisig
+// address.
+type PartialSignature struct {
+	PublicKey string
+	Signature string
 }
 
 func New() *Wallet {
@@ -35,42 +54,19 @@ func New() *Wallet {
 	}
 	w.PrivateKey = privateKey
 	w.PublicKey = &w.PrivateKey.PublicKey
-
-	h2 := sha256.New()
-	h2.Write(w.PublicKey.X.Bytes())
-	h2.Write(w.PublicKey.Y.Bytes())
-	digest2 := h2.Sum(nil)
-
-	h3 := ripemd160.New()
-	h3.Write(digest2)
-	digest3 := h3.Sum(nil)
-
-	vd4 := make([]byte, 21)
-	vd4[0] = 0x00
-	copy(vd4[1:], digest3[:])
-
-	h5 := sha256.New()
-	h5.Write(vd4)
-	digest5 := h5.Sum(nil)
-
-	h6 := sha256.New()
-	h6.Write(digest5)
-	digest6 := h6.Sum(nil)
-
-	checkSum := digest6[:4]
-
-	dc8 := make([]byte, 25)
-	copy(dc8[:21], vd4[:])
-	copy(dc8[21:], checkSum[:])
-
-	address := base58.Encode(dc8)
-	w.BlockchainAddress = address
+	w.BlockchainAddress = Address(w.PublicKey)
 
 	return w
 }
 
+// Address derives the base58check blockchain address of a public key on the active
+// network; see address.FromPublicKey.
+func Address(publicKey *ecdsa.PublicKey) string {
+	return address.FromPublicKey(publicKey).String()
+}
+
 func (w *Wallet) PrivateKeyStr() string {
-	return fmt.Sprintf("%x", w.PrivateKey.D.Bytes())
+	return fmt.Sprintf("%064x", w.PrivateKey.D)
 }
 
 func (w *Wallet) PublicKeyStr() string {
@@ -0,0 +1,248 @@
+// Package watchonly keeps named groups of addresses that are monitored without
+// holding their keys.
+package watchonly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+)
+
+const MAX_GROUP_ADDRESSES = 1000
+
+var (
+	ErrNotFound = errors.New("watchonly: group not found")
+	ErrExists   = errors.New("watchonly: group already exists")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+type Group struct {
+	Name      string    `json:"name"`
+	Addresses []string  `json:"addresses"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store persists groups as one JSON file.
+type Store struct {
+	path     string
+	mut      sync.Mutex
+	groups   map[string]*Group
+	watchers map[string]map[chan []string]struct{}
+}
+
+func New(path string) (*Store, error) {
+	s := &Store{path: path, groups: make(map[string]*Group), watchers: make(map[string]map[chan []string]struct{})}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watchonly: failed to read %s: %v", path, err)
+	}
+	var groups []*Group
+	if err := json.Unmarshal(b, &groups); err != nil {
+		return nil, fmt.Errorf("watchonly: failed to decode %s: %v", path, err)
+	}
+	for _, g := range groups {
+		s.groups[g.Name] = g
+	}
+	return s, nil
+}
+
+func (s *Store) Create(name string, addresses []string) (*Group, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("watchonly: name must be 1-64 letters, digits, '-' or '_'")
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if _, ok := s.groups[name]; ok {
+		return nil, ErrExists
+	}
+	g := &Group{Name: name, Addresses: make([]string, 0), CreatedAt: time.Now().UTC()}
+	if err := g.add(addresses); err != nil {
+		return nil, err
+	}
+	s.groups[name] = g
+	if err := s.save(); err != nil {
+		delete(s.groups, name)
+		return nil, err
+	}
+	return g.copy(), nil
+}
+
+// AddAddresses adds addresses to a group, ignoring ones it already watches.
+func (s *Store) AddAddresses(name string, addresses []string) (*Group, error) {
+	return s.update(name, func(g *Group) error {
+		return g.add(addresses)
+	})
+}
+
+func (s *Store) RemoveAddresses(name string, addresses []string) (*Group, error) {
+	return s.update(name, func(g *Group) error {
+		remove := make(map[string]bool, len(addresses))
+		for _, a := range addresses {
+			remove[a] = true
+		}
+		kept := g.Addresses[:0]
+		for _, a := range g.Addresses {
+			if !remove[a] {
+				kept = append(kept, a)
+			}
+		}
+		g.Addresses = kept
+		return nil
+	})
+}
+
+func (s *Store) Get(name string) (*Group, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	g, ok := s.groups[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return g.copy(), nil
+}
+
+func (s *Store) List() []*Group {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	groups := make([]*Group, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, g.copy())
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+func (s *Store) Delete(name string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	g, ok := s.groups[name]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.groups, name)
+	if err := s.save(); err != nil {
+		s.groups[name] = g
+		return err
+	}
+	for ch := range s.watchers[name] {
+		close(ch)
+	}
+	delete(s.watchers, name)
+	return nil
+}
+
+// Watch returns a group along with a channel that receives its addresses whenever they
+// change. The channel only holds the latest set and is closed when the group is
+// deleted; the returned function stops watching.
+func (s *Store) Watch(name string) (*Group, <-chan []string, func(), error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	g, ok := s.groups[name]
+	if !ok {
+		return nil, nil, nil, ErrNotFound
+	}
+	ch := make(chan []string, 1)
+	if s.watchers[name] == nil {
+		s.watchers[name] = make(map[chan []string]struct{})
+	}
+	s.watchers[name][ch] = struct{}{}
+
+	return g.copy(), ch, func() {
+		s.mut.Lock()
+		defer s.mut.Unlock()
+		delete(s.watchers[name], ch)
+	}, nil
+}
+
+// update applies f to a copy of the group and keeps the result once it is saved.
+func (s *Store) update(name string, f func(g *Group) error) (*Group, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	old, ok := s.groups[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	g := old.copy()
+	if err := f(g); err != nil {
+		return nil, err
+	}
+	s.groups[name] = g
+	if err := s.save(); err != nil {
+		s.groups[name] = old
+		return nil, err
+	}
+	for ch := range s.watchers[name] {
+		select {
+		case <-ch: // replace a set the watcher hasn't picked up yet
+		default:
+		}
+		ch <- g.copy().Addresses
+	}
+	return g.copy(), nil
+}
+
+// save writes all groups to a temporary file and renames it over the store's path;
+// the caller must hold s.mut.
+func (s *Store) save() error {
+	groups := make([]*Group, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	b, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return fmt.Errorf("watchonly: failed to encode groups: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("watchonly: failed to create data directory: %v", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("watchonly: failed to write %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("watchonly: failed to replace %s: %v", s.path, err)
+	}
+	return nil
+}
+
+func (g *Group) add(addresses []string) error {
+	watched := make(map[string]bool, len(g.Addresses))
+	for _, a := range g.Addresses {
+		watched[a] = true
+	}
+	for _, a := range addresses {
+		if err := address.Validate(a); err != nil {
+			return fmt.Errorf("watchonly: %s: %w", a, err)
+		}
+		if watched[a] {
+			continue
+		}
+		if len(g.Addresses) == MAX_GROUP_ADDRESSES {
+			return fmt.Errorf("watchonly: a group can watch at most %d addresses", MAX_GROUP_ADDRESSES)
+		}
+		watched[a] = true
+		g.Addresses = append(g.Addresses, a)
+	}
+	return nil
+}
+
+func (g *Group) copy() *Group {
+	c := *g
+	c.Addresses = append([]string(nil), g.Addresses...)
+	return &c
+}
@@ -0,0 +1,91 @@
+package watchonly
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.json")
+	s, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := wallet.New().BlockchainAddress, wallet.New().BlockchainAddress
+
+	if _, err := s.Create("cold", []string{a, a}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("cold", nil); !errors.Is(err, ErrExists) {
+		t.Fatalf("creating a taken name: %v, want %v", err, ErrExists)
+	}
+	if _, err := s.Create("bad name", nil); err == nil {
+		t.Fatal("accepted a name with a space")
+	}
+	if _, err := s.AddAddresses("cold", []string{"not-an-address"}); err == nil {
+		t.Fatal("accepted an invalid address")
+	}
+	g, err := s.AddAddresses("cold", []string{b, a})
+	if err != nil || !slices.Equal(g.Addresses, []string{a, b}) {
+		t.Fatalf("AddAddresses = %v, %v; want each address once", g, err)
+	}
+	g.Addresses[0] = "changed" // callers get copies
+	if g, _ := s.Get("cold"); g.Addresses[0] != a {
+		t.Fatal("a returned group shares its addresses with the store")
+	}
+
+	// groups survive a restart
+	reopened, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, err := reopened.Get("cold"); err != nil || !slices.Equal(g.Addresses, []string{a, b}) {
+		t.Fatalf("reopened group = %v, %v", g, err)
+	}
+
+	if g, err := s.RemoveAddresses("cold", []string{a}); err != nil || !slices.Equal(g.Addresses, []string{b}) {
+		t.Fatalf("RemoveAddresses = %v, %v", g, err)
+	}
+	if err := s.Delete("cold"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("cold"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s, _ := New(filepath.Join(t.TempDir(), "watch.json"))
+	a, b, c := wallet.New().BlockchainAddress, wallet.New().BlockchainAddress, wallet.New().BlockchainAddress
+	if _, err := s.Create("cold", []string{a}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := s.Watch("hot"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("watching a missing group: %v", err)
+	}
+
+	g, changes, stop, err := s.Watch("cold")
+	if err != nil || !slices.Equal(g.Addresses, []string{a}) {
+		t.Fatalf("Watch = %v, %v", g, err)
+	}
+	s.AddAddresses("cold", []string{b})
+	s.AddAddresses("cold", []string{c})
+	if got := <-changes; !slices.Equal(got, []string{a, b, c}) {
+		t.Fatalf("changed addresses = %v, want only the latest set", got)
+	}
+	select {
+	case got := <-changes:
+		t.Fatalf("received a stale set %v", got)
+	default:
+	}
+
+	s.Delete("cold")
+	if _, ok := <-changes; ok {
+		t.Fatal("the channel stayed open after the group was deleted")
+	}
+	stop()
+}
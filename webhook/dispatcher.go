@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zde37/Zero-Chain/address"
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+const (
+	DELIVERY_INTERVAL    = time.Second
+	DELIVERY_TIMEOUT     = 10 * time.Second
+	MAX_DELIVERY_HISTORY = 1000 // finished deliveries kept, oldest are forgotten first
+)
+
+// Chain is the part of the blockchain the dispatcher watches. Its subscription must not
+// drop events, or notifications would silently go missing.
+type Chain interface {
+	SubscribeLossless() (<-chan blockchain.Event, func())
+	GetTransaction(hash [32]byte) blockchain.TxRecord
+}
+
+// Dispatcher keeps the hook registry, turns chain events into deliveries and posts
+// them with retries. Its whole state is persisted to a JSON file after every change.
+type Dispatcher struct {
+	Client *http.Client
+
+	chain Chain
+	path  string
+	mut   sync.Mutex
+	state *state
+	sent  map[deliveryKey]int // state.Deliveries counted by hook, event and transaction
+
+	stopOnce    sync.Once
+	done        chan struct{}
+	unsubscribe func()
+}
+
+type deliveryKey struct {
+	hookID, event, txHash string
+}
+
+func New(chain Chain, path string) (*Dispatcher, error) {
+	s, err := loadState(path)
+	if err != nil {
+		return nil, err
+	}
+	d := &Dispatcher{
+		Client: &http.Client{Timeout: DELIVERY_TIMEOUT},
+		chain:  chain,
+		path:   path,
+		state:  s,
+		sent:   make(map[deliveryKey]int, len(s.Deliveries)),
+		done:   make(chan struct{}),
+	}
+	for _, dl := range s.Deliveries {
+		d.sent[dl.key()]++
+	}
+	d.prune()
+	return d, nil
+}
+
+// Run starts watching the chain and delivering notifications in the background.
+func (d *Dispatcher) Run() {
+	events, unsubscribe := d.chain.SubscribeLossless()
+	d.mut.Lock()
+	d.unsubscribe = unsubscribe
+	d.mut.Unlock()
+	go d.watch(events)
+	go d.deliver()
+}
+
+// Stop stops watching the chain and delivering notifications. A delivery in flight
+// still has its outcome recorded.
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		d.mut.Lock()
+		unsubscribe := d.unsubscribe
+		d.mut.Unlock()
+		if unsubscribe != nil {
+			unsubscribe()
+		}
+		close(d.done)
+	})
+}
+
+func (d *Dispatcher) Register(h Hook) (*Hook, error) {
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook: invalid url %q", h.URL)
+	}
+	for _, a := range h.Addresses {
+		if err := address.Validate(a); err != nil {
+			return nil, fmt.Errorf("webhook: invalid address %q: %v", a, err)
+		}
+	}
+	for _, e := range h.Events {
+		if e != EVENT_TX_ACCEPTED && e != EVENT_TX_CONFIRMED {
+			return nil, fmt.Errorf("webhook: unknown event %q", e)
+		}
+	}
+	if h.Confirmations < 0 {
+		return nil, fmt.Errorf("webhook: confirmations must not be negative")
+	}
+	if h.Confirmations == 0 {
+		h.Confirmations = DEFAULT_CONFIRMATIONS
+	}
+	h.ID = newID()
+	h.CreatedAt = time.Now().UTC()
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	d.state.Hooks = append(d.state.Hooks, &h)
+	return &h, d.state.save(d.path)
+}
+
+func (d *Dispatcher) Hooks() []*Hook {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return slices.Clone(d.state.Hooks)
+}
+
+// Delete removes a hook along with its queued deliveries.
+func (d *Dispatcher) Delete(id string) error {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	i := slices.IndexFunc(d.state.Hooks, func(h *Hook) bool { return h.ID == id })
+	if i < 0 {
+		return fmt.Errorf("webhook: hook %s not found", id)
+	}
+	d.state.Hooks = slices.Delete(d.state.Hooks, i, i+1)
+	d.state.Deliveries = slices.DeleteFunc(d.state.Deliveries, func(dl *Delivery) bool {
+		if dl.HookID == id && dl.Status == DELIVERY_PENDING {
+			d.forget(dl)
+			return true
+		}
+		return false
+	})
+	d.state.Awaiting = slices.DeleteFunc(d.state.Awaiting, func(a awaiting) bool { return a.HookID == id })
+	return d.state.save(d.path)
+}
+
+// Deliveries lists the recorded deliveries of a hook, oldest first.
+func (d *Dispatcher) Deliveries(hookID string) []Delivery {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	deliveries := make([]Delivery, 0)
+	for _, dl := range d.state.Deliveries {
+		if dl.HookID == hookID {
+			deliveries = append(deliveries, *dl)
+		}
+	}
+	return deliveries
+}
+
+func (d *Dispatcher) watch(events <-chan blockchain.Event) {
+	for e := range events {
+		switch e.Type {
+		case blockchain.EVENT_TX_ACCEPTED:
+			d.onAccepted(e.Transaction)
+		case blockchain.EVENT_BLOCK_CONNECTED:
+			d.onBlock(e.Block)
+		}
+	}
+}
+
+func (d *Dispatcher) onAccepted(t *transaction.Transaction) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	changed := false
+	for _, h := range d.state.Hooks {
+		if h.matches(EVENT_TX_ACCEPTED, t) {
+			d.enqueue(h, EVENT_TX_ACCEPTED, blockchain.TxRecord{Transaction: t, Status: blockchain.TX_STATUS_PENDING})
+			changed = true
+		}
+	}
+	if changed {
+		d.persist()
+	}
+}
+
+// onBlock starts tracking the confirmations of the block's transactions and notifies
+// every hook whose tracked transactions are now buried deep enough.
+func (d *Dispatcher) onBlock(b *blockchain.Block) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	changed := false
+	for _, t := range b.Transactions {
+		for _, h := range d.state.Hooks {
+			a := awaiting{HookID: h.ID, TxHash: t.Hash}
+			if h.matches(EVENT_TX_CONFIRMED, t) && !slices.Contains(d.state.Awaiting, a) &&
+				d.sent[deliveryKey{h.ID, EVENT_TX_CONFIRMED, fmt.Sprintf("%x", t.Hash)}] == 0 {
+				d.state.Awaiting = append(d.state.Awaiting, a)
+				changed = true
+			}
+		}
+	}
+
+	remaining := d.state.Awaiting[:0]
+	for _, a := range d.state.Awaiting {
+		i := slices.IndexFunc(d.state.Hooks, func(h *Hook) bool { return h.ID == a.HookID })
+		record := d.chain.GetTransaction(a.TxHash)
+		switch {
+		case i < 0 || record.Status == blockchain.TX_STATUS_UNKNOWN:
+			// hook deleted or transaction replaced; nothing left to report
+			changed = true
+		case record.Status == blockchain.TX_STATUS_CONFIRMED && record.Confirmations >= d.state.Hooks[i].Confirmations:
+			d.enqueue(d.state.Hooks[i], EVENT_TX_CONFIRMED, record)
+			changed = true
+		default:
+			remaining = append(remaining, a)
+		}
+	}
+	d.state.Awaiting = remaining
+	if changed {
+		d.persist()
+	}
+}
+
+func (dl *Delivery) key() deliveryKey {
+	return deliveryKey{dl.HookID, dl.Event, dl.TxHash}
+}
+
+// forget drops a delivery that is being removed from the index; the caller must hold d.mut.
+func (d *Dispatcher) forget(dl *Delivery) {
+	k := dl.key()
+	if d.sent[k]--; d.sent[k] <= 0 {
+		delete(d.sent, k)
+	}
+}
+
+// prune forgets the oldest finished deliveries beyond MAX_DELIVERY_HISTORY; the caller
+// must hold d.mut.
+func (d *Dispatcher) prune() {
+	finished := 0
+	for _, dl := range d.state.Deliveries {
+		if dl.Status != DELIVERY_PENDING {
+			finished++
+		}
+	}
+	excess := finished - MAX_DELIVERY_HISTORY
+	if excess <= 0 {
+		return
+	}
+	d.state.Deliveries = slices.DeleteFunc(d.state.Deliveries, func(dl *Delivery) bool {
+		if excess > 0 && dl.Status != DELIVERY_PENDING {
+			excess--
+			d.forget(dl)
+			return true
+		}
+		return false
+	})
+}
+
+// enqueue records a new delivery; the caller must hold d.mut.
+func (d *Dispatcher) enqueue(h *Hook, event string, record blockchain.TxRecord) {
+	now := time.Now().UTC()
+	p := Payload{
+		DeliveryID:    newID(),
+		HookID:        h.ID,
+		Event:         event,
+		Transaction:   record.Transaction,
+		TxHash:        fmt.Sprintf("%x", record.Transaction.Hash),
+		Confirmations: record.Confirmations,
+		Timestamp:     now.Unix(),
+	}
+	if record.Block != nil {
+		p.BlockHeight = record.Block.Index
+		p.BlockHash = fmt.Sprintf("%x", record.Block.Hash)
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		log.Printf("webhook: failed to encode payload: %v", err)
+		return
+	}
+
+	dl := &Delivery{
+		ID:          p.DeliveryID,
+		HookID:      h.ID,
+		Event:       event,
+		TxHash:      p.TxHash,
+		Payload:     body,
+		Status:      DELIVERY_PENDING,
+		NextAttempt: now,
+		CreatedAt:   now,
+	}
+	d.state.Deliveries = append(d.state.Deliveries, dl)
+	d.sent[dl.key()]++
+}
+
+// persist saves the state; the caller must hold d.mut.
+func (d *Dispatcher) persist() {
+	if err := d.state.save(d.path); err != nil {
+		log.Println(err)
+	}
+}
+
+func (d *Dispatcher) deliver() {
+	ticker := time.NewTicker(DELIVERY_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			d.deliverDue()
+		}
+	}
+}
+
+// deliverDue makes one attempt at every pending delivery whose retry time has come.
+func (d *Dispatcher) deliverDue() {
+	d.mut.Lock()
+	hooks := make(map[string]Hook)
+	for _, h := range d.state.Hooks {
+		hooks[h.ID] = *h
+	}
+	due := make([]Delivery, 0)
+	now := time.Now()
+	for _, dl := range d.state.Deliveries {
+		if _, ok := hooks[dl.HookID]; ok && dl.Status == DELIVERY_PENDING && !dl.NextAttempt.After(now) {
+			due = append(due, *dl)
+		}
+	}
+	d.mut.Unlock()
+
+	for _, dl := range due {
+		h := hooks[dl.HookID]
+		d.record(dl.ID, d.post(h.URL, h.Secret, dl))
+	}
+}
+
+func (d *Dispatcher) post(target, secret string, dl Delivery) error {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EVENT_HEADER, dl.Event)
+	req.Header.Set(SIGNATURE_HEADER, Sign(secret, dl.Payload))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// record stores the outcome of a delivery attempt and schedules the next retry.
+func (d *Dispatcher) record(id string, err error) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	i := slices.IndexFunc(d.state.Deliveries, func(dl *Delivery) bool { return dl.ID == id })
+	if i < 0 {
+		return // hook was deleted while the request was in flight
+	}
+	dl := d.state.Deliveries[i]
+	dl.Attempts++
+	switch {
+	case err == nil:
+		dl.Status = DELIVERY_DELIVERED
+		dl.LastError = ""
+		d.prune()
+	case dl.Attempts >= MAX_ATTEMPTS:
+		dl.Status = DELIVERY_FAILED
+		dl.LastError = err.Error()
+		log.Printf("webhook: giving up on delivery %s after %d attempts: %v", dl.ID, dl.Attempts, err)
+		d.prune()
+	default:
+		dl.LastError = err.Error()
+		dl.NextAttempt = time.Now().Add(backoff(dl.Attempts))
+	}
+	d.persist()
+}
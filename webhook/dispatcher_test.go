@@ -0,0 +1,347 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zde37/Zero-Chain/blockchain"
+	"github.com/zde37/Zero-Chain/chainparams"
+	"github.com/zde37/Zero-Chain/transaction"
+	"github.com/zde37/Zero-Chain/wallet"
+)
+
+var alice, bob, carol = wallet.New().BlockchainAddress, wallet.New().BlockchainAddress, wallet.New().BlockchainAddress
+
+// fakeChain reports whatever records the test gives it.
+type fakeChain struct {
+	mut          sync.Mutex
+	records      map[[32]byte]blockchain.TxRecord
+	unsubscribed bool
+}
+
+func (c *fakeChain) SubscribeLossless() (<-chan blockchain.Event, func()) {
+	events := make(chan blockchain.Event)
+	return events, func() {
+		c.mut.Lock()
+		defer c.mut.Unlock()
+		c.unsubscribed = true
+		close(events)
+	}
+}
+
+func (c *fakeChain) GetTransaction(hash [32]byte) blockchain.TxRecord {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.records[hash]
+}
+
+// receiver is a hook endpoint that fails the first failures requests.
+type receiver struct {
+	mut      sync.Mutex
+	failures int
+	bodies   [][]byte
+	events   []string
+	valid    []bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	r.bodies = append(r.bodies, body)
+	r.events = append(r.events, req.Header.Get(EVENT_HEADER))
+	r.valid = append(r.valid, req.Header.Get(SIGNATURE_HEADER) == Sign("secret", body))
+}
+
+func newTestDispatcher(t *testing.T, r *receiver, confirmations int) (*Dispatcher, *fakeChain, *Hook) {
+	t.Helper()
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	chain := &fakeChain{records: make(map[[32]byte]blockchain.TxRecord)}
+	d, err := New(chain, filepath.Join(t.TempDir(), "webhooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := d.Register(Hook{URL: srv.URL, Secret: "secret", Addresses: []string{bob}, Confirmations: confirmations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, chain, h
+}
+
+func TestDelivery(t *testing.T) {
+	r := &receiver{}
+	d, _, h := newTestDispatcher(t, r, 0)
+
+	tx := &transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob, Value: 1, Hash: [32]byte{1}}
+	d.onAccepted(&transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: carol, Value: 1})
+	d.onAccepted(tx)
+	d.deliverDue()
+
+	if len(r.bodies) != 1 || r.events[0] != EVENT_TX_ACCEPTED || !r.valid[0] {
+		t.Fatalf("received %d notifications (%v), want one signed %s", len(r.bodies), r.events, EVENT_TX_ACCEPTED)
+	}
+	var p Payload
+	if err := json.Unmarshal(r.bodies[0], &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.HookID != h.ID || p.TxHash != fmt.Sprintf("%x", tx.Hash) || p.Transaction.Value != 1 {
+		t.Fatalf("payload = %+v", p)
+	}
+	if dl := d.Deliveries(h.ID); len(dl) != 1 || dl[0].Status != DELIVERY_DELIVERED || dl[0].Attempts != 1 {
+		t.Fatalf("deliveries = %+v, want one delivered on the first attempt", dl)
+	}
+
+	// the registry and delivery history survive a restart
+	reloaded, err := New(&fakeChain{}, d.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Hooks()) != 1 || len(reloaded.Deliveries(h.ID)) != 1 {
+		t.Fatal("the reloaded dispatcher lost its state")
+	}
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	r := &receiver{failures: 1}
+	d, _, h := newTestDispatcher(t, r, 0)
+
+	d.onAccepted(&transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob})
+	d.deliverDue()
+	dl := d.Deliveries(h.ID)[0]
+	if dl.Status != DELIVERY_PENDING || dl.Attempts != 1 || dl.LastError == "" || !dl.NextAttempt.After(time.Now()) {
+		t.Fatalf("after a failed attempt: %+v, want pending with a later retry", dl)
+	}
+
+	d.deliverDue() // not due yet
+	if len(r.bodies) != 0 {
+		t.Fatal("retried before the backoff elapsed")
+	}
+
+	d.mut.Lock()
+	d.state.Deliveries[0].NextAttempt = time.Now()
+	d.mut.Unlock()
+	d.deliverDue()
+	if dl := d.Deliveries(h.ID)[0]; dl.Status != DELIVERY_DELIVERED || dl.Attempts != 2 || dl.LastError != "" || len(r.bodies) != 1 {
+		t.Fatalf("after the retry: %+v, want delivered on the second attempt", dl)
+	}
+}
+
+func TestDeliveryGivesUp(t *testing.T) {
+	r := &receiver{failures: MAX_ATTEMPTS}
+	d, _, h := newTestDispatcher(t, r, 0)
+
+	d.onAccepted(&transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob})
+	for range MAX_ATTEMPTS {
+		d.mut.Lock()
+		d.state.Deliveries[0].NextAttempt = time.Now()
+		d.mut.Unlock()
+		d.deliverDue()
+	}
+	if dl := d.Deliveries(h.ID)[0]; dl.Status != DELIVERY_FAILED || dl.Attempts != MAX_ATTEMPTS {
+		t.Fatalf("after %d failures: %+v, want failed", MAX_ATTEMPTS, dl)
+	}
+}
+
+func TestConfirmationDelivery(t *testing.T) {
+	r := &receiver{}
+	d, chain, h := newTestDispatcher(t, r, 2)
+
+	tx := &transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob, Hash: [32]byte{2}}
+	block := &blockchain.Block{Index: 1, Transactions: []*transaction.Transaction{tx}}
+	confirm := func(confirmations int) {
+		chain.mut.Lock()
+		chain.records[tx.Hash] = blockchain.TxRecord{
+			Transaction: tx, Status: blockchain.TX_STATUS_CONFIRMED, Block: block, Confirmations: confirmations,
+		}
+		chain.mut.Unlock()
+		d.onBlock(block)
+		d.deliverDue()
+	}
+
+	confirm(1)
+	if len(r.bodies) != 0 {
+		t.Fatal("notified before the hook's confirmation count was reached")
+	}
+	confirm(2)
+	confirm(3)
+	if len(r.events) != 1 || r.events[0] != EVENT_TX_CONFIRMED {
+		t.Fatalf("received %v, want a single %s", r.events, EVENT_TX_CONFIRMED)
+	}
+	var p Payload
+	if err := json.Unmarshal(r.bodies[0], &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.HookID != h.ID || p.BlockHeight != 1 || p.Confirmations != 2 {
+		t.Fatalf("payload = %+v", p)
+	}
+}
+
+// TestDeliveryHistory finishes one more delivery than the history keeps: the oldest
+// finished one is forgotten, pending ones are kept, and a confirmation already
+// delivered is not delivered again.
+func TestDeliveryHistory(t *testing.T) {
+	r := &receiver{}
+	d, chain, h := newTestDispatcher(t, r, 1)
+
+	d.mut.Lock()
+	for i := range MAX_DELIVERY_HISTORY {
+		dl := &Delivery{ID: fmt.Sprint(i), HookID: h.ID, Event: EVENT_TX_CONFIRMED, TxHash: fmt.Sprintf("%x", [32]byte{byte(i), byte(i >> 8)}), Status: DELIVERY_DELIVERED}
+		d.state.Deliveries = append(d.state.Deliveries, dl)
+		d.sent[dl.key()]++
+	}
+	pending := &Delivery{ID: "pending", HookID: h.ID, Event: EVENT_TX_ACCEPTED, Status: DELIVERY_PENDING, NextAttempt: time.Now().Add(time.Hour)}
+	d.state.Deliveries = append(d.state.Deliveries, pending)
+	d.sent[pending.key()]++
+	d.mut.Unlock()
+
+	tx := &transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob, Hash: [32]byte{1}}
+	block := &blockchain.Block{Index: 1, Transactions: []*transaction.Transaction{tx}}
+	chain.records[tx.Hash] = blockchain.TxRecord{Transaction: tx, Status: blockchain.TX_STATUS_CONFIRMED, Block: block, Confirmations: 1}
+	d.onBlock(block)
+	if len(d.state.Awaiting) != 0 || len(d.Deliveries(h.ID)) != MAX_DELIVERY_HISTORY+1 {
+		t.Fatal("queued a confirmation that was already delivered")
+	}
+
+	tx = &transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: bob, Hash: [32]byte{0xff, 0xff}}
+	chain.records[tx.Hash] = blockchain.TxRecord{Transaction: tx, Status: blockchain.TX_STATUS_CONFIRMED, Block: block, Confirmations: 1}
+	block.Transactions = []*transaction.Transaction{tx}
+	d.onBlock(block)
+	d.deliverDue()
+	if len(r.bodies) != 1 {
+		t.Fatalf("received %d notifications, want 1", len(r.bodies))
+	}
+	deliveries := d.Deliveries(h.ID)
+	if len(deliveries) != MAX_DELIVERY_HISTORY+1 || deliveries[0].ID != "1" || !slices.ContainsFunc(deliveries, func(dl Delivery) bool { return dl.ID == "pending" }) {
+		t.Fatalf("%d deliveries starting with %s, want the oldest finished one forgotten", len(deliveries), deliveries[0].ID)
+	}
+	if d.sent[deliveryKey{h.ID, EVENT_TX_CONFIRMED, fmt.Sprintf("%x", [32]byte{})}] != 0 || len(d.sent) != len(deliveries) {
+		t.Fatalf("index holds %d deliveries, want %d", len(d.sent), len(deliveries))
+	}
+}
+
+// TestPersistOnChange connects a block none of the hooks cares about: the state file
+// isn't rewritten.
+func TestPersistOnChange(t *testing.T) {
+	d, _, _ := newTestDispatcher(t, &receiver{}, 1)
+	if err := os.Remove(d.path); err != nil {
+		t.Fatal(err)
+	}
+	tx := &transaction.Transaction{SenderBlockChainAddress: alice, RecipientBlockChainAddress: carol, Hash: [32]byte{3}}
+	d.onBlock(&blockchain.Block{Index: 1, Transactions: []*transaction.Transaction{tx}})
+	if _, err := os.Stat(d.path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("state file rewritten for an unrelated block: %v", err)
+	}
+}
+
+func TestStop(t *testing.T) {
+	chain := &fakeChain{}
+	d, err := New(chain, filepath.Join(t.TempDir(), "webhooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Run()
+	d.Stop()
+	d.Stop()
+	chain.mut.Lock()
+	defer chain.mut.Unlock()
+	if !chain.unsubscribed {
+		t.Fatal("still subscribed to the chain after Stop")
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	d, err := New(&fakeChain{}, filepath.Join(t.TempDir(), "webhooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []Hook{
+		{URL: "ftp://example.com"},
+		{URL: "http://example.com", Addresses: []string{"bob"}},
+		{URL: "http://example.com", Events: []string{"tx_mined"}},
+		{URL: "http://example.com", Confirmations: -1},
+	} {
+		if _, err := d.Register(h); err == nil {
+			t.Errorf("registered %+v", h)
+		}
+	}
+	if _, err := d.Register(Hook{URL: "http://example.com", Addresses: []string{bob}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDispatcherFollowsChain runs the dispatcher on a real chain's event feed and stalls
+// it during a burst of more events than a subscriber buffer holds: every transaction is
+// still notified once the dispatcher catches up.
+func TestDispatcherFollowsChain(t *testing.T) {
+	sender := wallet.New()
+	p := chainparams.Mainnet
+	p.Name = "test"
+	p.GenesisHash = ""
+	p.Checkpoints = nil
+	p.Difficulty = 1
+	p.MineOnDemand = true
+	p.Genesis.Allocations = []chainparams.Allocation{{Address: sender.BlockchainAddress, Value: 1000}}
+	p.Emission.GenesisAllocation = 1000
+	bc := blockchain.New(wallet.New().BlockchainAddress, 0, blockchain.MINING_MODE_CONTINUOUS, p)
+
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	d, err := New(bc, filepath.Join(t.TempDir(), "webhooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Register(Hook{URL: srv.URL, Secret: "secret", Addresses: []string{bob}}); err != nil {
+		t.Fatal(err)
+	}
+	d.Run()
+	defer d.Stop()
+
+	const n = blockchain.EVENT_BUFFER_SIZE + 10
+	d.mut.Lock()
+	for nonce := range uint64(n) {
+		md := transaction.NewMetaData(sender.PrivateKey, sender.PublicKey, sender.BlockchainAddress, bob, 1, 0.1, nonce)
+		if !bc.AddTransaction(sender.BlockchainAddress, bob, 1, 0.1, nonce, sender.PublicKey, md.GenerateSignature()) {
+			t.Fatalf("transaction with nonce %d rejected", nonce)
+		}
+	}
+	if _, ok := bc.GenerateBlocks(context.Background(), 1, bc.BlockChainAddress); !ok {
+		t.Fatal("failed to generate a block")
+	}
+	d.mut.Unlock()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		r.mut.Lock()
+		counts := make(map[string]int)
+		for _, e := range r.events {
+			counts[e]++
+		}
+		r.mut.Unlock()
+		if counts[EVENT_TX_ACCEPTED] == n && counts[EVENT_TX_CONFIRMED] == n {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("received %v, want %d of each event", counts, n)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// awaiting is a transaction a hook wants to hear about once it is confirmed enough.
+type awaiting struct {
+	HookID string   `json:"hook_id"`
+	TxHash [32]byte `json:"tx_hash"`
+}
+
+// state is everything the dispatcher persists between restarts.
+type state struct {
+	Hooks      []*Hook     `json:"hooks"`
+	Deliveries []*Delivery `json:"deliveries"`
+	Awaiting   []awaiting  `json:"awaiting"`
+}
+
+func loadState(path string) (*state, error) {
+	s := new(state)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("webhook: failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("webhook: failed to decode %s: %v", path, err)
+	}
+	return s, nil
+}
+
+// save writes the state to a temporary file and renames it over path so a crash
+// never leaves a half-written file behind.
+func (s *state) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("webhook: failed to encode state: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("webhook: failed to create data directory: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("webhook: failed to write %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("webhook: failed to replace %s: %v", path, err)
+	}
+	return nil
+}
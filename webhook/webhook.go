@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/zde37/Zero-Chain/transaction"
+)
+
+const (
+	EVENT_TX_ACCEPTED  = "tx_accepted"  // the transaction entered the memory pool
+	EVENT_TX_CONFIRMED = "tx_confirmed" // the transaction reached the hook's confirmation count
+
+	DELIVERY_PENDING   = "pending"
+	DELIVERY_DELIVERED = "delivered"
+	DELIVERY_FAILED    = "failed"
+
+	SIGNATURE_HEADER = "X-Zero-Chain-Signature"
+	EVENT_HEADER     = "X-Zero-Chain-Event"
+
+	DEFAULT_CONFIRMATIONS = 1
+	MAX_ATTEMPTS          = 8
+	INITIAL_BACKOFF       = 2 * time.Second
+	MAX_BACKOFF           = 10 * time.Minute
+)
+
+// Hook is a registered notification target. Empty Addresses or Events match everything.
+type Hook struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url"`
+	Secret        string    `json:"secret"`
+	Addresses     []string  `json:"addresses"`
+	Events        []string  `json:"events"`
+	Confirmations int       `json:"confirmations"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Delivery is one notification for one hook together with its retry state.
+type Delivery struct {
+	ID          string    `json:"id"`
+	HookID      string    `json:"hook_id"`
+	Event       string    `json:"event"`
+	TxHash      string    `json:"tx_hash"`
+	Payload     []byte    `json:"payload"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Payload is the JSON body posted to a hook's URL.
+type Payload struct {
+	DeliveryID    string                   `json:"delivery_id"`
+	HookID        string                   `json:"hook_id"`
+	Event         string                   `json:"event"`
+	Transaction   *transaction.Transaction `json:"transaction"`
+	TxHash        string                   `json:"tx_hash"`
+	BlockHeight   int                      `json:"block_height"`
+	BlockHash     string                   `json:"block_hash"`
+	Confirmations int                      `json:"confirmations"`
+	Timestamp     int64                    `json:"timestamp"`
+}
+
+// matches reports whether the hook wants event for a transaction.
+func (h *Hook) matches(event string, t *transaction.Transaction) bool {
+	if len(h.Events) > 0 && !slices.Contains(h.Events, event) {
+		return false
+	}
+	if len(h.Addresses) == 0 {
+		return true
+	}
+	return slices.Contains(h.Addresses, t.SenderBlockChainAddress) ||
+		slices.Contains(h.Addresses, t.RecipientBlockChainAddress)
+}
+
+// Sign returns the hex HMAC-SHA256 of body under secret, as sent in SIGNATURE_HEADER.
+// Receivers recompute it over the raw request body to authenticate a notification.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns how long to wait before retrying a delivery that has failed attempts times.
+func backoff(attempts int) time.Duration {
+	d := INITIAL_BACKOFF << (attempts - 1)
+	if d <= 0 || d > MAX_BACKOFF {
+		return MAX_BACKOFF
+	}
+	return d
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}